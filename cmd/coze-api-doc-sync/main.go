@@ -28,18 +28,49 @@ func run(args []string, stdout io.Writer) error {
 	markdownSubdir := fs.String("markdown-subdir", "api-markdown", "markdown output subdirectory")
 	swaggerSubdir := fs.String("swagger-subdir", "api-swagger", "swagger output subdirectory")
 	httpTimeout := fs.Duration("http-timeout", 30*time.Second, "HTTP timeout")
+	fromDir := fs.String("from-dir", "", "read llms.txt and pages from a local mirror instead of HTTP")
+	recordDir := fs.String("record-dir", "", "save every fetched page into a mirror for --from-dir")
+	manifestPath := fs.String("manifest", "", "sync manifest path (default <output-root>/api-sync-manifest.yaml)")
+	prune := fs.Bool("prune", false, "delete files of pages removed from the index or no longer API docs")
+	force := fs.Bool("force", false, "rewrite every page even when unchanged")
+	concurrency := fs.Int("concurrency", 4, "number of pages fetched at once")
+	retries := fs.Int("retries", 3, "retries for 5xx, 429 and timed-out requests")
+	retryBackoff := fs.Duration("retry-backoff", 500*time.Millisecond, "wait before the first retry, doubled after each")
+	hostInterval := fs.Duration("host-interval", 100*time.Millisecond, "minimum time between requests to one host")
+	continueOnError := fs.Bool("continue-on-error", false, "report pages that fail to fetch instead of failing the run")
 
 	if err := fs.Parse(args); err != nil {
 		return err
 	}
+	if *fromDir != "" && *recordDir != "" {
+		return fmt.Errorf("--from-dir and --record-dir cannot be used together")
+	}
+
+	httpFetcher := apidocsync.NewHTTPFetcher(*httpTimeout)
+	httpFetcher.Retries = *retries
+	httpFetcher.Backoff = *retryBackoff
+	httpFetcher.HostInterval = *hostInterval
+	var fetcher apidocsync.Fetcher = httpFetcher
+	if *fromDir != "" {
+		fetcher = &apidocsync.DirFetcher{Dir: *fromDir}
+	}
+	if *recordDir != "" {
+		fetcher = &apidocsync.RecordingFetcher{Fetcher: fetcher, Dir: *recordDir}
+	}
 
 	_, err := apidocsync.Run(context.Background(), stdout, apidocsync.Options{
-		LLMSURL:        *llmsURL,
-		Section:        *section,
-		OutputRoot:     *outputRoot,
-		MarkdownSubdir: *markdownSubdir,
-		SwaggerSubdir:  *swaggerSubdir,
-		HTTPTimeout:    *httpTimeout,
+		LLMSURL:         *llmsURL,
+		Section:         *section,
+		OutputRoot:      *outputRoot,
+		MarkdownSubdir:  *markdownSubdir,
+		SwaggerSubdir:   *swaggerSubdir,
+		HTTPTimeout:     *httpTimeout,
+		Fetcher:         fetcher,
+		ManifestPath:    *manifestPath,
+		Prune:           *prune,
+		Force:           *force,
+		Concurrency:     *concurrency,
+		ContinueOnError: *continueOnError,
 	})
 	return err
 }
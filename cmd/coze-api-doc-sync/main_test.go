@@ -5,6 +5,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -52,3 +55,38 @@ func TestRunSuccess(t *testing.T) {
 		t.Fatal("expected summary output")
 	}
 }
+
+func TestRunFromDir(t *testing.T) {
+	mirror := t.TempDir()
+	writeMirrorFile(t, filepath.Join(mirror, "docs.coze.cn", "llms.txt"),
+		"## 文档\n### developer_guides\n- [Demo](https://docs.coze.cn/api/open/docs/developer_guides/demo_api)\n")
+	writeMirrorFile(t, filepath.Join(mirror, "docs.coze.cn", "api", "open", "docs", "developer_guides", "demo_api"),
+		"# Demo API\n## 基础信息\n| 请求方式 | GET |\n| --- | --- |\n| 请求地址 | https://api.coze.cn/v1/demo |\n")
+
+	outputRoot := t.TempDir()
+	var out bytes.Buffer
+	if err := run([]string{"--from-dir", mirror, "--output-root", outputRoot}, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputRoot, "api-swagger", "demo_api.yaml")); err != nil {
+		t.Fatalf("expected swagger output: %v", err)
+	}
+}
+
+func TestRunFromDirAndRecordDirConflict(t *testing.T) {
+	var out bytes.Buffer
+	err := run([]string{"--from-dir", t.TempDir(), "--record-dir", t.TempDir()}, &out)
+	if err == nil || !strings.Contains(err.Error(), "cannot be used together") {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
+
+func writeMirrorFile(t *testing.T, filePath string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/dirdiff"
+)
+
+// runCheck reports the files in output that a regeneration would create,
+// change or delete, and fails when there is any.
+func runCheck(configPath string, swaggerPath string, lang string, output string, stdout io.Writer) error {
+	if info, err := os.Stat(output); err != nil {
+		return fmt.Errorf("stat output sdk %q: %w", output, err)
+	} else if !info.IsDir() {
+		return fmt.Errorf("output sdk %q is not a directory", output)
+	}
+
+	diffs, err := compareWithGenerated(configPath, swaggerPath, lang, output, false)
+	if err != nil {
+		return err
+	}
+	for _, diff := range diffs {
+		if _, err := fmt.Fprintf(stdout, "stale %s %s\n", staleAction(diff.Type), diff.Path); err != nil {
+			return err
+		}
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("%s sdk in %q is out of date: %d stale files, rerun without --check", lang, output, len(diffs))
+	}
+	_, err = fmt.Fprintf(stdout, "language=%s up_to_date=true output=%s\n", lang, output)
+	return err
+}
+
+// staleAction names what regeneration would do to a path; the output SDK is
+// the source side of the comparison.
+func staleAction(diffType dirdiff.DifferenceType) string {
+	switch diffType {
+	case dirdiff.MissingInTarget:
+		return "delete"
+	case dirdiff.ExtraInTarget:
+		return "create"
+	default:
+		return "update"
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunCheck(t *testing.T) {
+	tmp := t.TempDir()
+	outDir := filepath.Join(tmp, "out")
+	cfgPath := filepath.Join(tmp, "generator.yaml")
+	swaggerPath := filepath.Join(tmp, "swagger.yaml")
+
+	writeFile(t, swaggerPath, `
+paths:
+  /v3/chat:
+    post:
+      operationId: OpenApiChat
+`)
+	writeFile(t, cfgPath, `
+api:
+  packages:
+    - name: chat
+      source_dir: cozepy/chat
+      path_prefixes:
+        - /v3/chat
+  operation_mappings:
+    - path: /v3/chat
+      method: post
+      sdk_methods:
+        - chat.create
+`)
+	args := []string{"--config", cfgPath, "--swagger", swaggerPath, "--language", "python", "--output-sdk", outDir}
+	var out bytes.Buffer
+	if err := run(args, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	checkArgs := append([]string{"--check"}, args...)
+	out.Reset()
+	if err := run(checkArgs, &out); err != nil {
+		t.Fatalf("run(--check) error = %v, output:\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "up_to_date=true") {
+		t.Fatalf("unexpected check output: %q", out.String())
+	}
+
+	initPath := filepath.Join(outDir, "cozepy", "chat", "__init__.py")
+	writeFile(t, initPath, "stale\n")
+	writeFile(t, filepath.Join(outDir, "cozepy", "legacy.py"), "legacy = True\n")
+	before, err := os.ReadFile(initPath)
+	if err != nil {
+		t.Fatalf("read %s: %v", initPath, err)
+	}
+
+	out.Reset()
+	err = run(checkArgs, &out)
+	if err == nil || !strings.Contains(err.Error(), "2 stale files") {
+		t.Fatalf("expected stale files error, got %v, output:\n%s", err, out.String())
+	}
+	for _, want := range []string{"stale update cozepy/chat/__init__.py\n", "stale delete cozepy/legacy.py\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in check output, got:\n%s", want, out.String())
+		}
+	}
+	after, err := os.ReadFile(initPath)
+	if err != nil || !bytes.Equal(before, after) {
+		t.Fatalf("expected --check to leave output untouched, err=%v content=%q", err, after)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "cozepy", "legacy.py")); err != nil {
+		t.Fatalf("expected --check to keep extra files, stat err=%v", err)
+	}
+}
+
+func TestRunCheckMissingOutput(t *testing.T) {
+	var out bytes.Buffer
+	err := run([]string{"--check", "--language", "go", "--output-sdk", filepath.Join(t.TempDir(), "missing")}, &out)
+	if err == nil || !strings.Contains(err.Error(), "stat output sdk") {
+		t.Fatalf("expected missing output error, got: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/dirdiff"
+	"github.com/coze-dev/coze-sdk-gen/internal/generator"
+)
+
+// runDiff generates the SDK into a temporary directory and compares it with an
+// existing checkout, honoring diff.ignore_paths_by_language.
+func runDiff(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("coze-sdk-gen diff", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	configPath := fs.String("config", "config/generator.yaml", "path to generator config file")
+	swaggerPath := fs.String("swagger", "coze-openapi.yaml", "path to OpenAPI swagger yaml or json file")
+	languageArg := fs.String("language", "", languageUsage())
+	baselineArg := fs.String("baseline-sdk", "", "existing sdk checkout to compare against, required")
+	formatArg := fs.String("format", "text", "output format (text/json)")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	lang, err := parseLanguage(*languageArg)
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(strings.TrimSpace(*formatArg))
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format %q, supported formats: text, json", *formatArg)
+	}
+	baseline := strings.TrimSpace(*baselineArg)
+	if baseline == "" {
+		return fmt.Errorf("--baseline-sdk is required")
+	}
+	if info, err := os.Stat(baseline); err != nil {
+		return fmt.Errorf("stat baseline sdk %q: %w", baseline, err)
+	} else if !info.IsDir() {
+		return fmt.Errorf("baseline sdk %q is not a directory", baseline)
+	}
+
+	diffs, err := compareWithGenerated(*configPath, *swaggerPath, lang, baseline, true)
+	if err != nil {
+		return err
+	}
+	report := dirdiff.NewReport(baseline, "generated", diffs)
+	if format == "json" {
+		err = report.WriteJSON(stdout)
+	} else {
+		err = report.WriteText(stdout, "baseline", "generated")
+	}
+	if err != nil {
+		return err
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("generated %s sdk differs from %q: %d differences", lang, baseline, len(diffs))
+	}
+	if format == "json" {
+		return nil
+	}
+	_, err = fmt.Fprintf(stdout, "language=%s differences=0 baseline=%s\n", lang, baseline)
+	return err
+}
+
+// compareWithGenerated regenerates the SDK into a temporary directory and
+// compares dir against it; unified hunks are only computed with withHunks.
+// dir itself is never modified.
+func compareWithGenerated(configPath string, swaggerPath string, lang string, dir string, withHunks bool) ([]dirdiff.Difference, error) {
+	generated, err := os.MkdirTemp("", "coze-sdk-gen-"+lang+"-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp output directory: %w", err)
+	}
+	defer os.RemoveAll(generated)
+
+	cfg, doc, err := loadInputs(configPath, swaggerPath, lang, generated)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := generator.Run(cfg, doc); err != nil {
+		return nil, err
+	}
+	diffs, err := dirdiff.CompareDirs(dir, generated, cfg.DiffIgnorePathsForLanguage(lang))
+	if err != nil || !withHunks {
+		return diffs, err
+	}
+	if err := dirdiff.AttachHunks(dir, generated, diffs); err != nil {
+		return nil, err
+	}
+	return diffs, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/dirdiff"
+)
+
+func TestRunDiff(t *testing.T) {
+	tmp := t.TempDir()
+	baseline := filepath.Join(tmp, "baseline")
+	cfgPath := filepath.Join(tmp, "generator.yaml")
+	swaggerPath := filepath.Join(tmp, "swagger.yaml")
+
+	writeFile(t, swaggerPath, `
+paths:
+  /v3/chat:
+    post:
+      operationId: OpenApiChat
+`)
+	writeFile(t, cfgPath, `
+api:
+  packages:
+    - name: chat
+      source_dir: cozepy/chat
+      path_prefixes:
+        - /v3/chat
+  operation_mappings:
+    - path: /v3/chat
+      method: post
+      sdk_methods:
+        - chat.create
+diff:
+  ignore_paths_by_language:
+    python:
+      - README.md
+`)
+	var out bytes.Buffer
+	if err := run([]string{"--config", cfgPath, "--swagger", swaggerPath, "--language", "python", "--output-sdk", baseline}, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	writeFile(t, filepath.Join(baseline, "README.md"), "ignored")
+
+	diffArgs := []string{"diff", "--config", cfgPath, "--swagger", swaggerPath, "--language", "python", "--baseline-sdk", baseline}
+	out.Reset()
+	if err := run(diffArgs, &out); err != nil {
+		t.Fatalf("run(diff) error = %v, output:\n%s", err, out.String())
+	}
+	if !strings.Contains(out.String(), "differences=0") {
+		t.Fatalf("unexpected diff output: %q", out.String())
+	}
+
+	initPath := filepath.Join(baseline, "cozepy", "chat", "__init__.py")
+	content, err := os.ReadFile(initPath)
+	if err != nil {
+		t.Fatalf("read %s: %v", initPath, err)
+	}
+	writeFile(t, initPath, strings.Replace(string(content), "def create", "def create_legacy", 1))
+	writeFile(t, filepath.Join(baseline, "cozepy", "legacy.py"), "legacy = True\n")
+
+	out.Reset()
+	err = run(diffArgs, &out)
+	if err == nil || !strings.Contains(err.Error(), "2 differences") {
+		t.Fatalf("expected differences error, got %v, output:\n%s", err, out.String())
+	}
+	for _, want := range []string{
+		"content_mismatch cozepy/chat/__init__.py",
+		"--- baseline/cozepy/chat/__init__.py\n+++ generated/cozepy/chat/__init__.py\n@@ ",
+		"-    def create_legacy(",
+		"+    def create(",
+		"missing_in_target cozepy/legacy.py",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in diff output, got:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	err = run(append(diffArgs, "--format", "json"), &out)
+	if err == nil {
+		t.Fatal("expected differences error for json format")
+	}
+	var report dirdiff.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("decode json report: %v\n%s", err, out.String())
+	}
+	if report.Total != 2 || report.Counts[dirdiff.ContentMismatch] != 1 || len(report.Differences[0].Hunks) == 0 {
+		t.Fatalf("unexpected json report: %+v", report)
+	}
+}
+
+func TestRunDiffRequiresBaseline(t *testing.T) {
+	var out bytes.Buffer
+	err := run([]string{"diff", "--language", "go", "--format", "yaml"}, &out)
+	if err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Fatalf("expected unsupported format error, got: %v", err)
+	}
+	err = run([]string{"diff", "--language", "go"}, &out)
+	if err == nil || !strings.Contains(err.Error(), "--baseline-sdk is required") {
+		t.Fatalf("expected missing baseline error, got: %v", err)
+	}
+	err = run([]string{"diff", "--language", "go", "--baseline-sdk", filepath.Join(t.TempDir(), "missing")}, &out)
+	if err == nil || !strings.Contains(err.Error(), "stat baseline sdk") {
+		t.Fatalf("expected missing baseline directory error, got: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/docdrift"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+// runDocDrift compares the synced per-endpoint docs with the spec and fails
+// when they disagree.
+func runDocDrift(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("coze-sdk-gen doc-drift", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	swaggerPath := fs.String("swagger", "coze-openapi.yaml", "path to OpenAPI swagger yaml or json file")
+	docsDir := fs.String("docs-dir", "docs/api-swagger", "directory of per-endpoint swagger yaml files")
+	formatArg := fs.String("format", "text", "output format (text/json)")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	format := strings.ToLower(strings.TrimSpace(*formatArg))
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format %q, supported formats: text, json", *formatArg)
+	}
+	spec, err := openapi.Load(*swaggerPath)
+	if err != nil {
+		return err
+	}
+	pages, err := docdrift.LoadPages(*docsDir)
+	if err != nil {
+		return err
+	}
+
+	report := docdrift.NewReport(docdrift.Compare(spec, pages))
+	if format == "json" {
+		err = report.WriteJSON(stdout)
+	} else {
+		err = report.WriteText(stdout)
+	}
+	if err != nil {
+		return err
+	}
+	if len(report.Findings) > 0 {
+		return fmt.Errorf("spec %q drifts from %q in %d places", *swaggerPath, *docsDir, len(report.Findings))
+	}
+	return nil
+}
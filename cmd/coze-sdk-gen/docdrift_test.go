@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/docdrift"
+)
+
+func TestRunDocDrift(t *testing.T) {
+	tmp := t.TempDir()
+	specPath := filepath.Join(tmp, "coze-openapi.yaml")
+	docsDir := filepath.Join(tmp, "api-swagger")
+	if err := os.MkdirAll(docsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, specPath, `
+paths:
+  /v1/bots/{bot_id}:
+    get:
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: string
+`)
+	writeFile(t, filepath.Join(docsDir, "retrieve_bot.yaml"), `
+paths:
+  /v1/bots/:bot_id:
+    get:
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: string
+`)
+
+	var out bytes.Buffer
+	if err := run([]string{"doc-drift", "--swagger", specPath, "--docs-dir", docsDir}, &out); err != nil {
+		t.Fatalf("run(doc-drift) error = %v\n%s", err, out.String())
+	}
+
+	writeFile(t, filepath.Join(docsDir, "retrieve_bot.yaml"), `
+paths:
+  /v1/bots/:bot_id:
+    get:
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: integer
+`)
+	out.Reset()
+	err := run([]string{"doc-drift", "--swagger", specPath, "--docs-dir", docsDir, "--format", "json"}, &out)
+	if err == nil || !strings.Contains(err.Error(), "drifts") {
+		t.Fatalf("expected drift error, got %v", err)
+	}
+	var report docdrift.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if report.TypeMismatch != 1 || report.Findings[0].Field != "path.bot_id" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestRunDocDriftInvalidFormat(t *testing.T) {
+	err := run([]string{"doc-drift", "--format", "xml"}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Fatalf("expected format error, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+	"github.com/coze-dev/coze-sdk-gen/internal/speclint"
+)
+
+// runLintSpec reports spec problems that break generation and fails when any
+// unsuppressed error remains.
+func runLintSpec(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("coze-sdk-gen lint-spec", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	swaggerPath := fs.String("swagger", "coze-openapi.yaml", "path to OpenAPI swagger yaml or json file")
+	suppressionsPath := fs.String("suppressions", "", "yaml file listing rule/location pairs to ignore")
+	formatArg := fs.String("format", "text", "output format (text/json)")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	format := strings.ToLower(strings.TrimSpace(*formatArg))
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format %q, supported formats: text, json", *formatArg)
+	}
+	var suppressions *speclint.Suppressions
+	if path := strings.TrimSpace(*suppressionsPath); path != "" {
+		loaded, err := speclint.LoadSuppressions(path)
+		if err != nil {
+			return err
+		}
+		suppressions = loaded
+	}
+	doc, err := openapi.Load(*swaggerPath)
+	if err != nil {
+		return err
+	}
+
+	report := speclint.NewReport(speclint.Lint(doc), suppressions)
+	if format == "json" {
+		err = report.WriteJSON(stdout)
+	} else {
+		err = report.WriteText(stdout)
+	}
+	if err != nil {
+		return err
+	}
+	if report.Errors > 0 {
+		return fmt.Errorf("spec %q has %d lint errors", *swaggerPath, report.Errors)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/speclint"
+)
+
+func TestRunLintSpec(t *testing.T) {
+	tmp := t.TempDir()
+	swaggerPath := filepath.Join(tmp, "swagger.yaml")
+	suppressionsPath := filepath.Join(tmp, "suppressions.yaml")
+	writeFile(t, swaggerPath, `
+paths:
+  /v1/bots/{bot_id}:
+    get:
+      operationId: GetBot
+      responses:
+        '200':
+          description: ok
+  /v1/bots:
+    get:
+      responses:
+        '200':
+          description: ok
+`)
+	writeFile(t, suppressionsPath, `
+suppressions:
+  - rule: operation-id-missing
+    location: GET /v1/bots
+`)
+
+	var out bytes.Buffer
+	err := run([]string{"lint-spec", "--swagger", swaggerPath}, &out)
+	if err == nil || !strings.Contains(err.Error(), "1 lint errors") {
+		t.Fatalf("expected lint errors, got %v", err)
+	}
+	for _, want := range []string{
+		"error path-param-undeclared GET /v1/bots/{bot_id}: path parameter \"bot_id\" is not declared",
+		"warning operation-id-missing GET /v1/bots: operationId is empty",
+		"errors=1 warnings=1 suppressed=0",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	err = run([]string{"lint-spec", "--swagger", swaggerPath, "--suppressions", suppressionsPath, "--format", "json"}, &out)
+	if err == nil {
+		t.Fatal("expected suppressions to keep unrelated errors")
+	}
+	var report speclint.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("decode json report: %v\n%s", err, out.String())
+	}
+	if report.Errors != 1 || report.Warnings != 0 || len(report.Suppressed) != 1 {
+		t.Fatalf("unexpected json report: %+v", report)
+	}
+
+	if err := run([]string{"lint-spec", "--swagger", swaggerPath, "--format", "xml"}, &out); err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Fatalf("expected unsupported format error, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -9,28 +10,52 @@ import (
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
 	"github.com/coze-dev/coze-sdk-gen/internal/generator"
+	_ "github.com/coze-dev/coze-sdk-gen/internal/generator/go"
+	_ "github.com/coze-dev/coze-sdk-gen/internal/generator/python"
+	_ "github.com/coze-dev/coze-sdk-gen/internal/generator/typescript"
 	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
 	"github.com/coze-dev/coze-sdk-gen/internal/version"
 )
 
+// stderr receives the flag usage printed for -h.
+var stderr io.Writer = os.Stderr
+
 func main() {
-	if err := run(os.Args[1:], os.Stdout); err != nil {
+	if err := run(os.Args[1:], os.Stdout); err != nil && !errors.Is(err, flag.ErrHelp) {
 		fmt.Fprintf(os.Stderr, "coze-sdk-gen: %v\n", err)
 		os.Exit(1)
 	}
 }
 
 func run(args []string, stdout io.Writer) error {
+	if len(args) > 0 && args[0] == "diff" {
+		return runDiff(args[1:], stdout)
+	}
+	if len(args) > 0 && args[0] == "lint-spec" {
+		return runLintSpec(args[1:], stdout)
+	}
+	if len(args) > 0 && args[0] == "spec-diff" {
+		return runSpecDiff(args[1:], stdout)
+	}
+	if len(args) > 0 && args[0] == "merge" {
+		return runMerge(args[1:], stdout)
+	}
+	if len(args) > 0 && args[0] == "doc-drift" {
+		return runDocDrift(args[1:], stdout)
+	}
+
 	fs := flag.NewFlagSet("coze-sdk-gen", flag.ContinueOnError)
 	fs.SetOutput(io.Discard)
 
 	showVersion := fs.Bool("version", false, "print version")
 	configPath := fs.String("config", "config/generator.yaml", "path to generator config file")
-	swaggerPath := fs.String("swagger", "coze-openapi.yaml", "path to OpenAPI swagger yaml file")
-	languageArg := fs.String("language", "", "target language (python/go), required")
+	swaggerPath := fs.String("swagger", "coze-openapi.yaml", "path to OpenAPI swagger yaml or json file")
+	languageArg := fs.String("language", "", languageUsage())
 	outputArg := fs.String("output-sdk", "", "output sdk directory, required")
+	checkArg := fs.Bool("check", false, "verify --output-sdk is up to date without writing to it")
+	dryRunArg := fs.Bool("dry-run", false, "print the files generation would create, update and delete without writing")
 
-	if err := fs.Parse(args); err != nil {
+	if err := parseFlags(fs, args); err != nil {
 		return err
 	}
 
@@ -38,29 +63,26 @@ func run(args []string, stdout io.Writer) error {
 		_, err := fmt.Fprintln(stdout, version.String())
 		return err
 	}
-	lang := strings.ToLower(strings.TrimSpace(*languageArg))
-	if lang == "" {
-		return fmt.Errorf("--language is required")
-	}
-	if lang != "python" && lang != "go" {
-		return fmt.Errorf("unsupported language %q, supported languages: python, go", lang)
+	lang, err := parseLanguage(*languageArg)
+	if err != nil {
+		return err
 	}
 	output := strings.TrimSpace(*outputArg)
 	if output == "" {
 		return fmt.Errorf("--output-sdk is required")
 	}
 
-	cfg, err := config.Load(*configPath)
-	if err != nil {
-		return err
+	if *checkArg && *dryRunArg {
+		return fmt.Errorf("--check and --dry-run cannot be used together")
 	}
-	cfg.Language = lang
-	cfg.OutputSDK = output
-	if err := cfg.Validate(); err != nil {
-		return err
+	if *checkArg {
+		return runCheck(*configPath, *swaggerPath, lang, output, stdout)
+	}
+	if *dryRunArg {
+		return runDryRun(*configPath, *swaggerPath, lang, output, stdout)
 	}
 
-	doc, err := openapi.Load(*swaggerPath)
+	cfg, doc, err := loadInputs(*configPath, *swaggerPath, lang, output)
 	if err != nil {
 		return err
 	}
@@ -80,3 +102,49 @@ func run(args []string, stdout io.Writer) error {
 	)
 	return err
 }
+
+// parseFlags parses args into fs. Flag sets discard their own output so parse
+// errors are reported once by main; on -h the usage goes to stderr instead and
+// flag.ErrHelp is returned for main to exit successfully.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		fs.SetOutput(stderr)
+		fmt.Fprintf(stderr, "Usage of %s:\n", fs.Name())
+		fs.PrintDefaults()
+	}
+	return err
+}
+
+func languageUsage() string {
+	return fmt.Sprintf("target language (%s), required", strings.Join(generator.Languages(), "/"))
+}
+
+func parseLanguage(value string) (string, error) {
+	lang := strings.ToLower(strings.TrimSpace(value))
+	if lang == "" {
+		return "", fmt.Errorf("--language is required")
+	}
+	if _, ok := generator.Lookup(lang); !ok {
+		return "", fmt.Errorf("unsupported language %q, supported languages: %s", lang, strings.Join(generator.Languages(), ", "))
+	}
+	return lang, nil
+}
+
+func loadInputs(configPath string, swaggerPath string, lang string, output string) (*config.Config, *openapi.Document, error) {
+	cfg, err := config.Load(configPath, generator.ConfigLanguages())
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg.Language = lang
+	cfg.OutputSDK = output
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := openapi.Load(swaggerPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, doc, nil
+}
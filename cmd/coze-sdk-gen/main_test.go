@@ -2,6 +2,8 @@ package main
 
 import (
 	"bytes"
+	"errors"
+	"flag"
 	"os"
 	"path/filepath"
 	"strings"
@@ -113,6 +115,26 @@ api:
 	)
 }
 
+func TestRunHelpPrintsUsage(t *testing.T) {
+	var usage bytes.Buffer
+	stderr = &usage
+	defer func() { stderr = os.Stderr }()
+
+	var out bytes.Buffer
+	if err := run([]string{"-h"}, &out); !errors.Is(err, flag.ErrHelp) {
+		t.Fatalf("expected flag.ErrHelp, got %v", err)
+	}
+	for _, want := range []string{"Usage of coze-sdk-gen:", "-output-sdk", "target language (go/python/typescript), required"} {
+		if !strings.Contains(usage.String(), want) {
+			t.Fatalf("expected usage to contain %q, got:\n%s", want, usage.String())
+		}
+	}
+	usage.Reset()
+	if err := run([]string{"diff", "--help"}, &out); !errors.Is(err, flag.ErrHelp) || !strings.Contains(usage.String(), "Usage of coze-sdk-gen diff:") {
+		t.Fatalf("expected diff usage, got %v:\n%s", err, usage.String())
+	}
+}
+
 func TestRunInvalidArgs(t *testing.T) {
 	var out bytes.Buffer
 	if err := run([]string{"--invalid-flag"}, &out); err == nil {
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/specmerge"
+)
+
+// runMerge folds the per-endpoint documents of coze-api-doc-sync into the
+// consolidated spec and reports the operations it added or changed.
+func runMerge(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("coze-sdk-gen merge", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	swaggerPath := fs.String("swagger", "coze-openapi.yaml", "consolidated OpenAPI yaml to merge into")
+	docsDir := fs.String("docs-dir", "docs/api-swagger", "directory of per-endpoint swagger yaml files")
+	outputPath := fs.String("output", "", "where to write the merged spec (default --swagger)")
+	dryRun := fs.Bool("dry-run", false, "report the merge without writing the spec")
+	formatArg := fs.String("format", "text", "output format (text/json)")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	format := strings.ToLower(strings.TrimSpace(*formatArg))
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format %q, supported formats: text, json", *formatArg)
+	}
+	doc, err := specmerge.LoadDocument(*swaggerPath)
+	if err != nil {
+		return err
+	}
+	sources, err := specmerge.LoadSources(*docsDir)
+	if err != nil {
+		return err
+	}
+	result, err := specmerge.Merge(doc, sources)
+	if err != nil {
+		return err
+	}
+	if !*dryRun {
+		output := strings.TrimSpace(*outputPath)
+		if output == "" {
+			output = *swaggerPath
+		}
+		if err := specmerge.WriteDocument(output, doc); err != nil {
+			return err
+		}
+	}
+
+	report := specmerge.NewReport(result)
+	if format == "json" {
+		return report.WriteJSON(stdout)
+	}
+	return report.WriteText(stdout)
+}
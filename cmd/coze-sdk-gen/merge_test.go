@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/specmerge"
+)
+
+func TestRunMerge(t *testing.T) {
+	tmp := t.TempDir()
+	specPath := filepath.Join(tmp, "coze-openapi.yaml")
+	docsDir := filepath.Join(tmp, "api-swagger")
+	if err := os.MkdirAll(docsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, specPath, `
+openapi: 3.0.3
+paths:
+  /v1/bots:
+    get:
+      operationId: ListBots
+`)
+	writeFile(t, filepath.Join(docsDir, "retrieve_bot.yaml"), `
+paths:
+  /v1/bots/:bot_id:
+    get:
+      operationId: CozeGetretrieve_bot
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: string
+`)
+
+	var out bytes.Buffer
+	if err := run([]string{"merge", "--swagger", specPath, "--docs-dir", docsDir, "--dry-run"}, &out); err != nil {
+		t.Fatalf("run(merge --dry-run) error = %v", err)
+	}
+	if !strings.Contains(out.String(), "added GET /v1/bots/{bot_id} (retrieve_bot)") {
+		t.Fatalf("unexpected report:\n%s", out.String())
+	}
+	if content, _ := os.ReadFile(specPath); strings.Contains(string(content), "bot_id") {
+		t.Fatal("--dry-run should not write the spec")
+	}
+
+	out.Reset()
+	if err := run([]string{"merge", "--swagger", specPath, "--docs-dir", docsDir, "--format", "json"}, &out); err != nil {
+		t.Fatalf("run(merge) error = %v", err)
+	}
+	var report specmerge.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if report.Added != 1 || len(report.Operations) != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	content, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "/v1/bots/{bot_id}:") || !strings.Contains(string(content), "operationId: ListBots") {
+		t.Fatalf("unexpected merged spec:\n%s", content)
+	}
+}
+
+func TestRunMergeInvalidFormat(t *testing.T) {
+	err := run([]string{"merge", "--format", "xml"}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "unsupported format") {
+		t.Fatalf("expected format error, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/generator"
+	"github.com/coze-dev/coze-sdk-gen/internal/generator/fsutil"
+)
+
+type planEntry struct {
+	Action string
+	Path   string
+}
+
+// runDryRun prints what a generation into output would do: every file the
+// generator writes (create, update or unchanged) and every existing file that
+// the output cleanup would delete without rewriting it.
+func runDryRun(configPath string, swaggerPath string, lang string, output string, stdout io.Writer) error {
+	generated, err := os.MkdirTemp("", "coze-sdk-gen-"+lang+"-")
+	if err != nil {
+		return fmt.Errorf("create temp output directory: %w", err)
+	}
+	defer os.RemoveAll(generated)
+
+	cfg, doc, err := loadInputs(configPath, swaggerPath, lang, generated)
+	if err != nil {
+		return err
+	}
+	if _, err := generator.Run(cfg, doc); err != nil {
+		return err
+	}
+	removed, err := fsutil.PlanCleanOutputDir(output, cfg.DiffIgnorePathsForLanguage(lang))
+	if err != nil {
+		return err
+	}
+	plan, err := buildPlan(generated, output, removed)
+	if err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	for _, entry := range plan {
+		counts[entry.Action]++
+		if _, err := fmt.Fprintf(stdout, "%s %s\n", entry.Action, entry.Path); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(
+		stdout,
+		"plan language=%s create=%d update=%d unchanged=%d delete=%d output=%s\n",
+		lang,
+		counts["create"],
+		counts["update"],
+		counts["unchanged"],
+		counts["delete"],
+		output,
+	)
+	return err
+}
+
+func buildPlan(generated string, output string, removed []string) ([]planEntry, error) {
+	written, err := listFiles(generated)
+	if err != nil {
+		return nil, err
+	}
+	plan := make([]planEntry, 0, len(written))
+	writtenSet := map[string]struct{}{}
+	for _, rel := range written {
+		writtenSet[rel] = struct{}{}
+		next, err := os.ReadFile(filepath.Join(generated, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, fmt.Errorf("read generated file %q: %w", rel, err)
+		}
+		current, err := os.ReadFile(filepath.Join(output, filepath.FromSlash(rel)))
+		switch {
+		case os.IsNotExist(err):
+			plan = append(plan, planEntry{Action: "create", Path: rel})
+		case err != nil:
+			return nil, fmt.Errorf("read output file %q: %w", rel, err)
+		case bytes.Equal(current, next):
+			plan = append(plan, planEntry{Action: "unchanged", Path: rel})
+		default:
+			plan = append(plan, planEntry{Action: "update", Path: rel})
+		}
+	}
+
+	for _, name := range removed {
+		existing, err := listFiles(filepath.Join(output, name))
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range existing {
+			rel = pathJoin(name, rel)
+			if _, ok := writtenSet[rel]; !ok {
+				plan = append(plan, planEntry{Action: "delete", Path: rel})
+			}
+		}
+	}
+	sort.SliceStable(plan, func(i, j int) bool {
+		return plan[i].Path < plan[j].Path
+	})
+	return plan, nil
+}
+
+// listFiles returns the slash-separated paths of all non-directory entries
+// under root; a root that is itself a file yields ".".
+func listFiles(root string) ([]string, error) {
+	files := make([]string, 0)
+	err := filepath.WalkDir(root, func(pathName string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, pathName)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list files in %q: %w", root, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func pathJoin(name string, rel string) string {
+	if rel == "." {
+		return name
+	}
+	return name + "/" + rel
+}
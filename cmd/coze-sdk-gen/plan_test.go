@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunDryRun(t *testing.T) {
+	tmp := t.TempDir()
+	outDir := filepath.Join(tmp, "out")
+	cfgPath := filepath.Join(tmp, "generator.yaml")
+	swaggerPath := filepath.Join(tmp, "swagger.yaml")
+
+	writeFile(t, swaggerPath, `
+paths:
+  /v3/chat:
+    post:
+      operationId: OpenApiChat
+`)
+	writeFile(t, cfgPath, `
+api:
+  packages:
+    - name: chat
+      source_dir: cozepy/chat
+      path_prefixes:
+        - /v3/chat
+  operation_mappings:
+    - path: /v3/chat
+      method: post
+      sdk_methods:
+        - chat.create
+`)
+	args := []string{"--config", cfgPath, "--swagger", swaggerPath, "--language", "python", "--output-sdk", outDir}
+	var out bytes.Buffer
+	if err := run(args, &out); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	initPath := filepath.Join(outDir, "cozepy", "chat", "__init__.py")
+	writeFile(t, initPath, "edited\n")
+	writeFile(t, filepath.Join(outDir, "cozepy", "notes.txt"), "uncommitted work\n")
+	writeFile(t, filepath.Join(outDir, "tests", "test_chat.py"), "preserved\n")
+
+	out.Reset()
+	if err := run(append([]string{"--dry-run"}, args...), &out); err != nil {
+		t.Fatalf("run(--dry-run) error = %v", err)
+	}
+	for _, want := range []string{
+		"update cozepy/chat/__init__.py\n",
+		"unchanged cozepy/__init__.py\n",
+		"delete cozepy/notes.txt\n",
+		"plan language=python create=0 update=1 ",
+		" delete=1 output=" + outDir,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in plan, got:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "tests/test_chat.py") {
+		t.Fatalf("did not expect preserved entries in plan, got:\n%s", out.String())
+	}
+	if content, err := os.ReadFile(initPath); err != nil || string(content) != "edited\n" {
+		t.Fatalf("expected --dry-run to leave output untouched, err=%v content=%q", err, content)
+	}
+
+	fresh := filepath.Join(tmp, "fresh")
+	out.Reset()
+	if err := run([]string{"--dry-run", "--config", cfgPath, "--swagger", swaggerPath, "--language", "python", "--output-sdk", fresh}, &out); err != nil {
+		t.Fatalf("run(--dry-run) on missing output error = %v", err)
+	}
+	if !strings.Contains(out.String(), "create cozepy/chat/__init__.py\n") {
+		t.Fatalf("expected create entries for a fresh output, got:\n%s", out.String())
+	}
+	if _, err := os.Stat(fresh); !os.IsNotExist(err) {
+		t.Fatalf("expected --dry-run not to create output dir, stat err=%v", err)
+	}
+
+	if err := run(append([]string{"--dry-run", "--check"}, args...), &out); err == nil {
+		t.Fatal("expected --dry-run with --check to fail")
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/generator"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+	"github.com/coze-dev/coze-sdk-gen/internal/specdiff"
+)
+
+// runSpecDiff compares two versions of the spec and fails when a change
+// breaks an operation the config maps to sdk methods.
+func runSpecDiff(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("coze-sdk-gen spec-diff", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	configPath := fs.String("config", "config/generator.yaml", "path to generator config file")
+	oldPath := fs.String("old-swagger", "", "previous OpenAPI swagger yaml or json file, required")
+	newPath := fs.String("swagger", "coze-openapi.yaml", "updated OpenAPI swagger yaml or json file")
+	allArg := fs.Bool("all", false, "compare every operation instead of the mapped ones")
+	formatArg := fs.String("format", "text", "output format (text/json)")
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	format := strings.ToLower(strings.TrimSpace(*formatArg))
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported format %q, supported formats: text, json", *formatArg)
+	}
+	if strings.TrimSpace(*oldPath) == "" {
+		return fmt.Errorf("--old-swagger is required")
+	}
+	var cfg *config.Config
+	if !*allArg {
+		loaded, err := config.Load(*configPath, generator.ConfigLanguages())
+		if err != nil {
+			return err
+		}
+		cfg = loaded
+	}
+	oldDoc, err := openapi.Load(*oldPath)
+	if err != nil {
+		return err
+	}
+	newDoc, err := openapi.Load(*newPath)
+	if err != nil {
+		return err
+	}
+
+	report := specdiff.NewReport(specdiff.Compare(oldDoc, newDoc, cfg))
+	if format == "json" {
+		err = report.WriteJSON(stdout)
+	} else {
+		err = report.WriteText(stdout)
+	}
+	if err != nil {
+		return err
+	}
+	if report.Breaking > 0 {
+		return fmt.Errorf("spec %q has %d breaking changes from %q", *newPath, report.Breaking, *oldPath)
+	}
+	return nil
+}
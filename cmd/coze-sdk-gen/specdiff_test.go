@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/specdiff"
+)
+
+func TestRunSpecDiff(t *testing.T) {
+	tmp := t.TempDir()
+	cfgPath := filepath.Join(tmp, "generator.yaml")
+	oldPath := filepath.Join(tmp, "old.yaml")
+	newPath := filepath.Join(tmp, "new.yaml")
+	writeFile(t, oldPath, `
+paths:
+  /v3/chat:
+    post:
+      operationId: OpenApiChat
+      parameters:
+        - in: query
+          name: conversation_id
+          schema:
+            type: string
+  /v1/unmapped:
+    get:
+      operationId: Unmapped
+`)
+	writeFile(t, newPath, `
+paths:
+  /v3/chat:
+    post:
+      operationId: OpenApiChat
+      parameters:
+        - in: query
+          name: conversation_id
+          schema:
+            type: string
+        - in: query
+          name: locale
+          schema:
+            type: string
+`)
+	writeFile(t, cfgPath, `
+api:
+  packages:
+    - name: chat
+      source_dir: cozepy/chat
+      path_prefixes:
+        - /v3/chat
+  operation_mappings:
+    - path: /v3/chat
+      method: post
+      sdk_methods:
+        - chat.create
+`)
+
+	var out bytes.Buffer
+	if err := run([]string{"spec-diff", "--config", cfgPath, "--old-swagger", oldPath, "--swagger", newPath}, &out); err != nil {
+		t.Fatalf("run(spec-diff) error = %v, output:\n%s", err, out.String())
+	}
+	for _, want := range []string{
+		"non-breaking field-added POST /v3/chat query.locale: optional parameter added (chat.create)",
+		"breaking=0 non_breaking=1",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	err := run([]string{"spec-diff", "--all", "--old-swagger", oldPath, "--swagger", newPath, "--format", "json"}, &out)
+	if err == nil || !strings.Contains(err.Error(), "1 breaking changes") {
+		t.Fatalf("expected breaking change error, got %v", err)
+	}
+	var report specdiff.Report
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("decode json report: %v\n%s", err, out.String())
+	}
+	if report.Breaking != 1 || report.Changes[0].Kind != specdiff.OperationRemoved {
+		t.Fatalf("unexpected json report: %+v", report)
+	}
+
+	if err := run([]string{"spec-diff", "--swagger", newPath}, &out); err == nil || !strings.Contains(err.Error(), "--old-swagger is required") {
+		t.Fatalf("expected missing old swagger error, got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package apidocsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fetcher returns the text behind a docs URL: the llms index or a page.
+type Fetcher interface {
+	Fetch(ctx context.Context, rawURL string) (string, error)
+}
+
+const (
+	defaultHTTPRetries  = 3
+	defaultRetryBackoff = 500 * time.Millisecond
+	defaultHostInterval = 100 * time.Millisecond
+)
+
+// HTTPFetcher downloads docs over HTTP. It is safe for concurrent use.
+type HTTPFetcher struct {
+	Client *http.Client
+	// Retries is how many times a request failing with 5xx, 429 or a timeout
+	// is retried, waiting Backoff before the first retry and doubling it
+	// after each.
+	Retries int
+	Backoff time.Duration
+	// HostInterval is the minimum time between requests to one host.
+	HostInterval time.Duration
+
+	mu       sync.Mutex
+	nextSlot map[string]time.Time
+}
+
+func NewHTTPFetcher(timeout time.Duration) *HTTPFetcher {
+	return &HTTPFetcher{
+		Client:       &http.Client{Timeout: timeout},
+		Retries:      defaultHTTPRetries,
+		Backoff:      defaultRetryBackoff,
+		HostInterval: defaultHostInterval,
+	}
+}
+
+// HTTPStatusError is returned for a non-2xx response.
+type HTTPStatusError struct {
+	StatusCode int
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status %d", e.StatusCode)
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) (string, error) {
+	backoff := f.Backoff
+	for attempt := 0; ; attempt++ {
+		content, err := f.fetchOnce(ctx, rawURL)
+		if err == nil || attempt >= f.Retries || !retryable(err) || ctx.Err() != nil {
+			return content, err
+		}
+		if err := sleepContext(ctx, backoff); err != nil {
+			return "", err
+		}
+		backoff *= 2
+	}
+}
+
+func (f *HTTPFetcher) fetchOnce(ctx context.Context, rawURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := f.waitForHost(ctx, req.URL.Host); err != nil {
+		return "", err
+	}
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", &HTTPStatusError{StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(string(body), "\r\n", "\n"), nil
+}
+
+// waitForHost reserves the next request slot for host and sleeps until it.
+func (f *HTTPFetcher) waitForHost(ctx context.Context, host string) error {
+	if f.HostInterval <= 0 {
+		return nil
+	}
+	f.mu.Lock()
+	if f.nextSlot == nil {
+		f.nextSlot = map[string]time.Time{}
+	}
+	now := time.Now()
+	slot := f.nextSlot[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	f.nextSlot[host] = slot.Add(f.HostInterval)
+	f.mu.Unlock()
+	return sleepContext(ctx, time.Until(slot))
+}
+
+func retryable(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500 || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// DirFetcher reads docs from a local mirror laid out by MirrorPath, such as
+// one written by RecordingFetcher.
+type DirFetcher struct {
+	Dir string
+}
+
+func (f *DirFetcher) Fetch(ctx context.Context, rawURL string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	filePath, err := MirrorPath(f.Dir, rawURL)
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("read mirrored doc: %w", err)
+	}
+	return strings.ReplaceAll(string(content), "\r\n", "\n"), nil
+}
+
+// RecordingFetcher saves every page Fetcher returns into a mirror under Dir,
+// so a later run can replay it with DirFetcher.
+type RecordingFetcher struct {
+	Fetcher Fetcher
+	Dir     string
+}
+
+func (f *RecordingFetcher) Fetch(ctx context.Context, rawURL string) (string, error) {
+	content, err := f.Fetcher.Fetch(ctx, rawURL)
+	if err != nil {
+		return "", err
+	}
+	filePath, err := MirrorPath(f.Dir, rawURL)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return "", fmt.Errorf("create mirror dir: %w", err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("record %s: %w", rawURL, err)
+	}
+	return content, nil
+}
+
+// MirrorPath maps a docs URL to its file in a mirror directory:
+// <dir>/<host>/<path>, with ":" in the host replaced by "_" and "index" for
+// directory URLs. Query strings and fragments are ignored.
+func MirrorPath(dir string, rawURL string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("parse doc url %q: %w", rawURL, err)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("doc url %q has no host", rawURL)
+	}
+	cleaned := path.Clean("/" + parsed.Path)
+	if cleaned == "/" || strings.HasSuffix(parsed.Path, "/") {
+		cleaned = path.Join(cleaned, "index")
+	}
+	host := strings.ReplaceAll(parsed.Host, ":", "_")
+	return filepath.Join(dir, host, filepath.FromSlash(strings.TrimPrefix(cleaned, "/"))), nil
+}
@@ -0,0 +1,161 @@
+package apidocsync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMirrorPath(t *testing.T) {
+	cases := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://docs.coze.cn/llms.txt", want: "docs.coze.cn/llms.txt"},
+		{url: "https://docs.coze.cn/api/open/docs/developer_guides/create_bot?lang=zh", want: "docs.coze.cn/api/open/docs/developer_guides/create_bot"},
+		{url: "http://127.0.0.1:8080/", want: "127.0.0.1_8080/index"},
+		{url: "https://docs.coze.cn/api/open/docs/", want: "docs.coze.cn/api/open/docs/index"},
+		{url: "https://docs.coze.cn/../../etc/passwd", want: "docs.coze.cn/etc/passwd"},
+	}
+	for _, tc := range cases {
+		got, err := MirrorPath("mirror", tc.url)
+		if err != nil {
+			t.Fatalf("MirrorPath(%q) error = %v", tc.url, err)
+		}
+		if want := filepath.Join("mirror", filepath.FromSlash(tc.want)); got != want {
+			t.Fatalf("MirrorPath(%q) = %q, want %q", tc.url, got, want)
+		}
+	}
+	if _, err := MirrorPath("mirror", "/llms.txt"); err == nil {
+		t.Fatal("expected error for url without host")
+	}
+}
+
+func TestRecordThenReplayOffline(t *testing.T) {
+	apiMarkdown := "# Demo API\r\n## 基础信息\r\n| 请求方式 | GET |\r\n| --- | --- |\r\n| 请求地址 | https://api.coze.cn/v1/demo |\r\n" +
+		"## 返回参数\r\n| 参数 | 类型 | 示例 | 说明 |\r\n| --- | --- | --- | --- |\r\n| code | Long | 0 | 状态 |\r\n"
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	llms := "## 文档\n### developer_guides\n- [Demo](" + server.URL + "/api/open/docs/developer_guides/demo_api)\n"
+	mux.HandleFunc("/llms.txt", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, llms)
+	})
+	mux.HandleFunc("/api/open/docs/developer_guides/demo_api", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, apiMarkdown)
+	})
+
+	mirror := t.TempDir()
+	recordedRoot := t.TempDir()
+	_, err := Run(context.Background(), io.Discard, Options{
+		LLMSURL:    server.URL + "/llms.txt",
+		Section:    "developer_guides",
+		OutputRoot: recordedRoot,
+		Fetcher:    &RecordingFetcher{Fetcher: NewHTTPFetcher(0), Dir: mirror},
+	})
+	server.Close()
+	if err != nil {
+		t.Fatalf("Run() with recording error = %v", err)
+	}
+
+	replayedRoot := t.TempDir()
+	result, err := Run(context.Background(), io.Discard, Options{
+		LLMSURL:    server.URL + "/llms.txt",
+		Section:    "developer_guides",
+		OutputRoot: replayedRoot,
+		Fetcher:    &DirFetcher{Dir: mirror},
+	})
+	if err != nil {
+		t.Fatalf("Run() from mirror error = %v", err)
+	}
+	if result.Generated != 1 {
+		t.Fatalf("expected 1 generated file, got %d", result.Generated)
+	}
+	for _, rel := range []string{"api-markdown/demo_api.md", "api-swagger/demo_api.yaml"} {
+		recorded, err := os.ReadFile(filepath.Join(recordedRoot, rel))
+		if err != nil {
+			t.Fatalf("read recorded %s: %v", rel, err)
+		}
+		replayed, err := os.ReadFile(filepath.Join(replayedRoot, rel))
+		if err != nil {
+			t.Fatalf("read replayed %s: %v", rel, err)
+		}
+		if !bytes.Equal(recorded, replayed) {
+			t.Fatalf("replayed %s differs from recorded run", rel)
+		}
+	}
+}
+
+func TestDirFetcherMissingPage(t *testing.T) {
+	_, err := (&DirFetcher{Dir: t.TempDir()}).Fetch(context.Background(), "https://docs.coze.cn/llms.txt")
+	if err == nil {
+		t.Fatal("expected error for page missing from mirror")
+	}
+}
+
+func TestHTTPFetcherRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/flaky":
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = io.WriteString(w, "ok")
+		case "/throttled":
+			calls.Add(1)
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	fetcher := &HTTPFetcher{Client: server.Client(), Retries: 3, Backoff: time.Millisecond}
+
+	content, err := fetcher.Fetch(context.Background(), server.URL+"/flaky")
+	if err != nil || content != "ok" || calls.Load() != 3 {
+		t.Fatalf("Fetch(flaky) = %q, %v after %d calls", content, err, calls.Load())
+	}
+
+	calls.Store(0)
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/throttled")
+	if err == nil || calls.Load() != 4 {
+		t.Fatalf("expected 4 attempts for 429, got %d (err %v)", calls.Load(), err)
+	}
+
+	calls.Store(0)
+	_, err = fetcher.Fetch(context.Background(), server.URL+"/missing")
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound || calls.Load() != 1 {
+		t.Fatalf("expected one attempt failing with 404, got %d (err %v)", calls.Load(), err)
+	}
+}
+
+func TestHTTPFetcherHostInterval(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	fetcher := &HTTPFetcher{Client: server.Client(), HostInterval: 20 * time.Millisecond}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := fetcher.Fetch(context.Background(), server.URL); err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("expected requests to be spaced by the host interval, took %s", elapsed)
+	}
+}
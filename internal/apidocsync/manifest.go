@@ -0,0 +1,77 @@
+package apidocsync
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultManifestName = "api-sync-manifest.yaml"
+
+// Manifest records the pages of the last sync, keyed by slug.
+type Manifest struct {
+	Pages map[string]ManifestEntry `yaml:"pages"`
+}
+
+// ManifestEntry is one synced page. SyncedAt is when its files were last
+// written; API is false for pages that did not parse as an API doc.
+type ManifestEntry struct {
+	URL      string    `yaml:"url"`
+	Hash     string    `yaml:"hash"`
+	API      bool      `yaml:"api"`
+	SyncedAt time.Time `yaml:"synced_at"`
+}
+
+// LoadManifest reads a manifest; a missing file is an empty manifest.
+func LoadManifest(filePath string) (*Manifest, error) {
+	manifest := &Manifest{Pages: map[string]ManifestEntry{}}
+	content, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read manifest %q: %w", filePath, err)
+	}
+	if err := yaml.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("parse manifest %q: %w", filePath, err)
+	}
+	if manifest.Pages == nil {
+		manifest.Pages = map[string]ManifestEntry{}
+	}
+	return manifest, nil
+}
+
+func (m *Manifest) Save(filePath string) error {
+	content, err := yaml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("create manifest dir: %w", err)
+	}
+	if err := os.WriteFile(filePath, content, 0o644); err != nil {
+		return fmt.Errorf("write manifest %q: %w", filePath, err)
+	}
+	return nil
+}
+
+func (m *Manifest) slugs() []string {
+	slugs := make([]string, 0, len(m.Pages))
+	for slug := range m.Pages {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
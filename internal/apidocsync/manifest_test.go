@@ -0,0 +1,128 @@
+package apidocsync
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func demoAPIMarkdown(path string) string {
+	return "# Demo API\n## 基础信息\n| 请求方式 | GET |\n| --- | --- |\n| 请求地址 | https://api.coze.cn" + path + " |\n" +
+		"## 返回参数\n| 参数 | 类型 | 示例 | 说明 |\n| --- | --- | --- | --- |\n| code | Long | 0 | 状态 |\n"
+}
+
+func TestRunIncremental(t *testing.T) {
+	pages := map[string]string{
+		"alpha": demoAPIMarkdown("/v1/alpha"),
+		"beta":  demoAPIMarkdown("/v1/beta"),
+		"gamma": demoAPIMarkdown("/v1/gamma"),
+		"delta": demoAPIMarkdown("/v1/delta"),
+	}
+	index := []string{"alpha", "beta", "delta", "gamma"}
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/llms.txt", func(w http.ResponseWriter, r *http.Request) {
+		var b strings.Builder
+		b.WriteString("## 文档\n### developer_guides\n")
+		for _, slug := range index {
+			b.WriteString("- [" + slug + "](" + server.URL + "/api/open/docs/developer_guides/" + slug + ")\n")
+		}
+		_, _ = io.WriteString(w, b.String())
+	})
+	mux.HandleFunc("/api/open/docs/developer_guides/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, pages[filepath.Base(r.URL.Path)])
+	})
+
+	outputRoot := t.TempDir()
+	sync := func(prune bool) Result {
+		t.Helper()
+		result, err := Run(context.Background(), io.Discard, Options{
+			LLMSURL:    server.URL + "/llms.txt",
+			OutputRoot: outputRoot,
+			Prune:      prune,
+		})
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		return result
+	}
+	assertSlugs := func(name string, got []string, want ...string) {
+		t.Helper()
+		if want == nil {
+			want = []string{}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s = %v, want %v", name, got, want)
+		}
+	}
+
+	first := sync(false)
+	assertSlugs("added", first.Added, "alpha", "beta", "delta", "gamma")
+	assertSlugs("unchanged", first.Unchanged)
+
+	alphaSwagger := filepath.Join(outputRoot, "api-swagger", "alpha.yaml")
+	if err := os.WriteFile(alphaSwagger, []byte("# hand-tweaked\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pages["beta"] = demoAPIMarkdown("/v2/beta")
+	pages["delta"] = "# Delta guide\nNo API here.\n"
+	index = []string{"alpha", "beta", "delta"}
+
+	second := sync(false)
+	assertSlugs("added", second.Added)
+	assertSlugs("updated", second.Updated, "beta")
+	assertSlugs("removed", second.Removed, "delta", "gamma")
+	assertSlugs("unchanged", second.Unchanged, "alpha")
+	if content, _ := os.ReadFile(alphaSwagger); string(content) != "# hand-tweaked\n" {
+		t.Fatalf("unchanged page was rewritten: %q", content)
+	}
+	gammaSwagger := filepath.Join(outputRoot, "api-swagger", "gamma.yaml")
+	deltaSwagger := filepath.Join(outputRoot, "api-swagger", "delta.yaml")
+	for _, path := range []string{gammaSwagger, deltaSwagger} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("removed page should be kept without prune: %v", err)
+		}
+	}
+
+	third := sync(true)
+	assertSlugs("removed", third.Removed, "delta", "gamma")
+	assertSlugs("unchanged", third.Unchanged, "alpha", "beta")
+	for _, path := range []string{gammaSwagger, deltaSwagger} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("expected pruned swagger to be deleted, stat err = %v", err)
+		}
+	}
+
+	manifest, err := LoadManifest(filepath.Join(outputRoot, defaultManifestName))
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	assertSlugs("manifest slugs", manifest.slugs(), "alpha", "beta", "delta")
+	if manifest.Pages["delta"].API {
+		t.Fatal("expected pruned delta to be recorded as a non-API page")
+	}
+	if entry := manifest.Pages["beta"]; entry.Hash != contentHash(pages["beta"]) || !entry.API || entry.SyncedAt.IsZero() {
+		t.Fatalf("unexpected manifest entry: %+v", entry)
+	}
+
+	fourth := sync(false)
+	assertSlugs("removed", fourth.Removed)
+}
+
+func TestLoadManifestMissingFile(t *testing.T) {
+	manifest, err := LoadManifest(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if len(manifest.Pages) != 0 {
+		t.Fatalf("expected empty manifest, got %+v", manifest)
+	}
+}
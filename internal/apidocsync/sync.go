@@ -2,6 +2,7 @@ package apidocsync
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -24,6 +26,7 @@ const (
 	defaultMarkdownDir = "api-markdown"
 	defaultSwaggerDir  = "api-swagger"
 	defaultHTTPTimeout = 30 * time.Second
+	defaultConcurrency = 4
 	defaultSpecVersion = "3.0.3"
 	defaultDocVersion  = "1.0.0"
 	defaultSuccessCode = "200"
@@ -70,6 +73,21 @@ type Options struct {
 	MarkdownSubdir string
 	SwaggerSubdir  string
 	HTTPTimeout    time.Duration
+	// Fetcher reads the llms index and pages; nil fetches over HTTP with
+	// HTTPTimeout.
+	Fetcher Fetcher
+	// ManifestPath records page hashes between runs so only changed pages
+	// are rewritten; defaults to api-sync-manifest.yaml under OutputRoot.
+	ManifestPath string
+	// Prune deletes the files of pages that left the index.
+	Prune bool
+	// Force rewrites every page even when its hash is unchanged.
+	Force bool
+	// Concurrency is the number of pages fetched at once.
+	Concurrency int
+	// ContinueOnError records pages that fail to fetch in Result.Failures
+	// and syncs the rest instead of failing the run.
+	ContinueOnError bool
 }
 
 // Result captures aggregate sync statistics.
@@ -80,15 +98,25 @@ type Result struct {
 	Skipped         int
 	MarkdownDir     string
 	SwaggerDir      string
+	Added           []string
+	Updated         []string
+	Removed         []string
+	Unchanged       []string
+	Failures        []PageFailure
 }
 
-// Run downloads docs in the configured section and writes markdown and Swagger files.
+// PageFailure is a page that could not be fetched in ContinueOnError mode.
+type PageFailure struct {
+	Slug  string
+	URL   string
+	Error string
+}
+
+// Run fetches docs in the configured section and writes markdown and Swagger files.
 func Run(ctx context.Context, stdout io.Writer, opts Options) (Result, error) {
 	opts = opts.withDefaults()
 
-	client := &http.Client{Timeout: opts.HTTPTimeout}
-
-	llmsContent, err := fetchText(ctx, client, opts.LLMSURL)
+	llmsContent, err := opts.Fetcher.Fetch(ctx, opts.LLMSURL)
 	if err != nil {
 		return Result{}, fmt.Errorf("fetch llms index: %w", err)
 	}
@@ -103,37 +131,87 @@ func Run(ctx context.Context, stdout io.Writer, opts Options) (Result, error) {
 
 	markdownDir := filepath.Join(opts.OutputRoot, opts.MarkdownSubdir)
 	swaggerDir := filepath.Join(opts.OutputRoot, opts.SwaggerSubdir)
-	if err := recreateDir(markdownDir); err != nil {
+	if err := os.MkdirAll(markdownDir, 0o755); err != nil {
 		return Result{}, fmt.Errorf("prepare markdown dir: %w", err)
 	}
-	if err := recreateDir(swaggerDir); err != nil {
+	if err := os.MkdirAll(swaggerDir, 0o755); err != nil {
 		return Result{}, fmt.Errorf("prepare swagger dir: %w", err)
 	}
 
+	manifest, err := LoadManifest(opts.ManifestPath)
+	if err != nil {
+		return Result{}, err
+	}
+
+	pages := fetchPages(ctx, opts.Fetcher, links, opts.Concurrency, !opts.ContinueOnError)
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+	if !opts.ContinueOnError {
+		for i, page := range pages {
+			// Pages after the first failure are cancelled; report the failure.
+			if page.err != nil && !errors.Is(page.err, context.Canceled) {
+				return Result{}, fmt.Errorf("fetch %s: %w", links[i].URL, page.err)
+			}
+		}
+	}
+
 	result := Result{
 		Section:         opts.Section,
 		TotalCandidates: len(links),
 		MarkdownDir:     markdownDir,
 		SwaggerDir:      swaggerDir,
+		Added:           []string{},
+		Updated:         []string{},
+		Removed:         []string{},
+		Unchanged:       []string{},
+		Failures:        []PageFailure{},
 	}
 
-	for _, link := range links {
-		if err := ctx.Err(); err != nil {
-			return Result{}, err
-		}
+	now := time.Now().UTC().Truncate(time.Second)
+	seen := make(map[string]struct{}, len(links))
+	for i, link := range links {
+		seen[link.Slug] = struct{}{}
 
-		markdown, err := fetchText(ctx, client, link.URL)
+		markdown, err := pages[i].markdown, pages[i].err
 		if err != nil {
-			return Result{}, fmt.Errorf("fetch %s: %w", link.URL, err)
+			result.Failures = append(result.Failures, PageFailure{Slug: link.Slug, URL: link.URL, Error: err.Error()})
+			continue
+		}
+
+		hash := contentHash(markdown)
+		markdownPath := filepath.Join(markdownDir, link.Slug+".md")
+		swaggerPath := filepath.Join(swaggerDir, link.Slug+".yaml")
+		previous, known := manifest.Pages[link.Slug]
+		if !opts.Force && known && previous.Hash == hash && previous.URL == link.URL &&
+			(!previous.API || filesExist(markdownPath, swaggerPath)) {
+			if previous.API {
+				result.Unchanged = append(result.Unchanged, link.Slug)
+			} else {
+				result.Skipped++
+			}
+			continue
 		}
 
 		apiDoc, ok := parseAPIDoc(link, markdown)
 		if !ok {
 			result.Skipped++
+			if known && previous.API {
+				// Like a page gone from the index, a page that stops parsing
+				// as an API doc keeps its files and manifest entry, and keeps
+				// being reported, until it is pruned.
+				result.Removed = append(result.Removed, link.Slug)
+				if !opts.Prune {
+					continue
+				}
+				if err := removeFiles(markdownPath, swaggerPath); err != nil {
+					return Result{}, err
+				}
+			}
+			manifest.Pages[link.Slug] = ManifestEntry{URL: link.URL, Hash: hash, SyncedAt: now}
 			continue
 		}
 
-		markdownPath := filepath.Join(markdownDir, link.Slug+".md")
 		if err := os.WriteFile(markdownPath, []byte(markdown), 0o644); err != nil {
 			return Result{}, fmt.Errorf("write markdown %s: %w", markdownPath, err)
 		}
@@ -142,22 +220,73 @@ func Run(ctx context.Context, stdout io.Writer, opts Options) (Result, error) {
 		if err != nil {
 			return Result{}, fmt.Errorf("build swagger for %s: %w", link.URL, err)
 		}
-		swaggerPath := filepath.Join(swaggerDir, link.Slug+".yaml")
 		if err := os.WriteFile(swaggerPath, swaggerYAML, 0o644); err != nil {
 			return Result{}, fmt.Errorf("write swagger %s: %w", swaggerPath, err)
 		}
 
+		manifest.Pages[link.Slug] = ManifestEntry{URL: link.URL, Hash: hash, API: true, SyncedAt: now}
+		if known && previous.API {
+			result.Updated = append(result.Updated, link.Slug)
+		} else {
+			result.Added = append(result.Added, link.Slug)
+		}
 		result.Generated++
 	}
 
+	// Pages gone from the index stay in the manifest, and keep being
+	// reported, until they are pruned.
+	for _, slug := range manifest.slugs() {
+		if _, ok := seen[slug]; ok {
+			continue
+		}
+		entry := manifest.Pages[slug]
+		if entry.API {
+			result.Removed = append(result.Removed, slug)
+			if !opts.Prune {
+				continue
+			}
+			markdownPath := filepath.Join(markdownDir, slug+".md")
+			swaggerPath := filepath.Join(swaggerDir, slug+".yaml")
+			if err := removeFiles(markdownPath, swaggerPath); err != nil {
+				return Result{}, err
+			}
+		}
+		delete(manifest.Pages, slug)
+	}
+
+	if err := manifest.Save(opts.ManifestPath); err != nil {
+		return Result{}, err
+	}
+
 	if stdout != nil {
+		for _, status := range []struct {
+			name  string
+			slugs []string
+		}{
+			{name: "added", slugs: result.Added},
+			{name: "updated", slugs: result.Updated},
+			{name: "removed", slugs: result.Removed},
+			{name: "unchanged", slugs: result.Unchanged},
+		} {
+			for _, slug := range status.slugs {
+				_, _ = fmt.Fprintf(stdout, "%s %s\n", status.name, slug)
+			}
+		}
+		for _, failure := range result.Failures {
+			_, _ = fmt.Fprintf(stdout, "failed %s: %s\n", failure.Slug, failure.Error)
+		}
 		_, _ = fmt.Fprintf(
 			stdout,
-			"section=%s total=%d generated=%d skipped=%d markdown_dir=%s swagger_dir=%s\n",
+			"section=%s total=%d generated=%d skipped=%d added=%d updated=%d removed=%d unchanged=%d failed=%d markdown_dir=%s swagger_dir=%s\n",
 			result.Section,
 			result.TotalCandidates,
 			result.Generated,
 			result.Skipped,
+			len(result.Added),
+			len(result.Updated),
+			len(result.Removed),
+			len(result.Unchanged),
+			len(result.Failures),
 			result.MarkdownDir,
 			result.SwaggerDir,
 		)
@@ -185,36 +314,79 @@ func (o Options) withDefaults() Options {
 	if o.HTTPTimeout <= 0 {
 		o.HTTPTimeout = defaultHTTPTimeout
 	}
+	if strings.TrimSpace(o.ManifestPath) == "" {
+		o.ManifestPath = filepath.Join(o.OutputRoot, defaultManifestName)
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = defaultConcurrency
+	}
+	if o.Fetcher == nil {
+		o.Fetcher = NewHTTPFetcher(o.HTTPTimeout)
+	}
 	return o
 }
 
-func fetchText(ctx context.Context, client *http.Client, rawURL string) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
-	if err != nil {
-		return "", err
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
+type fetchedPage struct {
+	markdown string
+	err      error
+}
+
+// fetchPages fetches links with a pool of workers. Results keep the order of
+// links; with stopOnError the first failure cancels the pages still pending.
+func fetchPages(ctx context.Context, fetcher Fetcher, links []docLink, concurrency int, stopOnError bool) []fetchedPage {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make([]fetchedPage, len(links))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for worker := 0; worker < concurrency; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				markdown, err := fetcher.Fetch(ctx, links[i].URL)
+				pages[i] = fetchedPage{markdown: markdown, err: err}
+				if err != nil && stopOnError {
+					cancel()
+				}
+			}
+		}()
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", fmt.Errorf("unexpected HTTP status %d", resp.StatusCode)
+	next := 0
+feed:
+	for ; next < len(links); next++ {
+		select {
+		case jobs <- next:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	for ; next < len(links); next++ {
+		pages[next].err = ctx.Err()
 	}
+	return pages
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
+func filesExist(paths ...string) bool {
+	for _, filePath := range paths {
+		if _, err := os.Stat(filePath); err != nil {
+			return false
+		}
 	}
-	return strings.ReplaceAll(string(body), "\r\n", "\n"), nil
+	return true
 }
 
-func recreateDir(dir string) error {
-	if err := os.RemoveAll(dir); err != nil {
-		return err
+func removeFiles(paths ...string) error {
+	for _, filePath := range paths {
+		if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove %s: %w", filePath, err)
+		}
 	}
-	return os.MkdirAll(dir, 0o755)
+	return nil
 }
 
 type docLink struct {
@@ -7,6 +7,8 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -274,3 +276,48 @@ func TestRunEndToEnd(t *testing.T) {
 		t.Fatalf("expected swagger file %s: %v", swaggerPath, err)
 	}
 }
+
+func TestRunContinueOnError(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	slugs := []string{"alpha", "broken", "gamma", "delta", "epsilon"}
+	llms := "## 文档\n### developer_guides\n"
+	for _, slug := range slugs {
+		llms += "- [" + slug + "](" + server.URL + "/api/open/docs/developer_guides/" + slug + ")\n"
+	}
+	mux.HandleFunc("/llms.txt", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, llms)
+	})
+	mux.HandleFunc("/api/open/docs/developer_guides/", func(w http.ResponseWriter, r *http.Request) {
+		slug := filepath.Base(r.URL.Path)
+		if slug == "broken" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, demoAPIMarkdown("/v1/"+slug))
+	})
+
+	opts := Options{
+		LLMSURL:     server.URL + "/llms.txt",
+		OutputRoot:  t.TempDir(),
+		Concurrency: 3,
+		Fetcher:     &HTTPFetcher{Client: server.Client()},
+	}
+	if _, err := Run(context.Background(), io.Discard, opts); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Fatalf("expected fetch error for broken page, got %v", err)
+	}
+
+	opts.ContinueOnError = true
+	result, err := Run(context.Background(), io.Discard, opts)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(result.Failures) != 1 || result.Failures[0].Slug != "broken" || !strings.Contains(result.Failures[0].Error, "404") {
+		t.Fatalf("unexpected failures: %+v", result.Failures)
+	}
+	if want := []string{"alpha", "delta", "epsilon", "gamma"}; !reflect.DeepEqual(result.Added, want) {
+		t.Fatalf("added = %v, want %v", result.Added, want)
+	}
+}
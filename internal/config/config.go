@@ -14,6 +14,7 @@ import (
 type Config struct {
 	Language             string           `yaml:"-"`
 	OutputSDK            string           `yaml:"-"`
+	Languages            []Language       `yaml:"-"`
 	CommentOverridesFile string           `yaml:"comment_overrides_file"`
 	Diff                 DiffConfig       `yaml:"diff"`
 	API                  APIConfig        `yaml:"api"`
@@ -24,30 +25,6 @@ type DiffConfig struct {
 	IgnorePathsByLanguage map[string][]string `yaml:"ignore_paths_by_language"`
 }
 
-var defaultDiffIgnorePathsByLanguage = map[string][]string{
-	"python": {
-		".git",
-		".github",
-		".gitignore",
-		".pre-commit-config.yaml",
-		".vscode",
-		"CONTRIBUTING.md",
-		"LICENSE",
-		"README.md",
-		"codecov.yml",
-		"examples",
-		"poetry.lock",
-		"__pycache__",
-		"tests",
-	},
-	"go": {
-		".git",
-		".github",
-		"README.md",
-		"*_test.go",
-	},
-}
-
 type CommentOverrides struct {
 	ClassDocstrings          map[string]string   `yaml:"class_docstrings"`
 	ClassDocstringStyles     map[string]string   `yaml:"class_docstring_styles"`
@@ -215,12 +192,12 @@ type ValidationReport struct {
 	UnmatchedPrefixes []string
 }
 
-func Load(path string) (*Config, error) {
+func Load(path string, languages []Language) (*Config, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("read config file %q: %w", path, err)
 	}
-	cfg, err := Parse(content)
+	cfg, err := Parse(content, languages)
 	if err != nil {
 		return nil, err
 	}
@@ -230,11 +207,12 @@ func Load(path string) (*Config, error) {
 	return cfg, nil
 }
 
-func Parse(content []byte) (*Config, error) {
+func Parse(content []byte, languages []Language) (*Config, error) {
 	var cfg Config
 	if err := yaml.Unmarshal(content, &cfg); err != nil {
 		return nil, fmt.Errorf("parse config yaml: %w", err)
 	}
+	cfg.Languages = languages
 	cfg.applyDefaults()
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -253,10 +231,10 @@ func (c *Config) applyDefaults() {
 	}
 	c.Diff.IgnorePathsByLanguage = normalizedByLanguage
 
-	for lang, defaults := range defaultDiffIgnorePathsByLanguage {
+	for _, lang := range c.SupportedLanguages() {
 		paths := c.Diff.IgnorePathsByLanguage[lang]
 		if len(paths) == 0 {
-			paths = append([]string(nil), defaults...)
+			paths = c.defaultDiffIgnorePaths(lang)
 		}
 		paths = normalizeDiffPaths(paths)
 		if !containsPath(paths, ".git") {
@@ -333,15 +311,15 @@ func (c *CommentOverrides) ensureMaps() {
 func (c *Config) Validate() error {
 	if strings.TrimSpace(c.Language) != "" {
 		lang := strings.ToLower(strings.TrimSpace(c.Language))
-		if lang != "python" && lang != "go" {
-			return fmt.Errorf("unsupported language %q, supported languages: python, go", c.Language)
+		if !c.IsSupportedLanguage(lang) {
+			return fmt.Errorf("unsupported language %q, supported languages: %s", c.Language, c.supportedLanguagesText())
 		}
 	}
 
 	for lang, paths := range c.Diff.IgnorePathsByLanguage {
 		normalizedLang := normalizeLanguage(lang)
-		if normalizedLang != "python" && normalizedLang != "go" {
-			return fmt.Errorf("diff.ignore_paths_by_language.%s is unsupported, supported languages: python, go", lang)
+		if !c.IsSupportedLanguage(normalizedLang) {
+			return fmt.Errorf("diff.ignore_paths_by_language.%s is unsupported, supported languages: %s", lang, c.supportedLanguagesText())
 		}
 		for i, path := range paths {
 			trimmed := strings.TrimSpace(path)
@@ -595,8 +573,8 @@ func (c *Config) DiffIgnorePathsForLanguage(language string) []string {
 	if paths := c.Diff.IgnorePathsByLanguage[lang]; len(paths) > 0 {
 		return append([]string(nil), paths...)
 	}
-	if defaults := defaultDiffIgnorePathsByLanguage[lang]; len(defaults) > 0 {
-		return append([]string(nil), defaults...)
+	if defaults := c.defaultDiffIgnorePaths(lang); len(defaults) > 0 {
+		return defaults
 	}
 	return []string{".git"}
 }
@@ -10,7 +10,7 @@ import (
 )
 
 func TestLoadConfigAndValidate(t *testing.T) {
-	cfg, err := Load(filepath.Join("testdata", "generator.yaml"))
+	cfg, err := Load(filepath.Join("testdata", "generator.yaml"), testLanguages)
 	if err != nil {
 		t.Fatalf("Load() error = %v", err)
 	}
@@ -20,7 +20,7 @@ func TestLoadConfigAndValidate(t *testing.T) {
 }
 
 func TestValidateRuntimeLanguage(t *testing.T) {
-	cfg, err := Parse([]byte("api: {}\n"))
+	cfg, err := Parse([]byte("api: {}\n"), testLanguages)
 	if err != nil {
 		t.Fatalf("Parse() error = %v", err)
 	}
@@ -39,14 +39,14 @@ api:
     - name: chat
       path_prefixes:
         - /v3/chat
-`))
+`), testLanguages)
 	if err != nil {
 		t.Fatalf("Parse() error = %v", err)
 	}
 }
 
 func TestValidateAgainstSwagger(t *testing.T) {
-	cfg, err := Load(filepath.Join("testdata", "generator.yaml"))
+	cfg, err := Load(filepath.Join("testdata", "generator.yaml"), testLanguages)
 	if err != nil {
 		t.Fatalf("Load() error = %v", err)
 	}
@@ -63,7 +63,7 @@ func TestValidateAgainstSwagger(t *testing.T) {
 }
 
 func TestValidateAgainstSwaggerHasErrors(t *testing.T) {
-	cfg, err := Load(filepath.Join("testdata", "generator.yaml"))
+	cfg, err := Load(filepath.Join("testdata", "generator.yaml"), testLanguages)
 	if err != nil {
 		t.Fatalf("Load() error = %v", err)
 	}
@@ -92,7 +92,7 @@ func TestValidateAgainstSwaggerHasErrors(t *testing.T) {
 }
 
 func TestValidateAgainstNilSwagger(t *testing.T) {
-	cfg, err := Load(filepath.Join("testdata", "generator.yaml"))
+	cfg, err := Load(filepath.Join("testdata", "generator.yaml"), testLanguages)
 	if err != nil {
 		t.Fatalf("Load() error = %v", err)
 	}
@@ -107,7 +107,7 @@ func TestValidateAgainstNilSwagger(t *testing.T) {
 }
 
 func TestDefaultsApplied(t *testing.T) {
-	cfg, err := Parse([]byte("api: {}\n"))
+	cfg, err := Parse([]byte("api: {}\n"), testLanguages)
 	if err != nil {
 		t.Fatalf("Parse() error = %v", err)
 	}
@@ -151,10 +151,13 @@ func TestDefaultsApplied(t *testing.T) {
 	if got := cfg.DiffIgnorePathsForLanguage("go"); !containsPath(got, "README.md") {
 		t.Fatalf("expected go diff ignore paths to include README.md, got %#v", got)
 	}
+	if got := cfg.DiffIgnorePathsForLanguage("typescript"); !containsPath(got, "node_modules") || !containsPath(got, "dist") {
+		t.Fatalf("expected typescript diff ignore paths to include node_modules and dist, got %#v", got)
+	}
 }
 
 func TestParseIgnoresRuntimeOptionsInYAML(t *testing.T) {
-	cfg, err := Parse([]byte("language: go\noutput_sdk: out\napi: {}\n"))
+	cfg, err := Parse([]byte("language: go\noutput_sdk: out\napi: {}\n"), testLanguages)
 	if err != nil {
 		t.Fatalf("Parse() error = %v", err)
 	}
@@ -178,7 +181,7 @@ diff:
       - .git
       - .github
 api: {}
-`))
+`), testLanguages)
 	if err != nil {
 		t.Fatalf("Parse() error = %v", err)
 	}
@@ -238,7 +241,7 @@ inline_enum_member_comments:
 		t.Fatalf("write comments error: %v", err)
 	}
 
-	cfg, err := Load(configPath)
+	cfg, err := Load(configPath, testLanguages)
 	if err != nil {
 		t.Fatalf("Load() error = %v", err)
 	}
@@ -286,7 +289,7 @@ api:
 	if err := os.WriteFile(configPath, []byte(configYAML), 0o644); err != nil {
 		t.Fatalf("write config error: %v", err)
 	}
-	if _, err := Load(configPath); err == nil {
+	if _, err := Load(configPath, testLanguages); err == nil {
 		t.Fatal("expected Load() to fail for missing comment overrides file")
 	}
 }
@@ -406,7 +409,7 @@ api:
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			if _, err := Parse([]byte(tc.content)); err == nil {
+			if _, err := Parse([]byte(tc.content), testLanguages); err == nil {
 				t.Fatalf("expected Parse() to fail for case %s", tc.name)
 			}
 		})
@@ -423,7 +426,7 @@ api:
       source_dir: cozepy/benefits
       model_schemas:
         - schema: properties_data_properties_basic_info
-`))
+`), testLanguages)
 	if err != nil {
 		t.Fatalf("Parse() error = %v", err)
 	}
@@ -467,7 +470,7 @@ func TestValidateAgainstSwaggerAllowMissing(t *testing.T) {
 }
 
 func TestConfigHelpers(t *testing.T) {
-	cfg, err := Load(filepath.Join("testdata", "generator.yaml"))
+	cfg, err := Load(filepath.Join("testdata", "generator.yaml"), testLanguages)
 	if err != nil {
 		t.Fatalf("Load() error = %v", err)
 	}
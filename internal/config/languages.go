@@ -0,0 +1,49 @@
+package config
+
+import (
+	"strings"
+)
+
+// Language is a generator backend as config sees it: a name accepted by
+// --language and diff.ignore_paths_by_language, and the diff ignore paths
+// used when the config file sets none. Config has no backend list of its
+// own; callers pass the registered backends to Load or Parse.
+type Language struct {
+	Name               string
+	DefaultIgnorePaths []string
+}
+
+// SupportedLanguages returns the normalized names of c.Languages in order.
+func (c *Config) SupportedLanguages() []string {
+	names := make([]string, 0, len(c.Languages))
+	for _, language := range c.Languages {
+		if name := normalizeLanguage(language.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func (c *Config) IsSupportedLanguage(name string) bool {
+	_, ok := c.language(name)
+	return ok
+}
+
+func (c *Config) language(name string) (Language, bool) {
+	name = normalizeLanguage(name)
+	for _, language := range c.Languages {
+		if name != "" && normalizeLanguage(language.Name) == name {
+			return language, true
+		}
+	}
+	return Language{}, false
+}
+
+func (c *Config) defaultDiffIgnorePaths(name string) []string {
+	language, _ := c.language(name)
+	return normalizeDiffPaths(language.DefaultIgnorePaths)
+}
+
+func (c *Config) supportedLanguagesText() string {
+	return strings.Join(c.SupportedLanguages(), ", ")
+}
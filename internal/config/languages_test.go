@@ -0,0 +1,46 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+var testLanguages = []Language{
+	{Name: "go", DefaultIgnorePaths: []string{".git", ".github", "README.md", "*_test.go"}},
+	{Name: "Python", DefaultIgnorePaths: []string{".git", "tests"}},
+	{Name: "typescript", DefaultIgnorePaths: []string{".git", "node_modules", "dist"}},
+}
+
+func TestSupportedLanguagesKeepsGivenOrder(t *testing.T) {
+	cfg := &Config{Languages: testLanguages}
+	if got := strings.Join(cfg.SupportedLanguages(), ","); got != "go,python,typescript" {
+		t.Fatalf("unexpected supported languages: %s", got)
+	}
+	if !cfg.IsSupportedLanguage(" Go ") {
+		t.Fatal("expected language lookup to normalize case and spaces")
+	}
+	if cfg.IsSupportedLanguage("ruby") {
+		t.Fatal("did not expect ruby to be supported")
+	}
+}
+
+func TestValidateListsGivenLanguages(t *testing.T) {
+	cfg := &Config{Language: "ruby", Languages: testLanguages}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "supported languages: go, python, typescript") {
+		t.Fatalf("expected given languages in error, got %v", err)
+	}
+}
+
+func TestParseWithoutLanguagesRejectsIgnorePaths(t *testing.T) {
+	cfg, err := Parse([]byte("api: {}\n"), nil)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if got := cfg.DiffIgnorePathsForLanguage("go"); len(got) != 1 || got[0] != ".git" {
+		t.Fatalf("expected only .git without language defaults, got %#v", got)
+	}
+	if _, err := Parse([]byte("diff:\n  ignore_paths_by_language:\n    go: [vendor]\napi: {}\n"), nil); err == nil {
+		t.Fatal("expected ignore paths of an unknown language to be rejected")
+	}
+}
@@ -23,8 +23,11 @@ const (
 )
 
 type Difference struct {
-	Path string
-	Type DifferenceType
+	Path string         `json:"path"`
+	Type DifferenceType `json:"type"`
+	// Binary is set on content mismatches that are not line-diffable text.
+	Binary bool   `json:"binary,omitempty"`
+	Hunks  []Hunk `json:"hunks,omitempty"`
 }
 
 type snapshotEntry struct {
@@ -33,6 +36,8 @@ type snapshotEntry struct {
 	Hash  [32]byte
 }
 
+// CompareDirs lists the differences between two trees by mode and content
+// hash. Content mismatches carry no hunks; see AttachHunks.
 func CompareDirs(source string, target string, excludes []string) ([]Difference, error) {
 	srcSnapshot, err := snapshot(source, excludes)
 	if err != nil {
@@ -83,6 +88,31 @@ func CompareDirs(source string, target string, excludes []string) ([]Difference,
 	return diffs, nil
 }
 
+// AttachHunks reads both sides of every content mismatch in diffs and fills
+// in its unified hunks, or marks it Binary.
+func AttachHunks(source string, target string, diffs []Difference) error {
+	for i := range diffs {
+		if diffs[i].Type != ContentMismatch {
+			continue
+		}
+		rel := diffs[i].Path
+		from, err := os.ReadFile(filepath.Join(source, filepath.FromSlash(rel)))
+		if err != nil {
+			return fmt.Errorf("read file %q: %w", rel, err)
+		}
+		to, err := os.ReadFile(filepath.Join(target, filepath.FromSlash(rel)))
+		if err != nil {
+			return fmt.Errorf("read file %q: %w", rel, err)
+		}
+		if IsBinary(from) || IsBinary(to) {
+			diffs[i].Binary = true
+			continue
+		}
+		diffs[i].Hunks = Hunks(from, to)
+	}
+	return nil
+}
+
 func snapshot(root string, excludes []string) (map[string]snapshotEntry, error) {
 	entries := map[string]snapshotEntry{}
 	err := filepath.WalkDir(root, func(pathName string, d fs.DirEntry, walkErr error) error {
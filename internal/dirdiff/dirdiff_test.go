@@ -1,8 +1,11 @@
 package dirdiff
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -124,3 +127,102 @@ func writeFile(t *testing.T, pathName string, content string) {
 		t.Fatalf("write %s: %v", pathName, err)
 	}
 }
+
+func TestUnifiedDiff(t *testing.T) {
+	from := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n"
+	to := "1\n2\nX\n4\n5\n6\n7\n8\n9\n10\n11\n12\n14\n15\nnew"
+	want := `--- a
++++ b
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++X
+ 4
+ 5
+ 6
+@@ -10,6 +10,6 @@
+ 10
+ 11
+ 12
+-13
+ 14
+ 15
++new
+\ No newline at end of file
+`
+	if got := UnifiedDiff("a", "b", []byte(from), []byte(to)); got != want {
+		t.Fatalf("unexpected unified diff:\n%s", got)
+	}
+	if got := UnifiedDiff("a", "b", nil, []byte("x\n")); got != "--- a\n+++ b\n@@ -0,0 +1 @@\n+x\n" {
+		t.Fatalf("unexpected diff for new file:\n%s", got)
+	}
+	if got := UnifiedDiff("a", "b", []byte(from), []byte(from)); got != "" {
+		t.Fatalf("expected empty diff for equal input, got:\n%s", got)
+	}
+}
+
+func TestCompareDirsAttachesHunksAndBinaryFallback(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+
+	writeFile(t, filepath.Join(src, "client.go"), "package coze\n\nconst a = 1\n")
+	writeFile(t, filepath.Join(dst, "client.go"), "package coze\n\nconst a = 2\n")
+	writeFile(t, filepath.Join(src, "logo.png"), "\x89PNG\x00\x01")
+	writeFile(t, filepath.Join(dst, "logo.png"), "\x89PNG\x00\x02")
+
+	diffs, err := CompareDirs(src, dst, nil)
+	if err != nil {
+		t.Fatalf("CompareDirs() error = %v", err)
+	}
+	if len(diffs) != 2 || len(diffs[0].Hunks) != 0 || diffs[1].Binary {
+		t.Fatalf("expected 2 differences without content details, got %+v", diffs)
+	}
+	if err := AttachHunks(src, dst, diffs); err != nil {
+		t.Fatalf("AttachHunks() error = %v", err)
+	}
+	text := diffs[0]
+	if text.Path != "client.go" || text.Binary || len(text.Hunks) != 1 {
+		t.Fatalf("unexpected text difference: %+v", text)
+	}
+	hunk := text.Hunks[0]
+	if hunk.FromStart != 1 || hunk.FromLines != 3 || hunk.ToStart != 1 || hunk.ToLines != 3 {
+		t.Fatalf("unexpected hunk range: %+v", hunk)
+	}
+	if got := strings.Join(hunk.Lines, "|"); got != " package coze| |-const a = 1|+const a = 2" {
+		t.Fatalf("unexpected hunk lines: %q", got)
+	}
+	if binary := diffs[1]; binary.Path != "logo.png" || !binary.Binary || len(binary.Hunks) != 0 {
+		t.Fatalf("unexpected binary difference: %+v", binary)
+	}
+
+	report := NewReport(src, dst, append(diffs, Difference{Path: "extra.go", Type: ExtraInTarget}))
+	var jsonOut bytes.Buffer
+	if err := report.WriteJSON(&jsonOut); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode report: %v\n%s", err, jsonOut.String())
+	}
+	if decoded.Total != 3 || decoded.Counts[ContentMismatch] != 2 || decoded.Counts[ExtraInTarget] != 1 {
+		t.Fatalf("unexpected report counts: %+v", decoded)
+	}
+	if len(decoded.Differences[0].Hunks) != 1 || !decoded.Differences[1].Binary {
+		t.Fatalf("expected hunks and binary flag in report, got %+v", decoded.Differences)
+	}
+
+	var textOut bytes.Buffer
+	if err := report.WriteText(&textOut, "a", "b"); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	for _, want := range []string{
+		"content_mismatch client.go\n--- a/client.go\n+++ b/client.go\n@@ -1,3 +1,3 @@\n",
+		"Binary files a/logo.png and b/logo.png differ\n",
+		"extra_in_target extra.go\n",
+	} {
+		if !strings.Contains(textOut.String(), want) {
+			t.Fatalf("expected %q in text report, got:\n%s", want, textOut.String())
+		}
+	}
+}
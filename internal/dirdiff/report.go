@@ -0,0 +1,64 @@
+package dirdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report is the machine-readable form of a CompareDirs result.
+type Report struct {
+	Source      string                 `json:"source"`
+	Target      string                 `json:"target"`
+	Total       int                    `json:"total"`
+	Counts      map[DifferenceType]int `json:"counts"`
+	Differences []Difference           `json:"differences"`
+}
+
+func NewReport(source string, target string, diffs []Difference) Report {
+	report := Report{
+		Source:      source,
+		Target:      target,
+		Total:       len(diffs),
+		Counts:      map[DifferenceType]int{},
+		Differences: diffs,
+	}
+	if report.Differences == nil {
+		report.Differences = []Difference{}
+	}
+	for _, diff := range diffs {
+		report.Counts[diff.Type]++
+	}
+	return report
+}
+
+func (r Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("encode diff report: %w", err)
+	}
+	return nil
+}
+
+// WriteText prints each difference, followed by its unified hunks or a binary
+// notice for content mismatches. Paths are labelled with fromLabel/toLabel.
+func (r Report) WriteText(w io.Writer, fromLabel string, toLabel string) error {
+	var buf strings.Builder
+	for _, diff := range r.Differences {
+		buf.WriteString(fmt.Sprintf("%s %s\n", diff.Type, diff.Path))
+		if diff.Type != ContentMismatch {
+			continue
+		}
+		fromName := fromLabel + "/" + diff.Path
+		toName := toLabel + "/" + diff.Path
+		if diff.Binary {
+			buf.WriteString(fmt.Sprintf("Binary files %s and %s differ\n", fromName, toName))
+			continue
+		}
+		buf.WriteString(FormatUnified(fromName, toName, diff.Hunks))
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
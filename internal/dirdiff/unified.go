@@ -0,0 +1,236 @@
+package dirdiff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	unifiedContextLines = 3
+	// maxLCSCells bounds the line-matching table; larger middles are reported
+	// as one replaced block instead of a minimal edit script.
+	maxLCSCells = 4 << 20
+)
+
+const noNewlineMarker = "\\ No newline at end of file"
+
+// binarySniffLen matches the prefix git inspects when deciding whether a file
+// is binary.
+const binarySniffLen = 8000
+
+// Hunk is one unified-diff block. Lines keep their ' ', '-' or '+' prefix and
+// drop the trailing newline.
+type Hunk struct {
+	FromStart int      `json:"from_start"`
+	FromLines int      `json:"from_lines"`
+	ToStart   int      `json:"to_start"`
+	ToLines   int      `json:"to_lines"`
+	Lines     []string `json:"lines"`
+}
+
+type lineOp struct {
+	Kind byte
+	Line string
+}
+
+// UnifiedDiff renders the line-level changes between from and to in unified
+// format with three lines of context. It returns "" when the texts are equal.
+func UnifiedDiff(fromName string, toName string, from []byte, to []byte) string {
+	return FormatUnified(fromName, toName, Hunks(from, to))
+}
+
+// Hunks returns the unified-diff hunks that turn from into to.
+func Hunks(from []byte, to []byte) []Hunk {
+	ops := diffLines(splitLines(string(from)), splitLines(string(to)))
+	return groupHunks(ops, unifiedContextLines)
+}
+
+func FormatUnified(fromName string, toName string, hunks []Hunk) string {
+	if len(hunks) == 0 {
+		return ""
+	}
+	var buf strings.Builder
+	buf.WriteString("--- " + fromName + "\n")
+	buf.WriteString("+++ " + toName + "\n")
+	for _, hunk := range hunks {
+		buf.WriteString(hunk.String())
+	}
+	return buf.String()
+}
+
+func (h Hunk) String() string {
+	var buf strings.Builder
+	buf.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(h.FromStart, h.FromLines), hunkRange(h.ToStart, h.ToLines)))
+	for _, line := range h.Lines {
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// IsBinary reports whether content should be compared byte-wise rather than
+// line by line.
+func IsBinary(content []byte) bool {
+	sniff := content
+	if len(sniff) > binarySniffLen {
+		sniff = sniff[:binarySniffLen]
+	}
+	return bytes.IndexByte(sniff, 0) >= 0 || !utf8.Valid(content)
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func diffLines(from []string, to []string) []lineOp {
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix && from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]lineOp, 0, len(from)+len(to))
+	for _, line := range from[:prefix] {
+		ops = append(ops, lineOp{Kind: ' ', Line: line})
+	}
+	ops = append(ops, diffMiddle(from[prefix:len(from)-suffix], to[prefix:len(to)-suffix])...)
+	for _, line := range from[len(from)-suffix:] {
+		ops = append(ops, lineOp{Kind: ' ', Line: line})
+	}
+	return ops
+}
+
+func diffMiddle(from []string, to []string) []lineOp {
+	ops := make([]lineOp, 0, len(from)+len(to))
+	if len(from)*len(to) > maxLCSCells {
+		for _, line := range from {
+			ops = append(ops, lineOp{Kind: '-', Line: line})
+		}
+		for _, line := range to {
+			ops = append(ops, lineOp{Kind: '+', Line: line})
+		}
+		return ops
+	}
+
+	// lengths[i][j] is the LCS length of from[i:] and to[j:].
+	width := len(to) + 1
+	lengths := make([]int32, (len(from)+1)*width)
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			switch {
+			case from[i] == to[j]:
+				lengths[i*width+j] = lengths[(i+1)*width+j+1] + 1
+			case lengths[(i+1)*width+j] >= lengths[i*width+j+1]:
+				lengths[i*width+j] = lengths[(i+1)*width+j]
+			default:
+				lengths[i*width+j] = lengths[i*width+j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			ops = append(ops, lineOp{Kind: ' ', Line: from[i]})
+			i++
+			j++
+		case lengths[(i+1)*width+j] >= lengths[i*width+j+1]:
+			ops = append(ops, lineOp{Kind: '-', Line: from[i]})
+			i++
+		default:
+			ops = append(ops, lineOp{Kind: '+', Line: to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		ops = append(ops, lineOp{Kind: '-', Line: from[i]})
+	}
+	for ; j < len(to); j++ {
+		ops = append(ops, lineOp{Kind: '+', Line: to[j]})
+	}
+	return ops
+}
+
+func groupHunks(ops []lineOp, context int) []Hunk {
+	hunks := make([]Hunk, 0)
+	for start := 0; start < len(ops); {
+		for start < len(ops) && ops[start].Kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+		// Extend the hunk while the gap between changes fits in two contexts.
+		end := start
+		for end < len(ops) {
+			next := end
+			for next < len(ops) && ops[next].Kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*context {
+				break
+			}
+			for next < len(ops) && ops[next].Kind != ' ' {
+				next++
+			}
+			end = next
+		}
+		from := max(start-context, 0)
+		to := min(end+context, len(ops))
+		hunks = append(hunks, buildHunk(ops, from, to))
+		start = to
+	}
+	return hunks
+}
+
+func buildHunk(ops []lineOp, from int, to int) Hunk {
+	fromLine, toLine := 1, 1
+	for _, op := range ops[:from] {
+		if op.Kind != '+' {
+			fromLine++
+		}
+		if op.Kind != '-' {
+			toLine++
+		}
+	}
+	hunk := Hunk{FromStart: fromLine, ToStart: toLine}
+	for _, op := range ops[from:to] {
+		if op.Kind != '+' {
+			hunk.FromLines++
+		}
+		if op.Kind != '-' {
+			hunk.ToLines++
+		}
+		hunk.Lines = append(hunk.Lines, string(op.Kind)+strings.TrimSuffix(op.Line, "\n"))
+		if !strings.HasSuffix(op.Line, "\n") {
+			hunk.Lines = append(hunk.Lines, noNewlineMarker)
+		}
+	}
+	// An empty range names the line before it, as in diff -u.
+	if hunk.FromLines == 0 {
+		hunk.FromStart--
+	}
+	if hunk.ToLines == 0 {
+		hunk.ToStart--
+	}
+	return hunk
+}
+
+func hunkRange(start int, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
@@ -0,0 +1,231 @@
+// Package docdrift compares the operations of the synced API docs with the
+// consolidated spec to find where the spec has gone stale.
+package docdrift
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+type Kind string
+
+const (
+	OperationMissing Kind = "operation-missing"
+	MissingInSpec    Kind = "missing-in-spec"
+	MissingInDocs    Kind = "missing-in-docs"
+	TypeMismatch     Kind = "type-mismatch"
+	RequiredMismatch Kind = "required-mismatch"
+)
+
+// ignoredHeaders are documented on every page but expressed by security
+// schemes and content types in the spec.
+var ignoredHeaders = map[string]bool{
+	"authorization": true,
+	"content-type":  true,
+}
+
+// Page is one per-endpoint document written by coze-api-doc-sync.
+type Page struct {
+	Slug string
+	Doc  *openapi.Document
+}
+
+// Finding is one difference between a documented operation and the spec.
+// Field is rooted at "path", "query", "header", "body" or "response"; "[]"
+// steps into array items. Docs and Spec hold the type or requiredness each
+// side declares.
+type Finding struct {
+	Operation string `json:"operation"`
+	Page      string `json:"page"`
+	Kind      Kind   `json:"kind"`
+	Field     string `json:"field,omitempty"`
+	Docs      string `json:"docs,omitempty"`
+	Spec      string `json:"spec,omitempty"`
+}
+
+func (f Finding) String() string {
+	location := f.Operation
+	if f.Field != "" {
+		location += " " + f.Field
+	}
+	text := fmt.Sprintf("%s %s", f.Kind, location)
+	switch f.Kind {
+	case TypeMismatch, RequiredMismatch:
+		text += fmt.Sprintf(": docs %s, spec %s", f.Docs, f.Spec)
+	case MissingInSpec:
+		text += ": docs " + f.Docs
+	case MissingInDocs:
+		text += ": spec " + f.Spec
+	}
+	return text + " (" + f.Page + ")"
+}
+
+// LoadPages reads every .yaml and .yml document in dir, sorted by name.
+func LoadPages(dir string) ([]Page, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read docs dir %q: %w", dir, err)
+	}
+	pages := make([]Page, 0, len(entries))
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		doc, err := openapi.Load(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, Page{Slug: strings.TrimSuffix(entry.Name(), ext), Doc: doc})
+	}
+	return pages, nil
+}
+
+// field is what one side declares about a parameter or schema property.
+// Required is only compared for request fields, since the docs do not mark
+// response fields.
+type field struct {
+	Type          string
+	Required      bool
+	CheckRequired bool
+}
+
+// Compare reports, per documented operation, the fields the docs and the spec
+// disagree on.
+func Compare(spec *openapi.Document, pages []Page) []Finding {
+	findings := make([]Finding, 0)
+	for _, page := range pages {
+		for _, ref := range page.Doc.ListOperations() {
+			path := openapi.NormalizePathTemplate(ref.Path)
+			operation := strings.ToUpper(ref.Method) + " " + path
+			docDetails, _ := page.Doc.OperationDetails(ref.Path, ref.Method)
+			specDetails, ok := spec.OperationDetails(path, ref.Method)
+			if !ok {
+				findings = append(findings, Finding{Operation: operation, Page: page.Slug, Kind: OperationMissing})
+				continue
+			}
+			findings = append(findings, compareFields(
+				operationFields(page.Doc, docDetails),
+				operationFields(spec, specDetails),
+				operation,
+				page.Slug,
+			)...)
+		}
+	}
+	return findings
+}
+
+func compareFields(docFields map[string]field, specFields map[string]field, operation string, page string) []Finding {
+	keys := make([]string, 0, len(docFields)+len(specFields))
+	for key := range docFields {
+		keys = append(keys, key)
+	}
+	for key := range specFields {
+		if _, ok := docFields[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	findings := make([]Finding, 0)
+	add := func(kind Kind, key string, docs string, spec string) {
+		findings = append(findings, Finding{Operation: operation, Page: page, Kind: kind, Field: key, Docs: docs, Spec: spec})
+	}
+	for _, key := range keys {
+		docField, inDocs := docFields[key]
+		specField, inSpec := specFields[key]
+		switch {
+		case !inSpec:
+			add(MissingInSpec, key, docField.Type, "")
+		case !inDocs:
+			add(MissingInDocs, key, "", specField.Type)
+		default:
+			if docField.Type != "" && specField.Type != "" && docField.Type != specField.Type {
+				add(TypeMismatch, key, docField.Type, specField.Type)
+			}
+			if docField.CheckRequired && docField.Required != specField.Required {
+				add(RequiredMismatch, key, requiredness(docField.Required), requiredness(specField.Required))
+			}
+		}
+	}
+	return findings
+}
+
+func operationFields(doc *openapi.Document, details *openapi.OperationDetails) map[string]field {
+	fields := map[string]field{}
+	for _, param := range details.Parameters {
+		if param.In == "header" && ignoredHeaders[strings.ToLower(param.Name)] {
+			continue
+		}
+		key := param.In + "." + param.Name
+		fields[key] = field{Type: schemaType(doc, param.Schema), Required: param.Required, CheckRequired: true}
+		collectFields(doc, key, param.Schema, true, fields, map[*openapi.Schema]struct{}{})
+	}
+	collectFields(doc, "body", details.RequestBodySchema, true, fields, map[*openapi.Schema]struct{}{})
+	collectFields(doc, "response", details.ResponseSchema, false, fields, map[*openapi.Schema]struct{}{})
+	return fields
+}
+
+// collectFields records the properties of schema under prefix, recursing into
+// objects and array items. open guards against recursive schemas.
+func collectFields(doc *openapi.Document, prefix string, schema *openapi.Schema, checkRequired bool, fields map[string]field, open map[*openapi.Schema]struct{}) {
+	resolved := doc.EffectiveSchema(schema)
+	if resolved == nil {
+		return
+	}
+	if _, ok := open[resolved]; ok {
+		return
+	}
+	open[resolved] = struct{}{}
+	defer delete(open, resolved)
+
+	if resolved.Items != nil {
+		collectFields(doc, prefix+"[]", resolved.Items, checkRequired, fields, open)
+	}
+	for name, property := range resolved.Properties {
+		key := prefix + "." + name
+		fields[key] = field{
+			Type:          schemaType(doc, property),
+			Required:      containsString(resolved.Required, name),
+			CheckRequired: checkRequired,
+		}
+		collectFields(doc, key, property, checkRequired, fields, open)
+	}
+}
+
+func schemaType(doc *openapi.Document, schema *openapi.Schema) string {
+	resolved := doc.EffectiveSchema(schema)
+	if resolved == nil {
+		return ""
+	}
+	switch {
+	case resolved.Type == "array" && resolved.Items != nil:
+		if itemType := schemaType(doc, resolved.Items); itemType != "" {
+			return "array of " + itemType
+		}
+	case resolved.Type == "" && len(resolved.Properties) > 0:
+		return "object"
+	}
+	return resolved.Type
+}
+
+func requiredness(required bool) string {
+	if required {
+		return "required"
+	}
+	return "optional"
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package docdrift
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+func mustParse(t *testing.T, content string) *openapi.Document {
+	t.Helper()
+	doc, err := openapi.Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return doc
+}
+
+const driftSpec = `
+paths:
+  /v1/bots/{bot_id}:
+    post:
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: string
+        - in: query
+          name: page
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Update'
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  code:
+                    type: integer
+                  legacy:
+                    type: string
+components:
+  schemas:
+    Update:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tags:
+          type: array
+          items:
+            type: string
+`
+
+const driftDocs = `
+paths:
+  /v1/bots/:bot_id:
+    post:
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: string
+        - in: header
+          name: Authorization
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                tags:
+                  type: array
+                  items:
+                    type: integer
+                prompt:
+                  type: object
+                  properties:
+                    text:
+                      type: string
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [code]
+                properties:
+                  code:
+                    type: integer
+  /v1/bots/:bot_id/publish:
+    post:
+      responses:
+        "200":
+          description: ok
+`
+
+func TestCompare(t *testing.T) {
+	findings := Compare(mustParse(t, driftSpec), []Page{{Slug: "update_bot", Doc: mustParse(t, driftDocs)}})
+	op := "POST /v1/bots/{bot_id}"
+	want := []Finding{
+		{Operation: op, Page: "update_bot", Kind: RequiredMismatch, Field: "body.name", Docs: "optional", Spec: "required"},
+		{Operation: op, Page: "update_bot", Kind: MissingInSpec, Field: "body.prompt", Docs: "object"},
+		{Operation: op, Page: "update_bot", Kind: MissingInSpec, Field: "body.prompt.text", Docs: "string"},
+		{Operation: op, Page: "update_bot", Kind: TypeMismatch, Field: "body.tags", Docs: "array of integer", Spec: "array of string"},
+		{Operation: op, Page: "update_bot", Kind: MissingInDocs, Field: "query.page", Spec: "integer"},
+		{Operation: op, Page: "update_bot", Kind: MissingInDocs, Field: "response.legacy", Spec: "string"},
+		{Operation: "POST /v1/bots/{bot_id}/publish", Page: "update_bot", Kind: OperationMissing},
+	}
+	if !reflect.DeepEqual(findings, want) {
+		t.Fatalf("Compare() =\n%v\nwant\n%v", findings, want)
+	}
+}
+
+func TestLoadPagesAndReport(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "update_bot.yaml"), []byte(driftDocs), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pages, err := LoadPages(dir)
+	if err != nil {
+		t.Fatalf("LoadPages() error = %v", err)
+	}
+	if len(pages) != 1 || pages[0].Slug != "update_bot" {
+		t.Fatalf("unexpected pages: %+v", pages)
+	}
+
+	report := NewReport(Compare(mustParse(t, driftSpec), pages))
+	var buf bytes.Buffer
+	if err := report.WriteText(&buf); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if got := string(lines[3]); got != "type-mismatch POST /v1/bots/{bot_id} body.tags: docs array of integer, spec array of string (update_bot)" {
+		t.Fatalf("unexpected finding line %q", got)
+	}
+	if got := string(lines[len(lines)-1]); got != "operation_missing=1 missing_in_spec=2 missing_in_docs=2 type_mismatch=1 required_mismatch=1" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+}
@@ -0,0 +1,63 @@
+package docdrift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Report struct {
+	OperationMissing int       `json:"operation_missing"`
+	MissingInSpec    int       `json:"missing_in_spec"`
+	MissingInDocs    int       `json:"missing_in_docs"`
+	TypeMismatch     int       `json:"type_mismatch"`
+	RequiredMismatch int       `json:"required_mismatch"`
+	Findings         []Finding `json:"findings"`
+}
+
+func NewReport(findings []Finding) Report {
+	report := Report{Findings: findings}
+	if report.Findings == nil {
+		report.Findings = []Finding{}
+	}
+	for _, finding := range findings {
+		switch finding.Kind {
+		case OperationMissing:
+			report.OperationMissing++
+		case MissingInSpec:
+			report.MissingInSpec++
+		case MissingInDocs:
+			report.MissingInDocs++
+		case TypeMismatch:
+			report.TypeMismatch++
+		case RequiredMismatch:
+			report.RequiredMismatch++
+		}
+	}
+	return report
+}
+
+func (r Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("encode drift report: %w", err)
+	}
+	return nil
+}
+
+// WriteText prints one line per finding followed by the totals.
+func (r Report) WriteText(w io.Writer) error {
+	var buf strings.Builder
+	for _, finding := range r.Findings {
+		buf.WriteString(finding.String())
+		buf.WriteString("\n")
+	}
+	buf.WriteString(fmt.Sprintf(
+		"operation_missing=%d missing_in_spec=%d missing_in_docs=%d type_mismatch=%d required_mismatch=%d\n",
+		r.OperationMissing, r.MissingInSpec, r.MissingInDocs, r.TypeMismatch, r.RequiredMismatch,
+	))
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
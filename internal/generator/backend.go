@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+// Backend generates an SDK for one target language. Backends register
+// themselves with Register; the CLI hands ConfigLanguages to config loading so
+// validation only accepts registered languages.
+type Backend interface {
+	Name() string
+	DefaultIgnorePaths() []string
+	Generate(cfg *config.Config, doc *openapi.Document) (Result, error)
+}
+
+// PostProcessor is implemented by backends that need a step after all files
+// are written, such as running a formatter over the output directory.
+type PostProcessor interface {
+	PostProcess(cfg *config.Config, result Result) error
+}
+
+var backends = struct {
+	sync.RWMutex
+	byName map[string]Backend
+	names  []string
+}{byName: map[string]Backend{}}
+
+// Register adds a backend under its normalized name. It panics on an empty or
+// duplicate name, so wiring mistakes fail at startup.
+func Register(backend Backend) {
+	if backend == nil {
+		panic("generator: backend is nil")
+	}
+	name := strings.ToLower(strings.TrimSpace(backend.Name()))
+	if name == "" {
+		panic("generator: backend name is required")
+	}
+	backends.Lock()
+	defer backends.Unlock()
+	if _, dup := backends.byName[name]; dup {
+		panic(fmt.Sprintf("generator: backend %q registered twice", name))
+	}
+	backends.byName[name] = backend
+	backends.names = append(backends.names, name)
+}
+
+func Lookup(language string) (Backend, bool) {
+	backends.RLock()
+	defer backends.RUnlock()
+	backend, ok := backends.byName[strings.ToLower(strings.TrimSpace(language))]
+	return backend, ok
+}
+
+// Languages returns the registered backend names in registration order.
+func Languages() []string {
+	backends.RLock()
+	defer backends.RUnlock()
+	return append([]string(nil), backends.names...)
+}
+
+// ConfigLanguages describes the registered backends for config.Load and
+// config.Parse, in registration order.
+func ConfigLanguages() []config.Language {
+	backends.RLock()
+	defer backends.RUnlock()
+	languages := make([]config.Language, 0, len(backends.names))
+	for _, name := range backends.names {
+		languages = append(languages, config.Language{
+			Name:               name,
+			DefaultIgnorePaths: backends.byName[name].DefaultIgnorePaths(),
+		})
+	}
+	return languages
+}
+
+// NewBackend returns a Backend that generates with generate, for backend
+// packages that register a plain function.
+func NewBackend(name string, defaultIgnorePaths []string, generate func(cfg *config.Config, doc *openapi.Document) (Result, error)) Backend {
+	return funcBackend{name: name, defaultIgnorePaths: defaultIgnorePaths, generate: generate}
+}
+
+type funcBackend struct {
+	name               string
+	defaultIgnorePaths []string
+	generate           func(cfg *config.Config, doc *openapi.Document) (Result, error)
+}
+
+func (b funcBackend) Name() string {
+	return b.name
+}
+
+func (b funcBackend) DefaultIgnorePaths() []string {
+	return append([]string(nil), b.defaultIgnorePaths...)
+}
+
+func (b funcBackend) Generate(cfg *config.Config, doc *openapi.Document) (Result, error) {
+	return b.generate(cfg, doc)
+}
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+type recordingBackend struct {
+	name          string
+	postProcessed *Result
+	postErr       error
+}
+
+func (b *recordingBackend) Name() string {
+	return b.name
+}
+
+func (b *recordingBackend) DefaultIgnorePaths() []string {
+	return []string{".git", "vendor"}
+}
+
+func (b *recordingBackend) Generate(cfg *config.Config, doc *openapi.Document) (Result, error) {
+	return Result{GeneratedFiles: 2, GeneratedOps: 1}, nil
+}
+
+func (b *recordingBackend) PostProcess(cfg *config.Config, result Result) error {
+	b.postProcessed = &result
+	return b.postErr
+}
+
+func TestBuiltinBackendsRegistered(t *testing.T) {
+	got := strings.Join(Languages(), ",")
+	if !strings.HasPrefix(got, "go,python,typescript") {
+		t.Fatalf("unexpected registered languages: %s", got)
+	}
+	cfg, err := config.Parse([]byte("api: {}\n"), ConfigLanguages())
+	if err != nil {
+		t.Fatalf("config.Parse() error = %v", err)
+	}
+	if got := cfg.DiffIgnorePathsForLanguage("python"); !containsString(got, "tests") {
+		t.Fatalf("expected python defaults from backend, got %#v", got)
+	}
+	if got := cfg.DiffIgnorePathsForLanguage("go"); !containsString(got, "*_test.go") {
+		t.Fatalf("expected go defaults from backend, got %#v", got)
+	}
+	if got := cfg.DiffIgnorePathsForLanguage("typescript"); !containsString(got, "node_modules") {
+		t.Fatalf("expected typescript defaults from backend, got %#v", got)
+	}
+}
+
+func TestRunUsesRegisteredBackendAndPostProcessor(t *testing.T) {
+	backend := &recordingBackend{name: "Registry-Test"}
+	Register(backend)
+
+	if _, ok := Lookup(" registry-test "); !ok {
+		t.Fatal("expected lookup to normalize backend name")
+	}
+	cfg, err := config.Parse([]byte("api: {}\n"), ConfigLanguages())
+	if err != nil {
+		t.Fatalf("config.Parse() error = %v", err)
+	}
+	if got := cfg.DiffIgnorePathsForLanguage("registry-test"); !containsString(got, "vendor") {
+		t.Fatalf("expected registered default ignore paths, got %#v", got)
+	}
+	cfg.Language = "registry-test"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected registered language to validate, got %v", err)
+	}
+
+	result, err := Run(cfg, nil)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.GeneratedFiles != 2 || backend.postProcessed == nil || backend.postProcessed.GeneratedOps != 1 {
+		t.Fatalf("expected post-process to receive result, got %+v / %+v", result, backend.postProcessed)
+	}
+
+	backend.postErr = errors.New("formatter failed")
+	if _, err := Run(cfg, nil); err == nil || !strings.Contains(err.Error(), "post-process registry-test output: formatter failed") {
+		t.Fatalf("expected post-process error, got %v", err)
+	}
+}
+
+func TestRegisterRejectsDuplicateBackend(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected duplicate registration to panic")
+		}
+	}()
+	Register(funcBackend{name: "Go"})
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -18,28 +18,49 @@ func CleanOutputDirPreserveGit(outputDir string) error {
 // glob patterns (for example, "*_test.go"), both matched against top-level
 // entry names.
 func CleanOutputDirPreserveEntries(outputDir string, preserveEntries []string) error {
+	removed, err := PlanCleanOutputDir(outputDir, preserveEntries)
+	if err != nil {
+		return err
+	}
+	outputDir = strings.TrimSpace(outputDir)
+	if removed == nil {
+		if mkErr := os.MkdirAll(outputDir, 0o755); mkErr != nil {
+			return fmt.Errorf("create output directory %q: %w", outputDir, mkErr)
+		}
+		return nil
+	}
+	for _, name := range removed {
+		target := filepath.Join(outputDir, name)
+		if err := os.RemoveAll(target); err != nil {
+			return fmt.Errorf("remove output entry %q: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// PlanCleanOutputDir returns the top-level entry names that
+// CleanOutputDirPreserveEntries would remove, without touching outputDir. It
+// returns nil when outputDir does not exist yet.
+func PlanCleanOutputDir(outputDir string, preserveEntries []string) ([]string, error) {
 	outputDir = strings.TrimSpace(outputDir)
 	if outputDir == "" {
-		return fmt.Errorf("output directory is empty")
+		return nil, fmt.Errorf("output directory is empty")
 	}
 
 	info, err := os.Stat(outputDir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			if mkErr := os.MkdirAll(outputDir, 0o755); mkErr != nil {
-				return fmt.Errorf("create output directory %q: %w", outputDir, mkErr)
-			}
-			return nil
+			return nil, nil
 		}
-		return fmt.Errorf("stat output directory %q: %w", outputDir, err)
+		return nil, fmt.Errorf("stat output directory %q: %w", outputDir, err)
 	}
 	if !info.IsDir() {
-		return fmt.Errorf("output path %q is not a directory", outputDir)
+		return nil, fmt.Errorf("output path %q is not a directory", outputDir)
 	}
 
 	entries, err := os.ReadDir(outputDir)
 	if err != nil {
-		return fmt.Errorf("read output directory %q: %w", outputDir, err)
+		return nil, fmt.Errorf("read output directory %q: %w", outputDir, err)
 	}
 	preserve := map[string]struct{}{}
 	preservePatterns := make([]string, 0)
@@ -54,6 +75,7 @@ func CleanOutputDirPreserveEntries(outputDir string, preserveEntries []string) e
 		}
 		preserve[name] = struct{}{}
 	}
+	removed := make([]string, 0, len(entries))
 	for _, entry := range entries {
 		name := entry.Name()
 		if _, ok := preserve[name]; ok {
@@ -62,12 +84,9 @@ func CleanOutputDirPreserveEntries(outputDir string, preserveEntries []string) e
 		if matchesGlobPatterns(name, preservePatterns) {
 			continue
 		}
-		target := filepath.Join(outputDir, name)
-		if err := os.RemoveAll(target); err != nil {
-			return fmt.Errorf("remove output entry %q: %w", target, err)
-		}
+		removed = append(removed, name)
 	}
-	return nil
+	return removed, nil
 }
 
 func topLevelEntryName(value string) string {
@@ -3,6 +3,7 @@ package fsutil
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -114,3 +115,32 @@ func TestCleanOutputDirPreserveEntriesWithGlob(t *testing.T) {
 		}
 	}
 }
+
+func TestPlanCleanOutputDirDoesNotRemove(t *testing.T) {
+	out := t.TempDir()
+	for _, rel := range []string{"README.md", "client_test.go", "client.go", "stale/old.go"} {
+		path := filepath.Join(out, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", path, err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	removed, err := PlanCleanOutputDir(out, []string{"README.md", "*_test.go"})
+	if err != nil {
+		t.Fatalf("PlanCleanOutputDir() error = %v", err)
+	}
+	if strings.Join(removed, ",") != "client.go,stale" {
+		t.Fatalf("unexpected removal plan: %v", removed)
+	}
+	if _, err := os.Stat(filepath.Join(out, "stale", "old.go")); err != nil {
+		t.Fatalf("expected planning to leave files in place, stat err=%v", err)
+	}
+
+	missing, err := PlanCleanOutputDir(filepath.Join(out, "missing"), nil)
+	if err != nil || missing != nil {
+		t.Fatalf("expected nil plan for missing dir, got %v, err=%v", missing, err)
+	}
+}
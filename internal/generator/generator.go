@@ -5,8 +5,6 @@ import (
 	"strings"
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
-	gogen "github.com/coze-dev/coze-sdk-gen/internal/generator/go"
-	pygen "github.com/coze-dev/coze-sdk-gen/internal/generator/python"
 	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
 )
 
@@ -20,34 +18,19 @@ func Run(cfg *config.Config, doc *openapi.Document) (Result, error) {
 		return Result{}, fmt.Errorf("config is required")
 	}
 
-	switch strings.ToLower(cfg.Language) {
-	case "python":
-		return GeneratePython(cfg, doc)
-	case "go":
-		return GenerateGo(cfg, doc)
-	default:
-		return Result{}, fmt.Errorf("unsupported language %q", cfg.Language)
+	lang := strings.ToLower(strings.TrimSpace(cfg.Language))
+	backend, ok := Lookup(lang)
+	if !ok {
+		return Result{}, fmt.Errorf("unsupported language %q, supported languages: %s", cfg.Language, strings.Join(Languages(), ", "))
 	}
-}
-
-func GeneratePython(cfg *config.Config, doc *openapi.Document) (Result, error) {
-	result, err := pygen.GeneratePython(cfg, doc)
+	result, err := backend.Generate(cfg, doc)
 	if err != nil {
 		return Result{}, err
 	}
-	return Result{
-		GeneratedFiles: result.GeneratedFiles,
-		GeneratedOps:   result.GeneratedOps,
-	}, nil
-}
-
-func GenerateGo(cfg *config.Config, doc *openapi.Document) (Result, error) {
-	result, err := gogen.GenerateGo(cfg, doc)
-	if err != nil {
-		return Result{}, err
+	if postProcessor, ok := backend.(PostProcessor); ok {
+		if err := postProcessor.PostProcess(cfg, result); err != nil {
+			return Result{}, fmt.Errorf("post-process %s output: %w", lang, err)
+		}
 	}
-	return Result{
-		GeneratedFiles: result.GeneratedFiles,
-		GeneratedOps:   result.GeneratedOps,
-	}, nil
+	return result, nil
 }
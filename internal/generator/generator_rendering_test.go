@@ -1,4 +1,4 @@
-package generator
+package generator_test
 
 import (
 	"strings"
@@ -6,9 +6,27 @@ import (
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
 	pygen "github.com/coze-dev/coze-sdk-gen/internal/generator/python"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
 	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
 )
 
+// withIRModels resolves the model_schemas of meta.Package through the IR, as
+// the python generator does before rendering.
+func withIRModels(doc *openapi.Document, meta pygen.PackageMeta) pygen.PackageMeta {
+	if meta.Package == nil {
+		return meta
+	}
+	pkg := *meta.Package
+	if pkg.Name == "" {
+		pkg.Name = meta.Name
+	}
+	cfg := &config.Config{API: config.APIConfig{Packages: []config.Package{pkg}}}
+	if irPkg, ok := ir.Build(cfg, doc).Package(pkg.Name); ok {
+		meta.Models = irPkg.Models
+	}
+	return meta
+}
+
 func TestRenderOperationMethodUsesSwaggerComments(t *testing.T) {
 	doc := mustParseSwagger(t)
 	details := openapi.OperationDetails{
@@ -117,7 +135,7 @@ func TestRenderPackageModuleUsesSwaggerFieldDescriptions(t *testing.T) {
 		},
 	}
 
-	code := pygen.RenderPackageModule(doc, meta, nil)
+	code := pygen.RenderPackageModule(doc, withIRModels(doc, meta), nil)
 	if !strings.Contains(code, "# Demo identifier.\n    id: str") {
 		t.Fatalf("expected required field description comment:\n%s", code)
 	}
@@ -174,7 +192,7 @@ func TestRenderPackageModuleFieldCommentsPreferSwaggerFallbackOverrides(t *testi
 		},
 	}
 
-	code := pygen.RenderPackageModuleWithComments(doc, meta, nil, commentOverrides)
+	code := pygen.RenderPackageModuleWithComments(doc, withIRModels(doc, meta), nil, commentOverrides)
 	if !strings.Contains(code, "# Swagger id description.\n    id: str") {
 		t.Fatalf("expected swagger description for id field:\n%s", code)
 	}
@@ -232,7 +250,7 @@ func TestRenderPackageModuleMethodDocstringPreferSwaggerFallbackOverrides(t *tes
 		},
 	}
 
-	code := pygen.RenderPackageModuleWithComments(doc, meta, bindings, commentOverrides)
+	code := pygen.RenderPackageModuleWithComments(doc, withIRModels(doc, meta), bindings, commentOverrides)
 	if !strings.Contains(code, "Create from overrides.") {
 		t.Fatalf("expected override docstring when swagger comment is missing:\n%s", code)
 	}
@@ -275,7 +293,7 @@ func TestRenderPackageModuleMethodDocstringUsesRichTextOverrides(t *testing.T) {
 		},
 	}
 
-	code := pygen.RenderPackageModuleWithComments(doc, meta, bindings, commentOverrides)
+	code := pygen.RenderPackageModuleWithComments(doc, withIRModels(doc, meta), bindings, commentOverrides)
 	if !strings.Contains(code, "Use rich text override.") {
 		t.Fatalf("expected rich text override docstring:\n%s", code)
 	}
@@ -315,7 +333,7 @@ func TestRenderPackageModuleRichTextOverrideCollapsedLinesAreSplit(t *testing.T)
 		},
 	}
 
-	code := pygen.RenderPackageModuleWithComments(doc, meta, bindings, commentOverrides)
+	code := pygen.RenderPackageModuleWithComments(doc, withIRModels(doc, meta), bindings, commentOverrides)
 	if !strings.Contains(code, "删除扣子应用的协作者。\n        删除协作者时") {
 		t.Fatalf("expected collapsed rich text override to be split into lines:\n%s", code)
 	}
@@ -404,7 +422,7 @@ func TestRenderPackageModuleIntEnumMixinBase(t *testing.T) {
 		},
 	}
 
-	code := pygen.RenderPackageModule(doc, meta, nil)
+	code := pygen.RenderPackageModule(doc, withIRModels(doc, meta), nil)
 	if !strings.Contains(code, "from enum import Enum") {
 		t.Fatalf("expected Enum import for int_enum base:\n%s", code)
 	}
@@ -473,7 +491,7 @@ func TestRenderPackageModuleAutoAddsReferencedSchemasForSameName(t *testing.T) {
 		},
 	}
 
-	code := pygen.RenderPackageModule(doc, meta, nil)
+	code := pygen.RenderPackageModule(doc, withIRModels(doc, meta), nil)
 	if !strings.Contains(code, "support_emotions: Optional[List[EmotionInfo]] = None") {
 		t.Fatalf("expected support_emotions to keep model type, got:\n%s", code)
 	}
@@ -536,7 +554,7 @@ func TestRenderPackageModulePrunesUnorderedSchemaDependencies(t *testing.T) {
 		},
 	}
 
-	code := pygen.RenderPackageModule(doc, meta, nil)
+	code := pygen.RenderPackageModule(doc, withIRModels(doc, meta), nil)
 	if !strings.Contains(code, "used_info: Optional[UsedInfo] = None") {
 		t.Fatalf("expected used_info typed with referenced UsedInfo model, got:\n%s", code)
 	}
@@ -568,7 +586,7 @@ func TestRenderPackageModuleAutoNumberPagedResponseMethods(t *testing.T) {
 		},
 	}
 
-	code := pygen.RenderPackageModule(doc, meta, nil)
+	code := pygen.RenderPackageModule(doc, withIRModels(doc, meta), nil)
 	if !strings.Contains(code, "class _PrivateListWorkflowData(CozeModel, NumberPagedResponse[WorkflowBasic]):") {
 		t.Fatalf("expected paged model class definition:\n%s", code)
 	}
@@ -603,7 +621,7 @@ func TestRenderPackageModuleAutoNumberPagedResponseMethodsWithCustomFieldNames(t
 		},
 	}
 
-	code := pygen.RenderPackageModule(doc, meta, nil)
+	code := pygen.RenderPackageModule(doc, withIRModels(doc, meta), nil)
 	if !strings.Contains(code, "def get_total(self) -> Optional[int]:\n        return self.total_count") {
 		t.Fatalf("expected auto generated get_total from total_count:\n%s", code)
 	}
@@ -647,7 +665,7 @@ func TestRenderPackageModuleAutoTokenAndLastIDPagedResponseMethods(t *testing.T)
 		},
 	}
 
-	code := pygen.RenderPackageModule(doc, meta, nil)
+	code := pygen.RenderPackageModule(doc, withIRModels(doc, meta), nil)
 	if !strings.Contains(code, "def get_next_page_token(self) -> Optional[str]:\n        return self.next_page_token") {
 		t.Fatalf("expected auto generated get_next_page_token for TokenPagedResponse:\n%s", code)
 	}
@@ -689,7 +707,7 @@ func TestRenderPackageModuleEmptyModelWithSwaggerDocstringOmitsPass(t *testing.T
 		},
 	}
 
-	code := pygen.RenderPackageModule(doc, meta, nil)
+	code := pygen.RenderPackageModule(doc, withIRModels(doc, meta), nil)
 	classStart := strings.Index(code, "class DeleteConversationResp(CozeModel):")
 	if classStart < 0 {
 		t.Fatalf("expected empty model class definition:\n%s", code)
@@ -726,7 +744,7 @@ func TestRenderPackageModuleExtraFieldAlias(t *testing.T) {
 		},
 	}
 
-	code := pygen.RenderPackageModule(doc, meta, nil)
+	code := pygen.RenderPackageModule(doc, withIRModels(doc, meta), nil)
 	if !strings.Contains(code, `from_: Optional[str] = Field(alias="from")`) {
 		t.Fatalf("expected alias field rendering for required extra field:\n%s", code)
 	}
@@ -766,7 +784,7 @@ func TestRenderPackageModuleBeforeValidators(t *testing.T) {
 		},
 	}
 
-	code := pygen.RenderPackageModule(doc, meta, nil)
+	code := pygen.RenderPackageModule(doc, withIRModels(doc, meta), nil)
 	if !strings.Contains(code, `@field_validator("publish_time", mode="before")`) {
 		t.Fatalf("expected int_to_string before validator:\n%s", code)
 	}
@@ -821,7 +839,7 @@ func TestRenderPackageModuleBuilders(t *testing.T) {
 		},
 	}
 
-	code := pygen.RenderPackageModule(doc, meta, nil)
+	code := pygen.RenderPackageModule(doc, withIRModels(doc, meta), nil)
 	if !strings.Contains(code, "@staticmethod\n    def build_no_auto_update() -> \"DocumentUpdateRule\":") {
 		t.Fatalf("expected builder staticmethod for no_auto_update:\n%s", code)
 	}
@@ -1,13 +1,17 @@
-package generator
+package generator_test
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/generator"
+	gogen "github.com/coze-dev/coze-sdk-gen/internal/generator/go"
 	pygen "github.com/coze-dev/coze-sdk-gen/internal/generator/python"
+	_ "github.com/coze-dev/coze-sdk-gen/internal/generator/typescript"
 	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
 )
 
@@ -16,7 +20,7 @@ func TestGeneratePythonFromSwagger(t *testing.T) {
 	cfg := testConfig(out)
 	doc := mustParseSwagger(t)
 
-	result, err := GeneratePython(cfg, doc)
+	result, err := pygen.GeneratePython(cfg, doc)
 	if err != nil {
 		t.Fatalf("GeneratePython() error = %v", err)
 	}
@@ -39,7 +43,7 @@ func TestGeneratePythonAudioTranscriptionsAsyncCreateFromMapping(t *testing.T) {
 	cfg.Language = "python"
 	cfg.OutputSDK = t.TempDir()
 
-	if _, err := GeneratePython(cfg, doc); err != nil {
+	if _, err := pygen.GeneratePython(cfg, doc); err != nil {
 		t.Fatalf("GeneratePython() error = %v", err)
 	}
 
@@ -100,7 +104,7 @@ func TestGeneratePythonPreservesConfiguredDiffIgnorePaths(t *testing.T) {
 		"go":     {".git"},
 	}
 	doc := mustParseSwagger(t)
-	if _, err := GeneratePython(cfg, doc); err != nil {
+	if _, err := pygen.GeneratePython(cfg, doc); err != nil {
 		t.Fatalf("GeneratePython() error = %v", err)
 	}
 
@@ -127,7 +131,7 @@ func TestGeneratePythonOnlyMapped(t *testing.T) {
 	cfg.API.GenerateOnlyMapped = true
 	doc := mustParseSwagger(t)
 
-	result, err := GeneratePython(cfg, doc)
+	result, err := pygen.GeneratePython(cfg, doc)
 	if err != nil {
 		t.Fatalf("GeneratePython() error = %v", err)
 	}
@@ -149,14 +153,14 @@ func TestGeneratePythonValidationFailure(t *testing.T) {
 		SDKMethods: []string{"chat.not_exist"},
 	})
 
-	if _, err := GeneratePython(cfg, mustParseSwagger(t)); err == nil {
+	if _, err := pygen.GeneratePython(cfg, mustParseSwagger(t)); err == nil {
 		t.Fatal("expected swagger validation failure")
 	}
 }
 
 func TestGeneratePythonNilDoc(t *testing.T) {
 	cfg := testConfig(t.TempDir())
-	if _, err := GeneratePython(cfg, nil); err == nil {
+	if _, err := pygen.GeneratePython(cfg, nil); err == nil {
 		t.Fatal("expected error for nil swagger")
 	}
 }
@@ -166,7 +170,7 @@ func TestGenerateGoFromSwagger(t *testing.T) {
 	cfg.Language = "go"
 	cfg.OutputSDK = t.TempDir()
 
-	result, err := GenerateGo(cfg, doc)
+	result, err := gogen.GenerateGo(cfg, doc)
 	if err != nil {
 		t.Fatalf("GenerateGo() error = %v", err)
 	}
@@ -180,7 +184,78 @@ func TestGenerateGoFromSwagger(t *testing.T) {
 	assertFileContains(t, filepath.Join(cfg.OutputSDK, "apps.go"), "func (r *apps) List(")
 	assertFileContains(t, filepath.Join(cfg.OutputSDK, "users.go"), "func (r *users) Me(")
 	assertFileContains(t, filepath.Join(cfg.OutputSDK, "templates.go"), "func (r *templates) Duplicate(")
+	assertFileContains(t, filepath.Join(cfg.OutputSDK, "bots.go"), "func (r *bots) Create(")
+	assertFileContains(t, filepath.Join(cfg.OutputSDK, "workspaces.go"), "Members: newWorkspacesMembers(core),")
 	assertFileContains(t, filepath.Join(cfg.OutputSDK, "go.mod"), "module github.com/coze-dev/coze-go")
+	assertGoPackageCompiles(t, cfg.OutputSDK)
+}
+
+func TestGenerateGoDecodesUnknownUnionVariants(t *testing.T) {
+	cfg, doc := mustLoadRealConfigAndSwagger(t)
+	cfg.Language = "go"
+	cfg.OutputSDK = t.TempDir()
+	unions, err := openapi.Parse([]byte(`
+components:
+  schemas:
+    ProbeMessage:
+      type: object
+      properties:
+        content:
+          $ref: '#/components/schemas/ProbeContent'
+    ProbeContent:
+      oneOf:
+        - $ref: '#/components/schemas/ProbeText'
+      discriminator:
+        propertyName: type
+    ProbeText:
+      type: object
+      properties:
+        type:
+          type: string
+        text:
+          type: string
+`))
+	if err != nil {
+		t.Fatalf("openapi.Parse() error = %v", err)
+	}
+	for name, schema := range unions.Components.Schemas {
+		doc.Components.Schemas[name] = schema
+	}
+	cfg.API.Packages = append(cfg.API.Packages, config.Package{
+		Name:         "probes",
+		ModelSchemas: []config.ModelSchema{{Schema: "ProbeMessage"}},
+	})
+	if _, err := gogen.GenerateGo(cfg, doc); err != nil {
+		t.Fatalf("GenerateGo() error = %v", err)
+	}
+
+	decodeTest := `package coze
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeUnknownProbeContent(t *testing.T) {
+	raw := ` + "`" + `{"type":"video","url":"https://example.com/v.mp4"}` + "`" + `
+	var message ProbeMessage
+	if err := json.Unmarshal([]byte(` + "`" + `{"content":` + "`" + `+raw+` + "`" + `}` + "`" + `), &message); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	unknown, ok := message.Content.(*ProbeContentUnknown)
+	if !ok || unknown.Tag != "video" || string(unknown.Raw) != raw {
+		t.Fatalf("unexpected content: %#v", message.Content)
+	}
+	encoded, err := json.Marshal(message.Content)
+	if err != nil || string(encoded) != raw {
+		t.Fatalf("Marshal() = %s, %v", encoded, err)
+	}
+}
+`
+	if err := os.WriteFile(filepath.Join(cfg.OutputSDK, "decode_test.go"), []byte(decodeTest), 0o644); err != nil {
+		t.Fatalf("write decode test: %v", err)
+	}
+	runGoCommand(t, cfg.OutputSDK, "test", "-run", "TestDecodeUnknownProbeContent", ".")
 }
 
 func TestGenerateGoPreservesGitDirectory(t *testing.T) {
@@ -204,7 +279,7 @@ func TestGenerateGoPreservesGitDirectory(t *testing.T) {
 	cfg, doc := mustLoadRealConfigAndSwagger(t)
 	cfg.Language = "go"
 	cfg.OutputSDK = out
-	if _, err := GenerateGo(cfg, doc); err != nil {
+	if _, err := gogen.GenerateGo(cfg, doc); err != nil {
 		t.Fatalf("GenerateGo() error = %v", err)
 	}
 
@@ -237,7 +312,7 @@ func TestGenerateGoPreservesIgnoredReadmeAndGithub(t *testing.T) {
 	cfg.Language = "go"
 	cfg.OutputSDK = out
 
-	if _, err := GenerateGo(cfg, doc); err != nil {
+	if _, err := gogen.GenerateGo(cfg, doc); err != nil {
 		t.Fatalf("GenerateGo() error = %v", err)
 	}
 
@@ -261,7 +336,7 @@ func TestGenerateGoValidationFailure(t *testing.T) {
 		SDKMethods: []string{"chat.not_exist"},
 	})
 
-	if _, err := GenerateGo(cfg, doc); err == nil {
+	if _, err := gogen.GenerateGo(cfg, doc); err == nil {
 		t.Fatal("expected swagger validation failure")
 	}
 }
@@ -270,20 +345,51 @@ func TestRunGoLanguage(t *testing.T) {
 	cfg, doc := mustLoadRealConfigAndSwagger(t)
 	cfg.Language = "go"
 	cfg.OutputSDK = t.TempDir()
-	if _, err := Run(cfg, doc); err != nil {
+	if _, err := generator.Run(cfg, doc); err != nil {
 		t.Fatalf("expected Run() to support go language, got error: %v", err)
 	}
 }
 
+func TestGenerateTypeScriptFromSwagger(t *testing.T) {
+	cfg, doc := mustLoadRealConfigAndSwagger(t)
+	cfg.Language = "typescript"
+	cfg.OutputSDK = t.TempDir()
+	nodeModule := filepath.Join(cfg.OutputSDK, "node_modules", "typescript", "package.json")
+	if err := os.MkdirAll(filepath.Dir(nodeModule), 0o755); err != nil {
+		t.Fatalf("mkdir node_modules: %v", err)
+	}
+	if err := os.WriteFile(nodeModule, []byte("{}"), 0o644); err != nil {
+		t.Fatalf("write node module: %v", err)
+	}
+
+	result, err := generator.Run(cfg, doc)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.GeneratedOps < 3 {
+		t.Fatalf("expected >=3 generated operations, got %d", result.GeneratedOps)
+	}
+	assertFileContains(t, filepath.Join(cfg.OutputSDK, "package.json"), `"type": "module"`)
+	assertFileContains(t, filepath.Join(cfg.OutputSDK, "src", "core.ts"), "export async function* parseSSE(")
+	assertFileContains(t, filepath.Join(cfg.OutputSDK, "src", "index.ts"), "export class CozeAPI {")
+	assertFileContains(t, filepath.Join(cfg.OutputSDK, "src", "index.ts"), "this.workspaces = new Workspaces(core);")
+	assertFileContains(t, filepath.Join(cfg.OutputSDK, "src", "resources", "workspaces.ts"), "this.members = new WorkspacesMembers(core);")
+	assertFileContains(t, filepath.Join(cfg.OutputSDK, "src", "resources", "chat.ts"), "AsyncGenerator<StreamEvent>")
+	assertFileContains(t, filepath.Join(cfg.OutputSDK, "src", "models.ts"), "export interface BotInfo {")
+	if _, err := os.Stat(nodeModule); err != nil {
+		t.Fatalf("expected node_modules to be preserved, stat err=%v", err)
+	}
+}
+
 func TestRunNilConfig(t *testing.T) {
-	if _, err := Run(nil, nil); err == nil {
+	if _, err := generator.Run(nil, nil); err == nil {
 		t.Fatal("expected Run() to fail for nil config")
 	}
 }
 
 func TestRunUnknownLanguage(t *testing.T) {
 	cfg := &config.Config{Language: "ruby"}
-	if _, err := Run(cfg, mustParseSwagger(t)); err == nil {
+	if _, err := generator.Run(cfg, mustParseSwagger(t)); err == nil {
 		t.Fatal("expected Run() to fail for unsupported language")
 	}
 }
@@ -366,7 +472,7 @@ func TestGeneratePythonFromRealConfig(t *testing.T) {
 	cfgPath := filepath.Join(root, "config", "generator.yaml")
 	swaggerPath := filepath.Join(root, "coze-openapi.yaml")
 
-	cfg, err := config.Load(cfgPath)
+	cfg, err := config.Load(cfgPath, generator.ConfigLanguages())
 	if err != nil {
 		t.Fatalf("config.Load(%q) error = %v", cfgPath, err)
 	}
@@ -377,7 +483,7 @@ func TestGeneratePythonFromRealConfig(t *testing.T) {
 		t.Fatalf("openapi.Load(%q) error = %v", swaggerPath, err)
 	}
 
-	result, err := GeneratePython(cfg, doc)
+	result, err := pygen.GeneratePython(cfg, doc)
 	if err != nil {
 		t.Fatalf("GeneratePython() error = %v", err)
 	}
@@ -797,7 +903,7 @@ func TestGeneratePythonMappingGeneratesSyncAndAsyncByDefault(t *testing.T) {
 		},
 	}
 
-	_, err := GeneratePython(cfg, mustParseSwagger(t))
+	_, err := pygen.GeneratePython(cfg, mustParseSwagger(t))
 	if err != nil {
 		t.Fatalf("GeneratePython() error = %v", err)
 	}
@@ -1498,7 +1604,7 @@ func mustLoadRealConfigAndSwagger(t *testing.T) (*config.Config, *openapi.Docume
 	cfgPath := filepath.Join(root, "config", "generator.yaml")
 	swaggerPath := filepath.Join(root, "coze-openapi.yaml")
 
-	cfg, err := config.Load(cfgPath)
+	cfg, err := config.Load(cfgPath, generator.ConfigLanguages())
 	if err != nil {
 		t.Fatalf("config.Load(%q) error = %v", cfgPath, err)
 	}
@@ -1509,6 +1615,28 @@ func mustLoadRealConfigAndSwagger(t *testing.T) (*config.Config, *openapi.Docume
 	return cfg, doc
 }
 
+// assertGoPackageCompiles builds and vets the generated package in dir; the
+// copied examples are not part of it.
+func assertGoPackageCompiles(t *testing.T, dir string) {
+	t.Helper()
+	runGoCommand(t, dir, "build", ".")
+	runGoCommand(t, dir, "vet", ".")
+}
+
+func runGoCommand(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+	cmd := exec.Command(goBin, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go %s in %s: %v\n%s", strings.Join(args, " "), dir, err, output)
+	}
+}
+
 func assertFileContains(t *testing.T, pathName string, expected string) {
 	t.Helper()
 	content := readFile(t, pathName)
@@ -8,12 +8,14 @@ import (
 	"strings"
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
 	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
 )
 
 type goAPIModuleRenderer struct {
-	FileName string
-	Render   func(cfg *config.Config, doc *openapi.Document) (string, error)
+	FileName    string
+	PackageName string
+	Render      func(cfg *config.Config, doc *openapi.Document) (string, error)
 }
 
 type goSwaggerModuleSpec struct {
@@ -38,64 +40,69 @@ type goSwaggerOperationBinding struct {
 	Summary    string
 	IsFile     bool
 	Order      int
+	Mapping    *config.OperationMapping
+	Details    openapi.OperationDetails
+	HasDetails bool
+	Parameters []ir.Parameter
+	Streaming  bool
+	Pagination *ir.Pagination
 }
 
 var goInlineAPIModuleRenderers = []goAPIModuleRenderer{
-	{FileName: "apps.go", Render: renderGoAppsModule},
-	{FileName: "audio_live.go", Render: renderGoAudioLiveModule},
-	{FileName: "audio_speech.go", Render: renderGoAudioSpeechModule},
-	{FileName: "audio_transcription.go", Render: renderGoAudioTranscriptionsModule},
-	{FileName: "chats_messages.go", Render: renderGoChatsMessagesModule},
-	{FileName: "files.go", Render: renderGoFilesModule},
-	{FileName: "templates.go", Render: renderGoTemplatesModule},
-	{FileName: "users.go", Render: renderGoUsersModule},
-	{FileName: "workflows_chat.go", Render: renderGoWorkflowsChatModule},
+	{FileName: "apps.go", PackageName: "apps", Render: renderGoAppsModule},
+	{FileName: "audio_live.go", PackageName: "audio_live", Render: renderGoAudioLiveModule},
+	{FileName: "audio_speech.go", PackageName: "audio_speech", Render: renderGoAudioSpeechModule},
+	{FileName: "audio_transcription.go", PackageName: "audio_transcriptions", Render: renderGoAudioTranscriptionsModule},
+	{FileName: "chats_messages.go", PackageName: "chat_message", Render: renderGoChatsMessagesModule},
+	{FileName: "files.go", PackageName: "files", Render: renderGoFilesModule},
+	{FileName: "templates.go", PackageName: "templates", Render: renderGoTemplatesModule},
+	{FileName: "users.go", PackageName: "users", Render: renderGoUsersModule},
+	{FileName: "workflows_chat.go", PackageName: "workflows_chat", Render: renderGoWorkflowsChatModule},
 }
 
-var goSwaggerAPIModuleSpecs = []goSwaggerModuleSpec{}
-
 var goCustomAPIModuleRenderers = []goAPIModuleRenderer{
 	{
-		FileName: "audio.go",
-		Render:   renderGoAudioModule,
+		FileName:    "audio.go",
+		PackageName: "audio",
+		Render:      renderGoAudioModule,
 	},
 }
 
 var goGeneratedAPIModuleFiles = buildGoGeneratedAPIModuleFiles()
 
 func buildGoGeneratedAPIModuleFiles() map[string]struct{} {
-	files := make(map[string]struct{}, len(goCustomAPIModuleRenderers)+len(goInlineAPIModuleRenderers)+len(goSwaggerAPIModuleSpecs))
+	files := make(map[string]struct{}, len(goCustomAPIModuleRenderers)+len(goInlineAPIModuleRenderers))
 	for _, renderer := range goCustomAPIModuleRenderers {
 		files[renderer.FileName] = struct{}{}
 	}
 	for _, renderer := range goInlineAPIModuleRenderers {
 		files[renderer.FileName] = struct{}{}
 	}
-	for _, spec := range goSwaggerAPIModuleSpecs {
-		files[spec.FileName] = struct{}{}
-	}
 	return files
 }
 
-func listGoAPIModuleRenderers() []goAPIModuleRenderer {
-	renderers := make([]goAPIModuleRenderer, 0, len(goCustomAPIModuleRenderers)+len(goInlineAPIModuleRenderers)+len(goSwaggerAPIModuleSpecs))
+// listGoAPIModuleRenderers returns the handwritten renderers followed by one
+// renderer per swagger module of models and the shared enums file.
+func listGoAPIModuleRenderers(models *goModelRegistry) []goAPIModuleRenderer {
+	renderers := make([]goAPIModuleRenderer, 0, len(goCustomAPIModuleRenderers)+len(goInlineAPIModuleRenderers)+len(models.specs)+1)
 	renderers = append(renderers, goCustomAPIModuleRenderers...)
 	renderers = append(renderers, goInlineAPIModuleRenderers...)
-
-	specs := append([]goSwaggerModuleSpec(nil), goSwaggerAPIModuleSpecs...)
-	sort.Slice(specs, func(i, j int) bool {
-		return specs[i].FileName < specs[j].FileName
-	})
-	for _, spec := range specs {
+	for _, spec := range models.specs {
 		specCopy := spec
 		renderers = append(renderers, goAPIModuleRenderer{
-			FileName: specCopy.FileName,
-			Render: func(cfg *config.Config, doc *openapi.Document) (string, error) {
-				bindings := buildGoSwaggerOperationBindings(cfg, doc, specCopy.PackageName)
-				return renderGoSwaggerModule(specCopy, bindings), nil
+			FileName:    specCopy.FileName,
+			PackageName: specCopy.PackageName,
+			Render: func(*config.Config, *openapi.Document) (string, error) {
+				return renderGoSwaggerModule(models, specCopy, models.bindings[specCopy.PackageName]), nil
 			},
 		})
 	}
+	renderers = append(renderers, goAPIModuleRenderer{
+		FileName: goEnumsFileName,
+		Render: func(*config.Config, *openapi.Document) (string, error) {
+			return renderGoEnumsModule(models)
+		},
+	})
 	return renderers
 }
 
@@ -182,27 +189,17 @@ func writeGoAudioEnum(
 	if buf == nil || strings.TrimSpace(typeName) == "" {
 		return
 	}
-	items = normalizeGoAudioEnumItems(items)
-	buf.WriteString(fmt.Sprintf("// %s represents the %s\n", typeName, comment))
-	buf.WriteString(fmt.Sprintf("type %s string\n\n", typeName))
-	buf.WriteString("const (\n")
-	for _, item := range items {
-		buf.WriteString(fmt.Sprintf("\t%s%s %s = %q\n", typeName, item.Name, typeName, item.Value))
-	}
-	buf.WriteString(")\n\n")
-	receiver = strings.TrimSpace(receiver)
-	if receiver == "" {
-		receiver = "v"
+	enum := &goEnumDefinition{
+		Name:     typeName,
+		Base:     "string",
+		Comment:  "represents the " + comment,
+		Receiver: receiver,
+		SkipPtr:  !withPtr,
 	}
-	buf.WriteString(fmt.Sprintf("func (%s %s) String() string {\n", receiver, typeName))
-	buf.WriteString(fmt.Sprintf("\treturn string(%s)\n", receiver))
-	buf.WriteString("}\n")
-	if withPtr {
-		buf.WriteString("\n")
-		buf.WriteString(fmt.Sprintf("func (%s %s) Ptr() *%s {\n", receiver, typeName, typeName))
-		buf.WriteString(fmt.Sprintf("\treturn &%s\n", receiver))
-		buf.WriteString("}\n")
+	for _, item := range normalizeGoAudioEnumItems(items) {
+		enum.Items = append(enum.Items, goEnumItem{ConstName: typeName + item.Name, Literal: fmt.Sprintf("%q", item.Value)})
 	}
+	writeGoEnum(buf, enum)
 }
 
 func buildGoAudioEnumItems(
@@ -275,7 +272,7 @@ func collectGoEnumItemsFromConfig(cfg *config.Config, packageName string, modelN
 					name = value
 				}
 				items = append(items, goAudioEnumItem{
-					Name:  strings.ToUpper(normalizeGoExportedIdentifier(name)),
+					Name:  strings.ToUpper(goExportedName(name)),
 					Value: value,
 				})
 			}
@@ -391,7 +388,7 @@ func enumItemsFromValues(values []string) []goAudioEnumItem {
 			continue
 		}
 		items = append(items, goAudioEnumItem{
-			Name:  strings.ToUpper(normalizeGoExportedIdentifier(cleanValue)),
+			Name:  strings.ToUpper(goExportedName(cleanValue)),
 			Value: cleanValue,
 		})
 	}
@@ -425,7 +422,7 @@ func normalizeGoAudioEnumItems(items []goAudioEnumItem) []goAudioEnumItem {
 			continue
 		}
 		if name == "" {
-			name = strings.ToUpper(normalizeGoExportedIdentifier(value))
+			name = strings.ToUpper(goExportedName(value))
 		}
 		if name == "" {
 			continue
@@ -492,56 +489,38 @@ func shouldSkipGoExtraAsset(rel string) bool {
 	return ok
 }
 
-func buildGoSwaggerOperationBindings(cfg *config.Config, doc *openapi.Document, packageName string) []goSwaggerOperationBinding {
-	if cfg == nil {
-		return nil
-	}
-	packageName = strings.TrimSpace(packageName)
-	if packageName == "" {
+func buildGoSwaggerOperationBindings(api *ir.API, packageName string) []goSwaggerOperationBinding {
+	pkg, ok := api.Package(packageName)
+	if !ok {
 		return nil
 	}
 
 	bindings := make([]goSwaggerOperationBinding, 0)
-	for _, mapping := range cfg.API.OperationMappings {
-		details, hasDetails := resolveGoOperationDetails(doc, mapping)
-		for methodIndex, sdkMethod := range mapping.SDKMethods {
-			pkg, method, ok := parseGoSDKMethod(cfg, mapping.Path, sdkMethod)
-			if !ok || pkg != packageName {
-				continue
-			}
-			goMethod := normalizeGoExportedIdentifier(method)
-			if goMethod == "" {
-				continue
-			}
-			httpMethod := strings.TrimSpace(mapping.HTTPMethodOverride)
-			if httpMethod == "" {
-				httpMethod = strings.TrimSpace(mapping.Method)
-			}
-			if httpMethod == "" {
-				httpMethod = http.MethodGet
-			}
-
-			isFile := false
-			summary := ""
-			if hasDetails {
-				summary = goOperationSummary(details)
-				contentType := strings.ToLower(strings.TrimSpace(details.RequestBodyContentType))
-				isFile = strings.Contains(contentType, "multipart/form-data")
-			}
-
-			order := len(bindings)
-			if mapping.Order > 0 {
-				order = mapping.Order + methodIndex
-			}
-			bindings = append(bindings, goSwaggerOperationBinding{
-				MethodName: goMethod,
-				HTTPMethod: strings.ToUpper(httpMethod),
-				Path:       strings.TrimSpace(mapping.Path),
-				Summary:    summary,
-				IsFile:     isFile,
-				Order:      order,
-			})
+	for _, op := range pkg.OperationsFor(Language) {
+		if op.Mapping == nil {
+			continue
+		}
+		goMethod := goExportedName(op.MethodName)
+		if goMethod == "" {
+			continue
+		}
+		if strings.HasPrefix(op.MethodName, "_") {
+			goMethod = goUnexportedName(goMethod)
 		}
+		bindings = append(bindings, goSwaggerOperationBinding{
+			MethodName: goMethod,
+			HTTPMethod: op.HTTPMethod,
+			Path:       op.Path,
+			Summary:    op.Summary,
+			IsFile:     op.IsFile,
+			Order:      op.Order,
+			Mapping:    op.Mapping,
+			Details:    op.Details,
+			HasDetails: op.HasDetails,
+			Parameters: op.Parameters,
+			Streaming:  op.Streaming,
+			Pagination: op.Pagination,
+		})
 	}
 
 	sort.Slice(bindings, func(i, j int) bool {
@@ -569,117 +548,6 @@ func buildGoSwaggerOperationBindings(cfg *config.Config, doc *openapi.Document,
 	return bindings
 }
 
-func resolveGoOperationDetails(doc *openapi.Document, mapping config.OperationMapping) (openapi.OperationDetails, bool) {
-	if doc == nil {
-		return openapi.OperationDetails{}, false
-	}
-	details, ok := doc.OperationDetails(strings.TrimSpace(mapping.Path), strings.TrimSpace(mapping.Method))
-	if !ok || details == nil {
-		return openapi.OperationDetails{}, false
-	}
-	return *details, true
-}
-
-func parseGoSDKMethod(cfg *config.Config, path string, sdkMethod string) (string, string, bool) {
-	sdkMethod = strings.TrimSpace(sdkMethod)
-	if sdkMethod == "" {
-		return "", "", false
-	}
-	parts := strings.Split(sdkMethod, ".")
-	switch len(parts) {
-	case 1:
-		method := strings.TrimSpace(parts[0])
-		if method == "" || cfg == nil {
-			return "", "", false
-		}
-		pkg, ok := cfg.ResolvePackage(path, "")
-		if !ok {
-			return "", "", false
-		}
-		return strings.TrimSpace(pkg.Name), method, true
-	case 2:
-		pkg := strings.TrimSpace(parts[0])
-		method := strings.TrimSpace(parts[1])
-		if pkg == "" || method == "" {
-			return "", "", false
-		}
-		return pkg, method, true
-	case 3:
-		if strings.TrimSpace(parts[0]) != "go" {
-			return "", "", false
-		}
-		pkg := strings.TrimSpace(parts[1])
-		method := strings.TrimSpace(parts[2])
-		if pkg == "" || method == "" {
-			return "", "", false
-		}
-		return pkg, method, true
-	default:
-		return "", "", false
-	}
-}
-
-func renderGoSwaggerModule(spec goSwaggerModuleSpec, bindings []goSwaggerOperationBinding) string {
-	coreField := strings.TrimSpace(spec.CoreFieldName)
-	if coreField == "" {
-		coreField = "core"
-	}
-
-	var buf bytes.Buffer
-	buf.WriteString("package coze\n\n")
-	if len(bindings) > 0 {
-		buf.WriteString("import (\n")
-		buf.WriteString("\t\"context\"\n")
-		buf.WriteString("\t\"net/http\"\n")
-		buf.WriteString(")\n\n")
-	}
-
-	for _, binding := range bindings {
-		if summary := strings.TrimSpace(binding.Summary); summary != "" {
-			buf.WriteString(fmt.Sprintf("// %s %s\n", binding.MethodName, summary))
-		}
-		buf.WriteString(fmt.Sprintf("func (r *%s) %s(ctx context.Context, req *SwaggerOperationRequest) (*SwaggerOperationResponse, error) {\n", spec.TypeName, binding.MethodName))
-		buf.WriteString("\tif req == nil {\n")
-		buf.WriteString("\t\treq = &SwaggerOperationRequest{}\n")
-		buf.WriteString("\t}\n")
-		buf.WriteString("\trequest := &RawRequestReq{\n")
-		buf.WriteString(fmt.Sprintf("\t\tMethod: %s,\n", goHTTPMethodConstant(binding.HTTPMethod)))
-		buf.WriteString(fmt.Sprintf("\t\tURL:    buildSwaggerOperationURL(%q, req.PathParams, req.QueryParams),\n", binding.Path))
-		buf.WriteString("\t\tBody:   req.Body,\n")
-		if binding.IsFile {
-			buf.WriteString("\t\tIsFile: true,\n")
-		}
-		buf.WriteString("\t}\n")
-		buf.WriteString("\tresponse := new(SwaggerOperationResponse)\n")
-		buf.WriteString(fmt.Sprintf("\terr := r.%s.rawRequest(ctx, request, response)\n", coreField))
-		buf.WriteString("\treturn response, err\n")
-		buf.WriteString("}\n\n")
-	}
-
-	buf.WriteString(fmt.Sprintf("type %s struct {\n", spec.TypeName))
-	buf.WriteString(fmt.Sprintf("\t%s *core\n", coreField))
-	for _, child := range spec.Children {
-		buf.WriteString(fmt.Sprintf("\t%s *%s\n", child.FieldName, child.TypeName))
-	}
-	buf.WriteString("}\n\n")
-
-	buf.WriteString(fmt.Sprintf("func %s(core *core) *%s {\n", spec.ConstructorName, spec.TypeName))
-	if len(spec.Children) == 0 {
-		buf.WriteString(fmt.Sprintf("\treturn &%s{%s: core}\n", spec.TypeName, coreField))
-		buf.WriteString("}\n")
-		return buf.String()
-	}
-
-	buf.WriteString(fmt.Sprintf("\treturn &%s{\n", spec.TypeName))
-	buf.WriteString(fmt.Sprintf("\t\t%s: core,\n", coreField))
-	for _, child := range spec.Children {
-		buf.WriteString(fmt.Sprintf("\t\t%s: %s(core),\n", child.FieldName, child.ConstructorName))
-	}
-	buf.WriteString("\t}\n")
-	buf.WriteString("}\n")
-	return buf.String()
-}
-
 func goHTTPMethodConstant(method string) string {
 	switch strings.ToUpper(strings.TrimSpace(method)) {
 	case http.MethodGet:
@@ -73,7 +73,7 @@ func TestRenderGoTemplatesModuleConvertsCurlyPath(t *testing.T) {
 }
 
 func TestListGoAPIModuleRenderersIncludesInlineRenderers(t *testing.T) {
-	renderers := listGoAPIModuleRenderers()
+	renderers := listGoAPIModuleRenderers(newGoModelRegistry(nil))
 	if len(renderers) < len(goInlineAPIModuleRenderers) {
 		t.Fatalf("expected at least %d renderers, got %d", len(goInlineAPIModuleRenderers), len(renderers))
 	}
@@ -0,0 +1,22 @@
+package gogen
+
+import (
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/generator"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+func init() {
+	generator.Register(generator.NewBackend(Language, DefaultDiffIgnorePaths, generate))
+}
+
+func generate(cfg *config.Config, doc *openapi.Document) (generator.Result, error) {
+	result, err := GenerateGo(cfg, doc)
+	if err != nil {
+		return generator.Result{}, err
+	}
+	return generator.Result{
+		GeneratedFiles: result.GeneratedFiles,
+		GeneratedOps:   result.GeneratedOps,
+	}, nil
+}
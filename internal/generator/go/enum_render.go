@@ -0,0 +1,243 @@
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+type goEnumItem struct {
+	ConstName string
+	Literal   string
+}
+
+type goEnumDefinition struct {
+	SchemaName  string
+	Name        string
+	PackageName string
+	Base        string
+	Comment     string
+	Receiver    string
+	Items       []goEnumItem
+	SkipPtr     bool
+}
+
+// goEnumsFileName holds spec enums that no generated package references.
+const goEnumsFileName = "enums.go"
+
+func (r *goModelRegistry) addEnum(packageName string, configName string, schemaName string, schema *openapi.Schema, model config.ModelSchema) {
+	if existing := r.enumBySchema[schemaName]; schemaName != "" && existing != nil {
+		r.alias(packageName, configName, existing.Name)
+		return
+	}
+	name := goModelTypeName(configName)
+	if _, reserved := r.reserved[name]; reserved {
+		// An emitted runtime file or handwritten module already declares it.
+		r.alias(packageName, configName, name)
+		return
+	}
+	enum := &goEnumDefinition{
+		SchemaName:  schemaName,
+		Name:        r.uniqueName(packageName, name),
+		PackageName: packageName,
+		Base:        goEnumBaseType(model, schema),
+		Comment:     oneLineText(goSchemaDescription(schema)),
+	}
+	type rawItem struct {
+		name  string
+		value interface{}
+	}
+	rawItems := make([]rawItem, 0)
+	if len(model.EnumValues) > 0 {
+		for _, item := range model.EnumValues {
+			rawItems = append(rawItems, rawItem{name: strings.TrimSpace(item.Name), value: item.Value})
+		}
+	} else if schema != nil {
+		for _, value := range schema.Enum {
+			rawItems = append(rawItems, rawItem{value: value})
+		}
+	}
+	seenValues := map[string]struct{}{}
+	seenNames := map[string]string{}
+	for _, item := range rawItems {
+		value := strings.Trim(strings.TrimSpace(fmt.Sprint(item.value)), "\"")
+		if _, exists := seenValues[value]; exists {
+			continue
+		}
+		seenValues[value] = struct{}{}
+		member := goEnumMemberName(enum.Name, item.name, value)
+		constName := enum.Name + member
+		if previous, exists := seenNames[constName]; exists {
+			r.errs = append(r.errs, fmt.Errorf("go enum %s: values %q and %q both map to constant %s", enum.Name, previous, value, constName))
+			continue
+		}
+		seenNames[constName] = value
+		literal := fmt.Sprintf("%q", value)
+		if enum.Base == "int" {
+			literal = value
+		}
+		enum.Items = append(enum.Items, goEnumItem{ConstName: constName, Literal: literal})
+	}
+	for _, item := range enum.Items {
+		if owner, exists := r.constants[item.ConstName]; exists {
+			r.errs = append(r.errs, fmt.Errorf("go enum constant %s of %s collides with %s", item.ConstName, enum.Name, owner))
+			continue
+		}
+		if _, exists := r.reserved[item.ConstName]; exists {
+			r.errs = append(r.errs, fmt.Errorf("go enum constant %s of %s collides with an existing type", item.ConstName, enum.Name))
+			continue
+		}
+		r.constants[item.ConstName] = enum.Name
+	}
+	r.enums = append(r.enums, enum)
+	r.enumByName[enum.Name] = enum
+	if schemaName != "" {
+		r.enumBySchema[schemaName] = enum
+	}
+	r.alias(packageName, configName, enum.Name)
+}
+
+// addUnreferencedSchemaEnums registers the component enums that no generated
+// package picked up; they are rendered into enums.go.
+func (r *goModelRegistry) addUnreferencedSchemaEnums() {
+	if r.doc == nil {
+		return
+	}
+	names := make([]string, 0, len(r.doc.Components.Schemas))
+	for name := range r.doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		schema := r.doc.ResolveSchema(r.doc.Components.Schemas[name])
+		if !ir.IsEnumSchema(schema) || r.enumBySchema[name] != nil {
+			continue
+		}
+		r.addEnum("", name, name, schema, config.ModelSchema{})
+	}
+}
+
+func (r *goModelRegistry) packageEnums(packageName string) []*goEnumDefinition {
+	if r == nil {
+		return nil
+	}
+	enums := make([]*goEnumDefinition, 0)
+	for _, enum := range r.enums {
+		if enum.PackageName == packageName {
+			enums = append(enums, enum)
+		}
+	}
+	return enums
+}
+
+// err reports enum naming collisions found while building the registry.
+func (r *goModelRegistry) err() error {
+	if r == nil || len(r.errs) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(r.errs))
+	for _, err := range r.errs {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Errorf("go enum name collisions: %s", strings.Join(messages, "; "))
+}
+
+// goEnumMemberName derives the constant suffix for an enum value. Configured
+// member names win over values; SCREAMING_CASE names are camel-cased and a
+// repeated type-name prefix is dropped.
+func goEnumMemberName(typeName string, configured string, value string) string {
+	source := strings.TrimSpace(configured)
+	if source == "" {
+		source = value
+	}
+	if source == "" {
+		return "Empty"
+	}
+	if strings.HasPrefix(source, "-") {
+		source = "minus_" + strings.TrimPrefix(source, "-")
+	}
+	if strings.ToUpper(source) == source || strings.ToLower(source) == source {
+		source = strings.ToLower(source)
+	}
+	member := goExportedName(source)
+	if trimmed := strings.TrimPrefix(member, typeName); trimmed != member && trimmed != "" && unicode.IsUpper([]rune(trimmed)[0]) {
+		member = trimmed
+	}
+	if strings.HasPrefix(member, "Op") && len(member) > 2 && unicode.IsDigit([]rune(member)[2]) {
+		member = "Value" + strings.TrimPrefix(member, "Op")
+	}
+	if member == "" {
+		return "Empty"
+	}
+	return member
+}
+
+func writeGoEnum(buf *bytes.Buffer, enum *goEnumDefinition) {
+	if buf == nil || enum == nil || strings.TrimSpace(enum.Name) == "" {
+		return
+	}
+	base := enum.Base
+	if base == "" {
+		base = "string"
+	}
+	if comment := strings.TrimSpace(enum.Comment); comment != "" {
+		buf.WriteString(fmt.Sprintf("// %s %s\n", enum.Name, comment))
+	}
+	buf.WriteString(fmt.Sprintf("type %s %s\n\n", enum.Name, base))
+	if len(enum.Items) > 0 {
+		buf.WriteString("const (\n")
+		for _, item := range enum.Items {
+			buf.WriteString(fmt.Sprintf("\t%s %s = %s\n", item.ConstName, enum.Name, item.Literal))
+		}
+		buf.WriteString(")\n\n")
+	}
+	receiver := strings.TrimSpace(enum.Receiver)
+	if receiver == "" {
+		receiver = strings.ToLower(enum.Name[:1])
+	}
+	buf.WriteString(fmt.Sprintf("func (%s %s) String() string {\n", receiver, enum.Name))
+	if base == "int" {
+		buf.WriteString(fmt.Sprintf("\treturn strconv.Itoa(int(%s))\n", receiver))
+	} else {
+		buf.WriteString(fmt.Sprintf("\treturn string(%s)\n", receiver))
+	}
+	buf.WriteString("}\n")
+	if !enum.SkipPtr {
+		buf.WriteString("\n")
+		buf.WriteString(fmt.Sprintf("func (%s %s) Ptr() *%s {\n", receiver, enum.Name, enum.Name))
+		buf.WriteString(fmt.Sprintf("\treturn &%s\n", receiver))
+		buf.WriteString("}\n")
+	}
+	buf.WriteString("\n")
+}
+
+func goEnumsUseStrconv(enums []*goEnumDefinition) bool {
+	for _, enum := range enums {
+		if enum.Base == "int" {
+			return true
+		}
+	}
+	return false
+}
+
+func renderGoEnumsModule(models *goModelRegistry) (string, error) {
+	if err := models.err(); err != nil {
+		return "", err
+	}
+	enums := models.packageEnums("")
+	var buf bytes.Buffer
+	buf.WriteString("package coze\n\n")
+	if goEnumsUseStrconv(enums) {
+		buf.WriteString("import \"strconv\"\n\n")
+	}
+	for _, enum := range enums {
+		writeGoEnum(&buf, enum)
+	}
+	return buf.String(), nil
+}
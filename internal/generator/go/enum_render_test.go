@@ -0,0 +1,196 @@
+package gogen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
+)
+
+const goEnumTestSwagger = `
+openapi: 3.0.0
+paths:
+  /v1/datasets:
+    get:
+      parameters:
+        - in: query
+          name: status
+          schema:
+            $ref: '#/components/schemas/DatasetStatus'
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    DatasetStatus:
+      type: integer
+      description: Dataset status.
+      enum: [1, 3, 9]
+    FileFormat:
+      type: string
+      enum: [pdf, docx, ""]
+`
+
+func TestGoModelRegistryRendersEnums(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, goEnumTestSwagger)
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Packages: []config.Package{
+				{
+					Name: "datasets",
+					ModelSchemas: []config.ModelSchema{
+						{
+							Name:     "DocumentSourceType",
+							EnumBase: "dynamic_str",
+							EnumValues: []config.ModelEnumValue{
+								{Name: "LOCAL_FILE", Value: "local_file"},
+								{Name: "DocumentSourceTypeOnlineWeb", Value: "online_web"},
+							},
+						},
+					},
+				},
+			},
+			OperationMappings: []config.OperationMapping{
+				{Path: "/v1/datasets", Method: "get", SDKMethods: []string{"datasets.list"}},
+			},
+		},
+	}
+
+	models := buildGoModelRegistry(cfg, doc, ir.Build(cfg, doc))
+	if err := models.err(); err != nil {
+		t.Fatalf("unexpected enum error: %v", err)
+	}
+	if got, _ := models.configType("datasets", "Optional[DocumentSourceType]"); got != "DocumentSourceType" {
+		t.Fatalf("configType() = %q", got)
+	}
+	if status := models.enumBySchema["DatasetStatus"]; status == nil || status.PackageName != "datasets" {
+		t.Fatalf("expected DatasetStatus to be owned by datasets, got %+v", status)
+	}
+
+	spec := goSwaggerModuleSpec{FileName: "datasets.go", PackageName: "datasets", TypeName: "datasets", ConstructorName: "newDatasets"}
+	content := renderGoSwaggerModule(models, spec, buildGoSwaggerOperationBindings(ir.Build(cfg, doc), "datasets"))
+	for _, fragment := range []string{
+		"\t\"strconv\"\n",
+		"Status *DatasetStatus `query:\"status\" json:\"-\"`",
+		"// DatasetStatus Dataset status.\ntype DatasetStatus int",
+		"DatasetStatusValue1 DatasetStatus = 1",
+		"return strconv.Itoa(int(d))",
+		"func (d DatasetStatus) Ptr() *DatasetStatus {",
+		"DocumentSourceTypeLocalFile DocumentSourceType = \"local_file\"",
+		"DocumentSourceTypeOnlineWeb DocumentSourceType = \"online_web\"",
+	} {
+		if !strings.Contains(content, fragment) {
+			t.Fatalf("expected rendered module to contain %q, got:\n%s", fragment, content)
+		}
+	}
+
+	enums, err := renderGoEnumsModule(models)
+	if err != nil {
+		t.Fatalf("renderGoEnumsModule() error = %v", err)
+	}
+	for _, fragment := range []string{
+		"type FileFormat string",
+		"FileFormatPdf FileFormat = \"pdf\"",
+		"FileFormatEmpty FileFormat = \"\"",
+		"return string(f)",
+	} {
+		if !strings.Contains(enums, fragment) {
+			t.Fatalf("expected enums module to contain %q, got:\n%s", fragment, enums)
+		}
+	}
+	if strings.Contains(enums, "strconv") || strings.Contains(enums, "DatasetStatus") {
+		t.Fatalf("unexpected enums module content:\n%s", enums)
+	}
+}
+
+func TestGoModelRegistryReportsEnumCollisions(t *testing.T) {
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Packages: []config.Package{
+				{
+					Name: "documents",
+					ModelSchemas: []config.ModelSchema{
+						{
+							Name: "FileKind",
+							EnumValues: []config.ModelEnumValue{
+								{Name: "IMAGE", Value: "image"},
+								{Name: "Image", Value: "img"},
+							},
+						},
+						{Name: "File", EnumValues: []config.ModelEnumValue{{Name: "KindImage", Value: "image"}}},
+					},
+				},
+			},
+		},
+	}
+
+	doc := mustParseOpenAPIDoc(t, goEnumTestSwagger)
+	err := buildGoModelRegistry(cfg, doc, ir.Build(cfg, doc)).err()
+	if err == nil {
+		t.Fatal("expected enum collisions to be reported")
+	}
+	for _, fragment := range []string{
+		`go enum FileKind: values "image" and "img" both map to constant FileKindImage`,
+		"go enum constant FileKindImage of File collides with FileKind",
+	} {
+		if !strings.Contains(err.Error(), fragment) {
+			t.Fatalf("expected error to contain %q, got %v", fragment, err)
+		}
+	}
+}
+
+func TestGoEnumMemberName(t *testing.T) {
+	cases := []struct {
+		typeName   string
+		configured string
+		value      string
+		want       string
+	}{
+		{typeName: "AudioFormat", configured: "OGG_OPUS", value: "ogg_opus", want: "OggOpus"},
+		{typeName: "VariableChannel", configured: "VariableChannelCustom", value: "custom", want: "Custom"},
+		{typeName: "ChatEventType", value: "conversation.chat.created", want: "ConversationChatCreated"},
+		{typeName: "BotMode", value: "1", want: "Value1"},
+		{typeName: "Offset", value: "-1", want: "Minus1"},
+		{typeName: "Status", value: "", want: "Empty"},
+		{typeName: "IDType", configured: "ID", value: "id", want: "ID"},
+	}
+	for _, tc := range cases {
+		if got := goEnumMemberName(tc.typeName, tc.configured, tc.value); got != tc.want {
+			t.Fatalf("goEnumMemberName(%q, %q, %q) = %q, want %q", tc.typeName, tc.configured, tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestGoModelRegistryDeclaresEnumsOnlyMissingFromEmittedFiles(t *testing.T) {
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Packages: []config.Package{
+				{
+					Name: "bots",
+					ModelSchemas: []config.ModelSchema{
+						{Name: "MessageRole", EnumValues: []config.ModelEnumValue{{Name: "USER", Value: "user"}}},
+						{Name: "PublishStatus", EnumValues: []config.ModelEnumValue{{Name: "ALL", Value: "all"}}},
+					},
+				},
+			},
+		},
+	}
+
+	doc := mustParseOpenAPIDoc(t, goEnumTestSwagger)
+	models := buildGoModelRegistry(cfg, doc, ir.Build(cfg, doc))
+	if err := models.err(); err != nil {
+		t.Fatalf("unexpected enum error: %v", err)
+	}
+	if _, ok := models.enumByName["MessageRole"]; ok {
+		t.Fatal("expected MessageRole from the runtime files to be reused, not redeclared")
+	}
+	if got, _ := models.configType("bots", "MessageRole"); got != "MessageRole" {
+		t.Fatalf("configType(MessageRole) = %q", got)
+	}
+	spec := goSwaggerModuleSpec{FileName: "bots.go", PackageName: "bots", TypeName: "bots", ConstructorName: "newBots"}
+	content := renderGoSwaggerModule(models, spec, nil)
+	if !strings.Contains(content, "type PublishStatus string") || !strings.Contains(content, "PublishStatusAll PublishStatus = \"all\"") {
+		t.Fatalf("expected PublishStatus to be declared, got:\n%s", content)
+	}
+}
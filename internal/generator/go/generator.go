@@ -10,15 +10,18 @@ import (
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
 	"github.com/coze-dev/coze-sdk-gen/internal/generator/fsutil"
-	pygen "github.com/coze-dev/coze-sdk-gen/internal/generator/python"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
 	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
 )
 
-type goOperationBinding struct {
-	MethodName string
-	Path       string
-	Method     string
-	Summary    string
+const Language = "go"
+
+// DefaultDiffIgnorePaths are the coze-go entries a regeneration leaves alone.
+var DefaultDiffIgnorePaths = []string{
+	".git",
+	".github",
+	"README.md",
+	"*_test.go",
 }
 
 type Result struct {
@@ -43,22 +46,23 @@ func GenerateGo(cfg *config.Config, doc *openapi.Document) (Result, error) {
 		return Result{}, fmt.Errorf("config and swagger mismatch: %s", report.Error())
 	}
 
-	bindings := buildGoOperationBindings(cfg, doc)
-	if len(bindings) == 0 {
+	api := ir.Build(cfg, doc)
+	operations := api.OperationsFor(Language)
+	if len(operations) == 0 {
 		return Result{}, fmt.Errorf("no operations selected for generation")
 	}
 
-	if err := fsutil.CleanOutputDirPreserveEntries(cfg.OutputSDK, cfg.DiffIgnorePathsForLanguage("go")); err != nil {
+	if err := fsutil.CleanOutputDirPreserveEntries(cfg.OutputSDK, cfg.DiffIgnorePathsForLanguage(Language)); err != nil {
 		return Result{}, fmt.Errorf("prepare output directory %q: %w", cfg.OutputSDK, err)
 	}
 
 	writer := &fileWriter{
 		written: map[string]struct{}{},
 	}
-	if err := writeGoRuntimeScaffolding(cfg.OutputSDK, writer); err != nil {
+	if err := writeGoRuntimeScaffolding(cfg.OutputSDK, doc, writer); err != nil {
 		return Result{}, err
 	}
-	if err := writeGoAPIModules(cfg, doc, writer); err != nil {
+	if err := writeGoAPIModules(cfg, doc, api, writer); err != nil {
 		return Result{}, err
 	}
 	if err := writeGoExtraAssets(cfg.OutputSDK, writer); err != nil {
@@ -67,50 +71,11 @@ func GenerateGo(cfg *config.Config, doc *openapi.Document) (Result, error) {
 
 	return Result{
 		GeneratedFiles: writer.count,
-		GeneratedOps:   len(bindings),
+		GeneratedOps:   len(operations),
 	}, nil
 }
 
-func buildGoOperationBindings(cfg *config.Config, doc *openapi.Document) []goOperationBinding {
-	allOps := doc.ListOperationDetails()
-	bindings := make([]goOperationBinding, 0, len(allOps))
-	seen := map[string]int{}
-
-	for _, details := range allOps {
-		base := strings.TrimSpace(details.OperationID)
-		if base == "" {
-			base = pygen.DefaultMethodName(details.OperationID, details.Path, details.Method)
-		}
-		name := normalizeGoExportedIdentifier(base)
-		if name == "" {
-			name = "Operation"
-		}
-
-		count := seen[name]
-		seen[name] = count + 1
-		if count > 0 {
-			name = fmt.Sprintf("%s%d", name, count+1)
-		}
-
-		bindings = append(bindings, goOperationBinding{
-			MethodName: name,
-			Path:       details.Path,
-			Method:     strings.ToUpper(details.Method),
-			Summary:    goOperationSummary(details),
-		})
-	}
-	return bindings
-}
-
-func goOperationSummary(details openapi.OperationDetails) string {
-	summary := strings.TrimSpace(details.Summary)
-	if summary == "" {
-		summary = strings.TrimSpace(details.Description)
-	}
-	return oneLineText(summary)
-}
-
-func writeGoRuntimeScaffolding(outputDir string, writer *fileWriter) error {
+func writeGoRuntimeScaffolding(outputDir string, doc *openapi.Document, writer *fileWriter) error {
 	textAssets := map[string]string{
 		".gitignore":      "gitignore.tpl",
 		"codecov.yml":     "codecov.yml.tpl",
@@ -132,37 +97,15 @@ func writeGoRuntimeScaffolding(outputDir string, writer *fileWriter) error {
 		}
 	}
 
-	goAssets := map[string]string{
-		"auth.go":                          "auth.go.tpl",
-		"auth_token.go":                    "auth_token.go.tpl",
-		"base_model.go":                    "base_model.go.tpl",
-		"client.go":                        "client.go.tpl",
-		"common.go":                        "common.go.tpl",
-		"const.go":                         "const.go.tpl",
-		"error.go":                         "error.go.tpl",
-		"enterprises.go":                   "enterprises.go.tpl",
-		"logger.go":                        "logger.go.tpl",
-		"pagination.go":                    "pagination.go.tpl",
-		"request.go":                       "request.go.tpl",
-		"stores.go":                        "stores.go.tpl",
-		"stream_reader.go":                 "stream_reader.go.tpl",
-		"user_agent.go":                    "user_agent.go.tpl",
-		"utils.go":                         "utils.go.tpl",
-		"websocket.go":                     "websocket.go.tpl",
-		"websocket_audio.go":               "websocket_audio.go.tpl",
-		"websocket_audio_speech_client.go": "websocket_audio_speech_client.go.tpl",
-		"websocket_audio_speech.go":        "websocket_audio_speech.go.tpl",
-		"websocket_audio_transcription_client.go": "websocket_audio_transcription_client.go.tpl",
-		"websocket_audio_transcription.go":        "websocket_audio_transcription.go.tpl",
-		"websocket_chat_client.go":                "websocket_chat_client.go.tpl",
-		"websocket_chat.go":                       "websocket_chat.go.tpl",
-		"websocket_client.go":                     "websocket_client.go.tpl",
-		"websocket_event.go":                      "websocket_event.go.tpl",
-		"websocket_event_type.go":                 "websocket_event_type.go.tpl",
-		"websocket_wait.go":                       "websocket_wait.go.tpl",
+	runtimeData, err := newGoRuntimeData(doc)
+	if err != nil {
+		return err
 	}
-	for target, asset := range goAssets {
+	for target, asset := range goRuntimeAssets {
 		content, err := renderGoRuntimeAsset(asset)
+		if _, ok := goTemplatedRuntimeAssets[asset]; ok {
+			content, err = renderGoRuntimeTemplate(asset, runtimeData)
+		}
 		if err != nil {
 			return err
 		}
@@ -177,6 +120,75 @@ func writeGoRuntimeScaffolding(outputDir string, writer *fileWriter) error {
 	return nil
 }
 
+// goRuntimeAssets maps each emitted runtime Go file to its template.
+var goRuntimeAssets = map[string]string{
+	"auth.go":                          "auth.go.tpl",
+	"auth_token.go":                    "auth_token.go.tpl",
+	"base_model.go":                    "base_model.go.tpl",
+	"client.go":                        "client.go.tpl",
+	"common.go":                        "common.go.tpl",
+	"const.go":                         "const.go.tpl",
+	"error.go":                         "error.go.tpl",
+	"enterprises.go":                   "enterprises.go.tpl",
+	"logger.go":                        "logger.go.tpl",
+	"pagination.go":                    "pagination.go.tpl",
+	"request.go":                       "request.go.tpl",
+	"stores.go":                        "stores.go.tpl",
+	"stream_reader.go":                 "stream_reader.go.tpl",
+	"stream_events.go":                 "stream_events.go.tpl",
+	"user_agent.go":                    "user_agent.go.tpl",
+	"utils.go":                         "utils.go.tpl",
+	"websocket.go":                     "websocket.go.tpl",
+	"websocket_audio.go":               "websocket_audio.go.tpl",
+	"websocket_audio_speech_client.go": "websocket_audio_speech_client.go.tpl",
+	"websocket_audio_speech.go":        "websocket_audio_speech.go.tpl",
+	"websocket_audio_transcription_client.go": "websocket_audio_transcription_client.go.tpl",
+	"websocket_audio_transcription.go":        "websocket_audio_transcription.go.tpl",
+	"websocket_chat_client.go":                "websocket_chat_client.go.tpl",
+	"websocket_chat.go":                       "websocket_chat.go.tpl",
+	"websocket_client.go":                     "websocket_client.go.tpl",
+	"websocket_event.go":                      "websocket_event.go.tpl",
+	"websocket_event_type.go":                 "websocket_event_type.go.tpl",
+	"websocket_wait.go":                       "websocket_wait.go.tpl",
+}
+
+// goTemplatedRuntimeAssets are the runtime assets rendered with goRuntimeData.
+var goTemplatedRuntimeAssets = map[string]struct{}{
+	"auth.go.tpl":   {},
+	"client.go.tpl": {},
+	"const.go.tpl":  {},
+}
+
+type goBaseURL struct {
+	Const string
+	URL   string
+}
+
+type goRuntimeData struct {
+	BaseURLs       []goBaseURL
+	DefaultBaseURL string
+}
+
+// newGoRuntimeData names a base-URL constant per spec server region; the
+// first one is the client default.
+func newGoRuntimeData(doc *openapi.Document) (goRuntimeData, error) {
+	baseURLs, err := ir.BaseURLs(doc)
+	if err != nil {
+		return goRuntimeData{}, err
+	}
+	data := goRuntimeData{}
+	for _, baseURL := range baseURLs {
+		data.BaseURLs = append(data.BaseURLs, goBaseURL{
+			Const: goExportedName(baseURL.Region) + "BaseURL",
+			URL:   baseURL.URL,
+		})
+	}
+	if len(data.BaseURLs) > 0 {
+		data.DefaultBaseURL = data.BaseURLs[0].Const
+	}
+	return data, nil
+}
+
 func writeGoExtraAssets(outputDir string, writer *fileWriter) error {
 	assets, err := listGoExtraAssets()
 	if err != nil {
@@ -201,8 +213,14 @@ func writeGoExtraAssets(outputDir string, writer *fileWriter) error {
 	return nil
 }
 
-func writeGoAPIModules(cfg *config.Config, doc *openapi.Document, writer *fileWriter) error {
-	for _, renderer := range listGoAPIModuleRenderers() {
+func writeGoAPIModules(cfg *config.Config, doc *openapi.Document, api *ir.API, writer *fileWriter) error {
+	// The model registry spans every package, so the swagger module renderers
+	// all share this one.
+	models := buildGoModelRegistry(cfg, doc, api)
+	if err := models.err(); err != nil {
+		return err
+	}
+	for _, renderer := range listGoAPIModuleRenderers(models) {
 		content, err := renderer.Render(cfg, doc)
 		if err != nil {
 			return err
@@ -218,34 +236,6 @@ func writeGoAPIModules(cfg *config.Config, doc *openapi.Document, writer *fileWr
 	return nil
 }
 
-func normalizeGoExportedIdentifier(value string) string {
-	parts := splitIdentifierWords(value)
-	if len(parts) == 0 {
-		return ""
-	}
-	var buf strings.Builder
-	for _, part := range parts {
-		if part == "" {
-			continue
-		}
-		runes := []rune(part)
-		first := unicode.ToUpper(runes[0])
-		buf.WriteRune(first)
-		if len(runes) > 1 {
-			buf.WriteString(string(runes[1:]))
-		}
-	}
-	result := buf.String()
-	if result == "" {
-		return ""
-	}
-	firstRune := []rune(result)[0]
-	if unicode.IsDigit(firstRune) {
-		return "Op" + result
-	}
-	return result
-}
-
 func splitIdentifierWords(value string) []string {
 	if value == "" {
 		return nil
@@ -613,7 +613,7 @@ func (r *workflowsChat) Stream(ctx context.Context, req *WorkflowsChatStreamReq)
 		URL:    %q,
 		Body:   req,
 	}
-	response := new(createChatsResp)
+	response := new(workflowsChatStreamResp)
 	err := r.client.rawRequest(ctx, request, response)
 	return newStream(ctx, r.client, response.HTTPResponse, parseChatEvent), err
 }
@@ -629,6 +629,11 @@ type WorkflowsChatStreamReq struct {
 	Ext                map[string]string `+"`json:\"ext,omitempty\"`"+`             // 扩展信息
 }
 
+type workflowsChatStreamResp struct {
+	baseResponse
+	HTTPResponse *http.Response `+"`json:\"-\"`"+`
+}
+
 type workflowsChat struct {
 	client *core
 }
@@ -0,0 +1,683 @@
+package gogen
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+type goModelDefinition struct {
+	SchemaName       string
+	Name             string
+	ConfigName       string
+	PackageName      string
+	Schema           *openapi.Schema
+	FieldOrder       []string
+	RequiredFields   []string
+	FieldTypes       map[string]string
+	ExtraFields      []config.ModelField
+	ExcludeUnordered bool
+}
+
+// goModelRegistry owns the Go model structs rendered from components.schemas and
+// package model_schemas. Every model lives in exactly one package file because
+// all generated files share the coze package namespace.
+type goModelRegistry struct {
+	doc           *openapi.Document
+	models        []*goModelDefinition
+	bySchema      map[string]*goModelDefinition
+	byName        map[string]*goModelDefinition
+	enums         []*goEnumDefinition
+	enumBySchema  map[string]*goEnumDefinition
+	enumByName    map[string]*goEnumDefinition
+	unions        []*goUnionDefinition
+	unionBySchema map[string]*goUnionDefinition
+	unionByName   map[string]*goUnionDefinition
+	constants     map[string]string
+	aliases       map[string]map[string]string
+	reserved      map[string]struct{}
+	// specs are the swagger modules in package name order, and bindings holds
+	// the operations of each of their packages.
+	specs    []goSwaggerModuleSpec
+	bindings map[string][]goSwaggerOperationBinding
+	errs     []error
+}
+
+var goTypeDeclPattern = regexp.MustCompile(`(?m)^type\s+([A-Za-z_][A-Za-z0-9_]*)`)
+
+func newGoModelRegistry(doc *openapi.Document) *goModelRegistry {
+	return &goModelRegistry{
+		doc:           doc,
+		bySchema:      map[string]*goModelDefinition{},
+		byName:        map[string]*goModelDefinition{},
+		enumBySchema:  map[string]*goEnumDefinition{},
+		enumByName:    map[string]*goEnumDefinition{},
+		unionBySchema: map[string]*goUnionDefinition{},
+		unionByName:   map[string]*goUnionDefinition{},
+		constants:     map[string]string{},
+		aliases:       map[string]map[string]string{},
+		reserved:      map[string]struct{}{},
+		bindings:      map[string][]goSwaggerOperationBinding{},
+	}
+}
+
+func buildGoModelRegistry(cfg *config.Config, doc *openapi.Document, api *ir.API) *goModelRegistry {
+	registry := newGoModelRegistry(doc)
+	if cfg == nil {
+		return registry
+	}
+	for name := range goReservedTypeNames(cfg, doc) {
+		registry.reserved[name] = struct{}{}
+	}
+
+	specs := buildGoSwaggerModuleSpecs(api)
+	registry.specs = specs
+	for _, spec := range specs {
+		bindings := buildGoSwaggerOperationBindings(api, spec.PackageName)
+		registry.bindings[spec.PackageName] = bindings
+		for _, binding := range bindings {
+			op := buildGoSwaggerOperation(registry, spec, binding)
+			registry.reserved[op.Request.Name] = struct{}{}
+			for _, def := range op.Types {
+				registry.reserved[def.Name] = struct{}{}
+			}
+		}
+	}
+
+	for _, spec := range specs {
+		if pkg, ok := api.Package(spec.PackageName); ok {
+			for _, model := range pkg.Models {
+				registry.addConfiguredModel(spec.PackageName, model)
+			}
+		}
+		for _, binding := range registry.bindings[spec.PackageName] {
+			if !binding.HasDetails {
+				continue
+			}
+			for _, schemaName := range goCollectSchemaRefs(doc, goOperationSchemaSeeds(doc, binding), "") {
+				registry.addSchemaModel(spec.PackageName, schemaName)
+			}
+		}
+		for i := 0; i < len(registry.models); i++ {
+			model := registry.models[i]
+			if model.PackageName != spec.PackageName {
+				continue
+			}
+			for _, schemaName := range registry.modelSchemaRefs(model) {
+				registry.addSchemaModel(spec.PackageName, schemaName)
+			}
+		}
+	}
+	registry.addUnreferencedSchemaEnums()
+	return registry
+}
+
+// goReservedTypeNames collects the type names already declared by emitted
+// runtime files and handwritten modules so generated models never shadow them.
+func goReservedTypeNames(cfg *config.Config, doc *openapi.Document) map[string]struct{} {
+	names := map[string]struct{}{}
+	collect := func(content string) {
+		for _, match := range goTypeDeclPattern.FindAllStringSubmatch(content, -1) {
+			names[match[1]] = struct{}{}
+		}
+	}
+	for _, asset := range goRuntimeAssets {
+		content, err := renderGoRuntimeAsset(asset)
+		if err == nil {
+			collect(content)
+		}
+	}
+	renderers := append(append([]goAPIModuleRenderer(nil), goCustomAPIModuleRenderers...), goInlineAPIModuleRenderers...)
+	for _, renderer := range renderers {
+		content, err := renderer.Render(cfg, doc)
+		if err == nil {
+			collect(content)
+		}
+	}
+	return names
+}
+
+func (r *goModelRegistry) addConfiguredModel(packageName string, irModel *ir.Model) {
+	model := *irModel.Config
+	schemaName, schema := irModel.SchemaName, irModel.Schema
+	configName := irModel.Name
+	if configName == "" {
+		configName = goModelNameFromSchema(packageName, schemaName)
+	}
+	if configName == "" {
+		return
+	}
+	if irModel.IsEnum {
+		r.addEnum(packageName, configName, schemaName, schema, model)
+		return
+	}
+	if schema == nil && schemaName != "" && !model.AllowMissingInSwagger {
+		return
+	}
+	if existing := r.bySchema[schemaName]; schemaName != "" && existing != nil {
+		r.alias(packageName, configName, existing.Name)
+		return
+	}
+	fieldTypes := map[string]string{}
+	for k, v := range model.FieldTypes {
+		fieldTypes[k] = v
+	}
+	r.register(&goModelDefinition{
+		SchemaName:       schemaName,
+		Name:             r.uniqueName(packageName, goModelTypeName(configName)),
+		ConfigName:       configName,
+		PackageName:      packageName,
+		Schema:           schema,
+		FieldOrder:       append([]string(nil), model.FieldOrder...),
+		RequiredFields:   append([]string(nil), model.RequiredFields...),
+		FieldTypes:       fieldTypes,
+		ExtraFields:      append([]config.ModelField(nil), model.ExtraFields...),
+		ExcludeUnordered: model.ExcludeUnorderedFields,
+	})
+}
+
+func (r *goModelRegistry) addSchemaModel(packageName string, schemaName string) {
+	schemaName = strings.TrimSpace(schemaName)
+	if schemaName == "" || r.doc == nil || r.bySchema[schemaName] != nil || r.enumBySchema[schemaName] != nil || r.unionBySchema[schemaName] != nil {
+		return
+	}
+	component, ok := r.doc.Components.Schemas[schemaName]
+	if !ok || component == nil {
+		return
+	}
+	schema := r.doc.EffectiveSchema(component)
+	configName := goModelNameFromSchema(packageName, schemaName)
+	if ir.IsEnumSchema(schema) {
+		r.addEnum(packageName, configName, schemaName, schema, config.ModelSchema{})
+		return
+	}
+	if schema != nil && len(schema.Properties) == 0 && r.addUnion(packageName, configName, schemaName, schema) {
+		return
+	}
+	if schema == nil || len(schema.Properties) == 0 {
+		return
+	}
+	r.register(&goModelDefinition{
+		SchemaName:  schemaName,
+		Name:        r.uniqueName(packageName, goModelTypeName(configName)),
+		ConfigName:  configName,
+		PackageName: packageName,
+		Schema:      schema,
+		FieldTypes:  map[string]string{},
+	})
+}
+
+func (r *goModelRegistry) register(model *goModelDefinition) {
+	r.models = append(r.models, model)
+	r.byName[model.Name] = model
+	if model.SchemaName != "" {
+		r.bySchema[model.SchemaName] = model
+	}
+	r.alias(model.PackageName, model.ConfigName, model.Name)
+}
+
+func (r *goModelRegistry) alias(packageName string, configName string, goName string) {
+	if r.aliases[packageName] == nil {
+		r.aliases[packageName] = map[string]string{}
+	}
+	r.aliases[packageName][configName] = goName
+	if r.aliases[""] == nil {
+		r.aliases[""] = map[string]string{}
+	}
+	if _, exists := r.aliases[""][configName]; !exists {
+		r.aliases[""][configName] = goName
+	}
+}
+
+func (r *goModelRegistry) uniqueName(packageName string, name string) string {
+	taken := func(candidate string) bool {
+		_, reserved := r.reserved[candidate]
+		_, used := r.byName[candidate]
+		_, enum := r.enumByName[candidate]
+		_, union := r.unionByName[candidate]
+		_, constant := r.constants[candidate]
+		return reserved || used || enum || union || constant
+	}
+	if !taken(name) {
+		return name
+	}
+	prefixed := goExportedName(packageName) + goExportedName(name)
+	if name != goExportedName(name) {
+		prefixed = goUnexportedName(prefixed)
+	}
+	if !taken(prefixed) {
+		return prefixed
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s%d", prefixed, i)
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+}
+
+func (r *goModelRegistry) isModel(goType string) bool {
+	if r == nil {
+		return false
+	}
+	_, ok := r.byName[goType]
+	return ok
+}
+
+// typeForSchema maps an OpenAPI schema onto the Go type used in generated
+// request, response and model structs.
+func (r *goModelRegistry) typeForSchema(schema *openapi.Schema) string {
+	if r == nil || schema == nil {
+		return "any"
+	}
+	if name, ok := r.doc.SchemaName(schema); ok {
+		if model := r.bySchema[name]; model != nil {
+			return model.Name
+		}
+		if enum := r.enumBySchema[name]; enum != nil {
+			return enum.Name
+		}
+		if union := r.unionBySchema[name]; union != nil {
+			return union.Name
+		}
+	}
+	resolved := r.doc.ResolveSchema(schema)
+	if resolved == nil {
+		return "any"
+	}
+	if len(resolved.AllOf) > 0 {
+		// A lone allOf member is a wrapper that only adds docs to a ref.
+		if len(resolved.AllOf) == 1 && len(resolved.Properties) == 0 {
+			return r.typeForSchema(resolved.AllOf[0])
+		}
+		resolved = r.doc.EffectiveSchema(resolved)
+	}
+	switch resolved.Type {
+	case "string":
+		if resolved.Format == "binary" {
+			return "io.Reader"
+		}
+		return "string"
+	case "integer":
+		if resolved.Format == "int64" {
+			return "int64"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "[]" + r.elementType(r.typeForSchema(resolved.Items))
+	case "object":
+		if additional, ok := resolved.AdditionalProperties.(map[string]interface{}); ok {
+			if valueType, ok := additional["type"].(string); ok {
+				return "map[string]" + r.typeForSchema(&openapi.Schema{Type: valueType})
+			}
+		}
+		return "map[string]any"
+	default:
+		if len(resolved.Enum) > 0 {
+			return "string"
+		}
+		if len(resolved.Properties) > 0 {
+			return "map[string]any"
+		}
+		return "any"
+	}
+}
+
+func (r *goModelRegistry) elementType(goType string) string {
+	if r.isModel(goType) {
+		return "*" + goType
+	}
+	return goType
+}
+
+// configType converts the python-flavoured type names used by model_schemas
+// and operation_mappings into Go types. The second result reports whether the
+// type was wrapped in Optional[...].
+func (r *goModelRegistry) configType(packageName string, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	optional := false
+	if inner, ok := goUnwrapConfigType(value, "Optional"); ok {
+		value = inner
+		optional = true
+	}
+	switch value {
+	case "str":
+		return "string", optional
+	case "int":
+		return "int", optional
+	case "float":
+		return "float64", optional
+	case "bool":
+		return "bool", optional
+	case "bytes":
+		return "[]byte", optional
+	case "", "Any", "object":
+		return "any", optional
+	}
+	if inner, ok := goUnwrapConfigType(value, "List"); ok {
+		elem, _ := r.configType(packageName, inner)
+		return "[]" + r.elementType(elem), optional
+	}
+	if inner, ok := goUnwrapConfigType(value, "Dict"); ok {
+		parts := strings.SplitN(inner, ",", 2)
+		if len(parts) == 2 {
+			elem, _ := r.configType(packageName, parts[1])
+			return "map[string]" + r.elementType(elem), optional
+		}
+		return "map[string]any", optional
+	}
+	if r != nil {
+		for _, scope := range []string{packageName, ""} {
+			if name, ok := r.aliases[scope][value]; ok {
+				return name, optional
+			}
+		}
+	}
+	return "any", optional
+}
+
+func goUnwrapConfigType(value string, wrapper string) (string, bool) {
+	prefix := wrapper + "["
+	if !strings.HasPrefix(value, prefix) || !strings.HasSuffix(value, "]") {
+		return "", false
+	}
+	return strings.TrimSpace(value[len(prefix) : len(value)-1]), true
+}
+
+func (r *goModelRegistry) modelSchemaRefs(model *goModelDefinition) []string {
+	if model.Schema == nil {
+		return nil
+	}
+	schemas := make([]*openapi.Schema, 0, len(model.Schema.Properties))
+	for _, name := range goModelPropertyNames(model) {
+		if strings.TrimSpace(model.FieldTypes[name]) != "" {
+			continue
+		}
+		schemas = append(schemas, model.Schema.Properties[name])
+	}
+	return goCollectSchemaRefs(r.doc, schemas, model.SchemaName)
+}
+
+// goOperationSchemaSeeds returns the schemas that end up as fields of the
+// request and response structs of an operation.
+func goOperationSchemaSeeds(doc *openapi.Document, binding goSwaggerOperationBinding) []*openapi.Schema {
+	details := binding.Details
+	seeds := make([]*openapi.Schema, 0)
+	for _, param := range details.Parameters {
+		seeds = append(seeds, param.Schema)
+	}
+	if body := doc.ResolveSchema(details.RequestBodySchema); body != nil {
+		for _, property := range body.Properties {
+			seeds = append(seeds, property)
+		}
+	}
+	if response := doc.ResolveSchema(details.ResponseSchema); response != nil {
+		for name, property := range response.Properties {
+			if name == "code" || name == "msg" {
+				continue
+			}
+			seeds = append(seeds, property)
+		}
+	}
+	return seeds
+}
+
+// goCollectSchemaRefs walks inline schemas and reports the component schemas
+// they reference. It stops at every named component: nested models collect
+// their own references when they are registered.
+func goCollectSchemaRefs(doc *openapi.Document, schemas []*openapi.Schema, self string) []string {
+	if doc == nil {
+		return nil
+	}
+	refs := map[string]struct{}{}
+	visited := map[*openapi.Schema]struct{}{}
+	var walk func(*openapi.Schema)
+	walk = func(current *openapi.Schema) {
+		if current == nil {
+			return
+		}
+		if name, ok := doc.SchemaName(current); ok && name != self {
+			refs[name] = struct{}{}
+			return
+		}
+		resolved := doc.ResolveSchema(current)
+		if resolved == nil {
+			return
+		}
+		if _, ok := visited[resolved]; ok {
+			return
+		}
+		visited[resolved] = struct{}{}
+		for _, property := range resolved.Properties {
+			walk(property)
+		}
+		walk(resolved.Items)
+		for _, item := range resolved.AllOf {
+			walk(item)
+		}
+	}
+	for _, schema := range schemas {
+		walk(schema)
+	}
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// packageModels returns the models owned by a package ordered so that every
+// model follows the models it references.
+func (r *goModelRegistry) packageModels(packageName string) []*goModelDefinition {
+	if r == nil {
+		return nil
+	}
+	owned := make([]*goModelDefinition, 0)
+	index := map[string]int{}
+	for _, model := range r.models {
+		if model.PackageName != packageName {
+			continue
+		}
+		index[model.Name] = len(owned)
+		owned = append(owned, model)
+	}
+	state := make([]int, len(owned))
+	ordered := make([]*goModelDefinition, 0, len(owned))
+	var visit func(i int)
+	visit = func(i int) {
+		if state[i] != 0 {
+			return
+		}
+		state[i] = 1
+		deps := make([]int, 0)
+		for _, field := range r.modelStruct(owned[i]).Fields {
+			name := strings.TrimLeft(field.Type, "[]*")
+			if dep, ok := index[name]; ok && dep != i {
+				deps = append(deps, dep)
+			}
+		}
+		sort.Ints(deps)
+		for _, dep := range deps {
+			visit(dep)
+		}
+		state[i] = 2
+		ordered = append(ordered, owned[i])
+	}
+	for i := range owned {
+		visit(i)
+	}
+	return ordered
+}
+
+func (r *goModelRegistry) modelStruct(model *goModelDefinition) goStructDef {
+	def := goStructDef{
+		Name:    model.Name,
+		Comment: oneLineText(goSchemaDescription(model.Schema)),
+		Embeds:  []string{"baseModel"},
+	}
+	required := map[string]bool{}
+	if model.Schema != nil {
+		for _, name := range model.Schema.Required {
+			required[name] = true
+		}
+	}
+	for _, name := range model.RequiredFields {
+		required[strings.TrimSpace(name)] = true
+	}
+	seen := map[string]struct{}{}
+	appendField := func(field goStructField) {
+		if field.Name == "" {
+			return
+		}
+		if _, exists := seen[field.Name]; exists {
+			return
+		}
+		seen[field.Name] = struct{}{}
+		def.Fields = append(def.Fields, field)
+	}
+	for _, name := range goModelPropertyNames(model) {
+		property := model.Schema.Properties[name]
+		fieldType := r.typeForSchema(property)
+		nullable := false
+		if resolved := r.doc.ResolveSchema(property); resolved != nil {
+			nullable = resolved.Nullable
+		}
+		if override := strings.TrimSpace(model.FieldTypes[name]); override != "" {
+			var optional bool
+			fieldType, optional = r.configType(model.PackageName, override)
+			nullable = nullable || optional
+		}
+		isRequired := required[name] && !nullable
+		appendField(goStructField{
+			Name:    goExportedName(name),
+			Type:    r.fieldType(fieldType, isRequired),
+			Tag:     goJSONTag(name, isRequired),
+			Comment: oneLineText(goSchemaDescription(property)),
+		})
+	}
+	for _, extra := range model.ExtraFields {
+		jsonName := strings.TrimSpace(extra.Alias)
+		if jsonName == "" {
+			jsonName = strings.TrimSuffix(strings.TrimSpace(extra.Name), "_")
+		}
+		fieldType, optional := r.configType(model.PackageName, extra.Type)
+		isRequired := extra.Required && !optional
+		appendField(goStructField{
+			Name: goExportedName(jsonName),
+			Type: r.fieldType(fieldType, isRequired),
+			Tag:  goJSONTag(jsonName, isRequired),
+		})
+	}
+	return def
+}
+
+func goModelPropertyNames(model *goModelDefinition) []string {
+	if model.Schema == nil || len(model.Schema.Properties) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(model.Schema.Properties))
+	seen := map[string]struct{}{}
+	for _, raw := range model.FieldOrder {
+		name := strings.TrimSpace(raw)
+		if _, ok := model.Schema.Properties[name]; !ok {
+			continue
+		}
+		if _, exists := seen[name]; exists {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	if model.ExcludeUnordered {
+		return names
+	}
+	remaining := make([]string, 0, len(model.Schema.Properties))
+	for name := range model.Schema.Properties {
+		if _, exists := seen[name]; !exists {
+			remaining = append(remaining, name)
+		}
+	}
+	sort.Strings(remaining)
+	return append(names, remaining...)
+}
+
+func goSchemaDescription(schema *openapi.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	if description := strings.TrimSpace(schema.Description); description != "" {
+		return description
+	}
+	return strings.TrimSpace(schema.Title)
+}
+
+func goEnumBaseType(model config.ModelSchema, schema *openapi.Schema) string {
+	base := strings.TrimSpace(model.EnumBase)
+	if base == "int" || base == "int_enum" || (schema != nil && schema.Type == "integer") {
+		return "int"
+	}
+	return "string"
+}
+
+// goModelNameFromSchema derives a model name from a component schema name.
+// Synthetic names produced by swagger flattening (properties_data_properties_*)
+// are trimmed and prefixed with the singular package name.
+func goModelNameFromSchema(packageName string, schemaName string) string {
+	candidate := strings.TrimSpace(schemaName)
+	if candidate == "" {
+		return ""
+	}
+	synthetic := false
+	for _, prefix := range []string{"properties_data_properties_", "properties_"} {
+		if strings.HasPrefix(candidate, prefix) {
+			candidate = strings.TrimPrefix(candidate, prefix)
+			synthetic = true
+		}
+	}
+	if !synthetic {
+		return candidate
+	}
+	candidate = strings.ReplaceAll(candidate, "_properties_", "_")
+	candidate = strings.ReplaceAll(candidate, "_items_", "_")
+	candidate = strings.TrimSuffix(candidate, "_items")
+	candidate = strings.Trim(candidate, "_")
+	if prefix := goSingularPackageName(packageName); prefix != "" && !strings.HasPrefix(candidate, prefix+"_") {
+		candidate = prefix + "_" + candidate
+	}
+	return candidate
+}
+
+func goSingularPackageName(packageName string) string {
+	name := strings.Trim(strings.TrimSpace(packageName), "_")
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses") && len(name) > 4:
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && len(name) > 1:
+		return strings.TrimSuffix(name, "s")
+	default:
+		return name
+	}
+}
+
+// goModelTypeName keeps python private class names (leading underscore)
+// unexported in Go.
+func goModelTypeName(name string) string {
+	name = strings.TrimSpace(name)
+	exported := goExportedName(name)
+	if strings.HasPrefix(name, "_") {
+		return goUnexportedName(exported)
+	}
+	return exported
+}
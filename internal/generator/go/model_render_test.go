@@ -0,0 +1,248 @@
+package gogen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
+)
+
+const goModelTestSwagger = `
+openapi: 3.0.0
+paths:
+  /v1/bots/{bot_id}:
+    get:
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  code:
+                    type: integer
+                  msg:
+                    type: string
+                  data:
+                    $ref: '#/components/schemas/OpenBot'
+components:
+  schemas:
+    OpenBot:
+      type: object
+      description: Bot detail.
+      required: [bot_id, name, prompt]
+      properties:
+        bot_id:
+          type: string
+        name:
+          type: string
+          description: Bot name.
+        description:
+          type: string
+          nullable: true
+        prompt:
+          $ref: '#/components/schemas/PromptInfo'
+        plugins:
+          type: array
+          items:
+            $ref: '#/components/schemas/PluginInfo'
+        mode:
+          type: integer
+    PromptInfo:
+      type: object
+      properties:
+        prompt:
+          type: string
+    PluginInfo:
+      type: object
+      properties:
+        plugin_id:
+          type: string
+`
+
+func TestBuildGoModelRegistryRendersConfiguredModels(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, goModelTestSwagger)
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Packages: []config.Package{
+				{
+					Name: "bots",
+					ModelSchemas: []config.ModelSchema{
+						{
+							Schema:     "OpenBot",
+							Name:       "Bot",
+							FieldOrder: []string{"name", "bot_id"},
+							FieldTypes: map[string]string{"mode": "Optional[BotMode]"},
+							ExtraFields: []config.ModelField{
+								{Name: "from_", Alias: "from", Type: "Optional[str]", Required: true},
+								{Name: "tags", Type: "List[str]"},
+							},
+						},
+						{
+							Name:       "BotMode",
+							EnumValues: []config.ModelEnumValue{{Name: "SINGLE", Value: 0}},
+							EnumBase:   "int",
+						},
+						{
+							Name:                  "_PrivateBotData",
+							AllowMissingInSwagger: true,
+							ExtraFields:           []config.ModelField{{Name: "bots", Type: "List[Bot]", Required: true}},
+						},
+					},
+				},
+			},
+			OperationMappings: []config.OperationMapping{
+				{Path: "/v1/bots/{bot_id}", Method: "get", SDKMethods: []string{"bots.retrieve"}},
+			},
+		},
+	}
+
+	models := buildGoModelRegistry(cfg, doc, ir.Build(cfg, doc))
+	ordered := models.packageModels("bots")
+	names := make([]string, 0, len(ordered))
+	for _, model := range ordered {
+		names = append(names, model.Name)
+	}
+	if got := strings.Join(names, ","); got != "PluginInfo,PromptInfo,Bot,privateBotData" {
+		t.Fatalf("unexpected model order: %s", got)
+	}
+
+	bot := models.modelStruct(models.byName["Bot"])
+	var fields []string
+	for _, field := range bot.Fields {
+		fields = append(fields, field.Name+" "+field.Type+" "+field.Tag)
+	}
+	want := []string{
+		`Name string json:"name"`,
+		`BotID string json:"bot_id"`,
+		`Description *string json:"description,omitempty"`,
+		`Mode *BotMode json:"mode,omitempty"`,
+		`Plugins []*PluginInfo json:"plugins,omitempty"`,
+		`Prompt PromptInfo json:"prompt"`,
+		`From *string json:"from,omitempty"`,
+		`Tags []string json:"tags,omitempty"`,
+	}
+	if strings.Join(fields, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected Bot fields:\n%s", strings.Join(fields, "\n"))
+	}
+	if bot.Comment != "Bot detail." || len(bot.Embeds) != 1 || bot.Embeds[0] != "baseModel" {
+		t.Fatalf("unexpected Bot struct: %+v", bot)
+	}
+	private := models.modelStruct(models.byName["privateBotData"])
+	if len(private.Fields) != 1 || private.Fields[0].Type != "[]*Bot" {
+		t.Fatalf("unexpected private model fields: %+v", private.Fields)
+	}
+
+	spec := goSwaggerModuleSpec{FileName: "bots.go", PackageName: "bots", TypeName: "bots", ConstructorName: "newBots"}
+	content := renderGoSwaggerModule(models, spec, buildGoSwaggerOperationBindings(ir.Build(cfg, doc), "bots"))
+	for _, fragment := range []string{
+		"func (r *bots) Retrieve(ctx context.Context, req *RetrieveBotsReq) (*Bot, error) {",
+		"Data *Bot `json:\"data\"`",
+		"// Bot Bot detail.",
+		"Name string `json:\"name\"` // Bot name.",
+	} {
+		if !strings.Contains(content, fragment) {
+			t.Fatalf("expected rendered module to contain %q, got:\n%s", fragment, content)
+		}
+	}
+}
+
+func TestGoModelRegistryAvoidsReservedNames(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, goModelTestSwagger)
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Packages: []config.Package{
+				{Name: "bots", ModelSchemas: []config.ModelSchema{{Schema: "OpenBot", Name: "Message"}}},
+				{Name: "workflows", ModelSchemas: []config.ModelSchema{{Name: "Message", AllowMissingInSwagger: true}}},
+			},
+		},
+	}
+
+	models := buildGoModelRegistry(cfg, doc, ir.Build(cfg, doc))
+	if models.bySchema["OpenBot"].Name != "BotsMessage" {
+		t.Fatalf("expected runtime Message type to be avoided, got %q", models.bySchema["OpenBot"].Name)
+	}
+	if _, ok := models.byName["WorkflowsMessage"]; !ok {
+		t.Fatal("expected workflows Message model to be prefixed with its package")
+	}
+	if models.bySchema["PromptInfo"].PackageName != "bots" {
+		t.Fatalf("expected PromptInfo to be owned by the first referencing package, got %q", models.bySchema["PromptInfo"].PackageName)
+	}
+	if got, _ := models.configType("workflows", "List[Message]"); got != "[]*WorkflowsMessage" {
+		t.Fatalf("configType() = %q", got)
+	}
+}
+
+func TestGoReservedTypeNamesOnlyCoverEmittedAssets(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, goModelTestSwagger)
+	cfg := &config.Config{}
+	if _, ok := goReservedTypeNames(cfg, doc)["ToolOutput"]; !ok {
+		t.Fatal("expected ToolOutput from stream_events.go to be reserved")
+	}
+
+	asset := goRuntimeAssets["stream_events.go"]
+	delete(goRuntimeAssets, "stream_events.go")
+	defer func() { goRuntimeAssets["stream_events.go"] = asset }()
+	if _, ok := goReservedTypeNames(cfg, doc)["ToolOutput"]; ok {
+		t.Fatal("expected ToolOutput to be free once stream_events.go is not emitted")
+	}
+}
+
+func TestGoModelRegistryFlattensAllOf(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, goModelTestSwagger+`
+    DraftBot:
+      description: Unpublished bot.
+      allOf:
+        - $ref: '#/components/schemas/PromptInfo'
+        - type: object
+          required: [draft_id]
+          properties:
+            draft_id:
+              type: string
+            plugin:
+              allOf:
+                - $ref: '#/components/schemas/PluginInfo'
+`)
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Packages: []config.Package{
+				{Name: "bots", ModelSchemas: []config.ModelSchema{{Schema: "DraftBot", Name: "DraftBot"}}},
+			},
+		},
+	}
+
+	models := buildGoModelRegistry(cfg, doc, ir.Build(cfg, doc))
+	draft := models.modelStruct(models.byName["DraftBot"])
+	var fields []string
+	for _, field := range draft.Fields {
+		fields = append(fields, field.Name+" "+field.Type+" "+field.Tag)
+	}
+	want := []string{
+		`DraftID string json:"draft_id"`,
+		`Plugin *PluginInfo json:"plugin,omitempty"`,
+		`Prompt *string json:"prompt,omitempty"`,
+	}
+	if strings.Join(fields, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected DraftBot fields:\n%s", strings.Join(fields, "\n"))
+	}
+	if draft.Comment != "Unpublished bot." {
+		t.Fatalf("unexpected DraftBot comment: %q", draft.Comment)
+	}
+}
+
+func TestGoModelNameFromSchemaTrimsSyntheticNames(t *testing.T) {
+	if got := goModelNameFromSchema("apps_collaborators", "properties_collaborators_items"); got != "apps_collaborator_collaborators" {
+		t.Fatalf("goModelNameFromSchema() = %q", got)
+	}
+	if got := goModelNameFromSchema("bots", "OpenBot"); got != "OpenBot" {
+		t.Fatalf("goModelNameFromSchema() = %q", got)
+	}
+}
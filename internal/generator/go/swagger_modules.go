@@ -0,0 +1,830 @@
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+// goSwaggerPackageTypeOverrides keeps the service type names that the published
+// coze-go SDK spells differently from the package name.
+var goSwaggerPackageTypeOverrides = map[string]goSwaggerModuleChild{
+	"chat":         {FieldName: "Chat", TypeName: "chat", ConstructorName: "newChats"},
+	"chat_message": {FieldName: "Messages", TypeName: "chatMessages", ConstructorName: "newChatMessages"},
+	"workspaces":   {FieldName: "Workspaces", TypeName: "workspace", ConstructorName: "newWorkspace"},
+}
+
+// goRuntimeAPIPackages are packages whose service types come from go_runtime templates.
+var goRuntimeAPIPackages = []string{"enterprises", "stores", "websockets"}
+
+var goIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type goStructField struct {
+	Name    string
+	Type    string
+	Tag     string
+	Comment string
+}
+
+type goStructDef struct {
+	Name    string
+	Comment string
+	Embeds  []string
+	Fields  []goStructField
+}
+
+type goSwaggerPagination struct {
+	Mode          string
+	ItemType      string
+	PageNumField  string
+	PageSizeField string
+	TokenField    string
+	HasMoreField  string
+	TotalField    string
+	NextField     string
+}
+
+type goSwaggerOperation struct {
+	Binding      goSwaggerOperationBinding
+	Request      goStructDef
+	ResponseType string
+	WireType     string
+	Types        []goStructDef
+	Unwrap       bool
+	StreamEvent  string
+	Pagination   *goSwaggerPagination
+}
+
+func goHandwrittenAPIPackages() map[string]struct{} {
+	packages := map[string]struct{}{}
+	for _, renderer := range goCustomAPIModuleRenderers {
+		packages[renderer.PackageName] = struct{}{}
+	}
+	for _, renderer := range goInlineAPIModuleRenderers {
+		packages[renderer.PackageName] = struct{}{}
+	}
+	for _, name := range goRuntimeAPIPackages {
+		packages[name] = struct{}{}
+	}
+	return packages
+}
+
+// buildGoSwaggerModuleSpecs returns a module per IR package that has go
+// operations or a config entry, in package name order.
+func buildGoSwaggerModuleSpecs(api *ir.API) []goSwaggerModuleSpec {
+	if api == nil {
+		return nil
+	}
+	packages := make([]*ir.Package, 0, len(api.Packages))
+	childrenByParent := map[string][]string{}
+	for _, pkg := range api.Packages {
+		if pkg.Config == nil && len(pkg.OperationsFor(Language)) == 0 {
+			continue
+		}
+		packages = append(packages, pkg)
+		if pkg.Parent != "" {
+			childrenByParent[pkg.Parent] = append(childrenByParent[pkg.Parent], pkg.Name)
+		}
+	}
+
+	handwritten := goHandwrittenAPIPackages()
+	specs := make([]goSwaggerModuleSpec, 0, len(packages))
+	for _, pkg := range packages {
+		if _, manual := handwritten[pkg.Name]; manual {
+			continue
+		}
+		service := goSwaggerServiceNames(pkg.Name, pkg.Parent)
+		spec := goSwaggerModuleSpec{
+			FileName:        pkg.Name + ".go",
+			PackageName:     pkg.Name,
+			TypeName:        service.TypeName,
+			ConstructorName: service.ConstructorName,
+			CoreFieldName:   "core",
+		}
+		for _, child := range childrenByParent[pkg.Name] {
+			spec.Children = append(spec.Children, goSwaggerServiceNames(child, pkg.Name))
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+func goSwaggerServiceNames(name string, parent string) goSwaggerModuleChild {
+	if override, ok := goSwaggerPackageTypeOverrides[name]; ok {
+		return override
+	}
+	leaf := name
+	if parent != "" {
+		leaf = strings.TrimPrefix(name, parent+"_")
+	}
+	exported := goExportedName(name)
+	return goSwaggerModuleChild{
+		FieldName:       goExportedName(leaf),
+		TypeName:        goUnexportedName(exported),
+		ConstructorName: "new" + exported,
+	}
+}
+
+func buildGoSwaggerOperation(models *goModelRegistry, spec goSwaggerModuleSpec, binding goSwaggerOperationBinding) goSwaggerOperation {
+	doc := models.doc
+	typeBase := goExportedName(binding.MethodName) + goExportedName(spec.PackageName)
+	op := goSwaggerOperation{
+		Binding: binding,
+		Request: goStructDef{Name: typeBase + "Req"},
+	}
+	mapping := binding.Mapping
+	if mapping == nil {
+		mapping = &config.OperationMapping{}
+	}
+	details := binding.Details
+
+	aliases := mapping.ParamAliases
+	seenFields := map[string]struct{}{}
+	appendField := func(field goStructField) {
+		if _, exists := seenFields[field.Name]; exists {
+			return
+		}
+		seenFields[field.Name] = struct{}{}
+		op.Request.Fields = append(op.Request.Fields, field)
+	}
+
+	queryNames := map[string]struct{}{}
+	for _, param := range binding.Parameters {
+		switch param.In {
+		case "path":
+			appendField(goStructField{
+				Name: goExportedName(param.FieldName),
+				Type: models.typeForSchema(param.Schema),
+				Tag:  fmt.Sprintf(`path:%q json:"-"`, param.Name),
+			})
+		case "query":
+			queryNames[param.Name] = struct{}{}
+			appendField(goStructField{
+				Name: goExportedName(param.FieldName),
+				Type: models.fieldType(models.typeForSchema(param.Schema), param.Required),
+				Tag:  fmt.Sprintf(`query:%q json:"-"`, param.Name),
+			})
+		}
+	}
+	for _, field := range mapping.QueryFields {
+		name := strings.TrimSpace(field.Name)
+		if _, exists := queryNames[name]; exists || name == "" {
+			continue
+		}
+		queryNames[name] = struct{}{}
+		fieldType, optional := models.configType(spec.PackageName, field.Type)
+		appendField(goStructField{
+			Name: goParamFieldName(name, aliases),
+			Type: models.fieldType(fieldType, field.Required && !optional),
+			Tag:  fmt.Sprintf(`query:%q json:"-"`, name),
+		})
+	}
+
+	isFile := binding.IsFile
+	filesFields := map[string]struct{}{}
+	for _, name := range mapping.FilesFields {
+		filesFields[strings.TrimSpace(name)] = struct{}{}
+	}
+	bodySchema := doc.ResolveSchema(details.RequestBodySchema)
+	bodyNames := goSwaggerBodyFieldNames(bodySchema, mapping.BodyFields)
+	requiredBody := map[string]bool{}
+	if bodySchema != nil {
+		for _, name := range bodySchema.Required {
+			requiredBody[name] = true
+		}
+	}
+	if len(mapping.BodyRequiredFields) > 0 {
+		requiredBody = map[string]bool{}
+		for _, name := range mapping.BodyRequiredFields {
+			requiredBody[name] = true
+		}
+	}
+	for _, name := range bodyNames {
+		var propertySchema *openapi.Schema
+		if bodySchema != nil {
+			propertySchema = bodySchema.Properties[name]
+		}
+		fieldType := models.typeForSchema(propertySchema)
+		if _, ok := filesFields[name]; ok && isFile {
+			fieldType = "io.Reader"
+		}
+		appendField(goStructField{
+			Name: goParamFieldName(name, aliases),
+			Type: models.fieldType(fieldType, requiredBody[name]),
+			Tag:  goJSONTag(name, requiredBody[name]),
+		})
+	}
+
+	wireName := goUnexportedName(typeBase + "Resp")
+	if binding.Streaming {
+		op.StreamEvent = goStreamEventType(binding.Path)
+		op.WireType = wireName
+		op.Types = append(op.Types, goStructDef{
+			Name:   wireName,
+			Embeds: []string{"baseResponse"},
+			Fields: []goStructField{{Name: "HTTPResponse", Type: "*http.Response", Tag: `json:"-"`}},
+		})
+		return op
+	}
+
+	responseSchema := doc.ResolveSchema(details.ResponseSchema)
+	if pagination := buildGoSwaggerPagination(models, spec.PackageName, binding.Pagination); pagination != nil {
+		op.Pagination = pagination
+		op.WireType = wireName
+		op.Request.Fields = ensureGoPaginationRequestFields(op.Request.Fields, pagination, details.Method, aliases)
+		dataName := wireName + "Data"
+		dataDef := goStructDef{
+			Name:   dataName,
+			Fields: []goStructField{{Name: "Items", Type: "[]*" + pagination.ItemType, Tag: fmt.Sprintf(`json:%q`, binding.Pagination.ItemsField)}},
+		}
+		if pagination.TotalField != "" {
+			dataDef.Fields = append(dataDef.Fields, goStructField{Name: "Total", Type: "int", Tag: fmt.Sprintf(`json:%q`, pagination.TotalField)})
+		}
+		if pagination.HasMoreField != "" {
+			dataDef.Fields = append(dataDef.Fields, goStructField{Name: "HasMore", Type: "bool", Tag: fmt.Sprintf(`json:%q`, pagination.HasMoreField)})
+		}
+		if pagination.NextField != "" {
+			dataDef.Fields = append(dataDef.Fields, goStructField{Name: "NextPageToken", Type: "string", Tag: fmt.Sprintf(`json:%q`, pagination.NextField)})
+		}
+		op.Types = append(op.Types,
+			goStructDef{
+				Name:   wireName,
+				Embeds: []string{"baseResponse"},
+				Fields: []goStructField{{Name: "Data", Type: "*" + dataName, Tag: `json:"data"`}},
+			},
+			dataDef,
+		)
+		return op
+	}
+
+	publicName := typeBase + "Resp"
+	op.ResponseType = publicName
+	dataField := goDefaultString(mapping.DataField, "data")
+	if rawData := goSwaggerRawDataSchema(responseSchema, dataField); rawData != nil {
+		if modelType := models.typeForSchema(rawData); models.isModel(modelType) {
+			op.Unwrap = true
+			op.ResponseType = modelType
+			op.WireType = wireName
+			op.Types = append(op.Types, goStructDef{
+				Name:   wireName,
+				Embeds: []string{"baseResponse"},
+				Fields: []goStructField{{Name: "Data", Type: "*" + modelType, Tag: fmt.Sprintf(`json:%q`, dataField)}},
+			})
+			return op
+		}
+	}
+	if dataSchema := ir.ResponseDataSchema(doc, responseSchema, dataField); dataSchema != nil && len(dataSchema.Properties) > 0 {
+		op.Unwrap = true
+		op.WireType = wireName
+		op.Types = append(op.Types,
+			goStructDef{
+				Name:   publicName,
+				Embeds: []string{"baseModel"},
+				Fields: goSchemaStructFields(models, dataSchema, nil),
+			},
+			goStructDef{
+				Name:   wireName,
+				Embeds: []string{"baseResponse"},
+				Fields: []goStructField{{Name: "Data", Type: "*" + publicName, Tag: fmt.Sprintf(`json:%q`, dataField)}},
+			},
+		)
+		return op
+	}
+
+	op.WireType = publicName
+	op.Types = append(op.Types, goStructDef{
+		Name:   publicName,
+		Embeds: []string{"baseResponse"},
+		Fields: goSchemaStructFields(models, responseSchema, map[string]struct{}{"code": {}, "msg": {}}),
+	})
+	return op
+}
+
+func goSwaggerBodyFieldNames(bodySchema *openapi.Schema, configured []string) []string {
+	if len(configured) > 0 {
+		names := make([]string, 0, len(configured))
+		for _, name := range configured {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	if bodySchema == nil {
+		return nil
+	}
+	names := make([]string, 0, len(bodySchema.Properties))
+	for name := range bodySchema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func goSwaggerRawDataSchema(responseSchema *openapi.Schema, dataField string) *openapi.Schema {
+	if responseSchema == nil || strings.Contains(dataField, ".") {
+		return nil
+	}
+	return responseSchema.Properties[dataField]
+}
+
+func buildGoSwaggerPagination(models *goModelRegistry, packageName string, source *ir.Pagination) *goSwaggerPagination {
+	if source == nil {
+		return nil
+	}
+	pagination := &goSwaggerPagination{
+		Mode:          source.Mode,
+		ItemType:      "map[string]any",
+		PageNumField:  source.PageNumField,
+		PageSizeField: source.PageSizeField,
+		TokenField:    source.PageTokenField,
+		HasMoreField:  source.HasMoreField,
+		TotalField:    source.TotalField,
+		NextField:     source.NextTokenField,
+	}
+	if source.Items != nil {
+		pagination.ItemType = models.typeForSchema(source.Items)
+	}
+	if !models.isModel(pagination.ItemType) && source.ItemType != "" {
+		if itemType, _ := models.configType(packageName, source.ItemType); models.isModel(itemType) {
+			pagination.ItemType = itemType
+		}
+	}
+	return pagination
+}
+
+func ensureGoPaginationRequestFields(fields []goStructField, pagination *goSwaggerPagination, method string, aliases map[string]string) []goStructField {
+	required := []struct {
+		raw  string
+		typ  string
+		name string
+	}{
+		{raw: pagination.PageSizeField, typ: "int", name: "PageSize"},
+	}
+	if pagination.Mode == "token" {
+		required = append(required, struct {
+			raw  string
+			typ  string
+			name string
+		}{raw: pagination.TokenField, typ: "string", name: "PageToken"})
+	} else {
+		required = append(required, struct {
+			raw  string
+			typ  string
+			name string
+		}{raw: pagination.PageNumField, typ: "int", name: "PageNum"})
+	}
+	for _, item := range required {
+		found := false
+		for i := range fields {
+			if fields[i].Name != goParamFieldName(item.raw, aliases) && !goTagHasName(fields[i].Tag, item.raw) {
+				continue
+			}
+			fields[i].Name = item.name
+			fields[i].Type = item.typ
+			fields[i].Tag = strings.Replace(fields[i].Tag, ",omitempty", "", 1)
+			found = true
+			break
+		}
+		if found {
+			continue
+		}
+		tag := goJSONTag(item.raw, true)
+		if isSafeGoQueryMethod(method) {
+			tag = fmt.Sprintf(`query:%q json:"-"`, item.raw)
+		}
+		fields = append(fields, goStructField{Name: item.name, Type: item.typ, Tag: tag})
+	}
+	return fields
+}
+
+func goTagHasName(tag string, name string) bool {
+	for _, key := range []string{"query", "json", "path"} {
+		if strings.Contains(tag, key+`:"`+name+`"`) || strings.Contains(tag, key+`:"`+name+`,`) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSafeGoQueryMethod(method string) bool {
+	switch strings.ToUpper(strings.TrimSpace(method)) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// goStreamEventType picks the event payload for a streaming endpoint: workflow
+// run endpoints emit workflow events, everything else speaks the chat protocol.
+func goStreamEventType(path string) string {
+	if strings.HasPrefix(strings.TrimSpace(path), "/v1/workflow/") {
+		return "WorkflowEvent"
+	}
+	return "ChatEvent"
+}
+
+func goSchemaStructFields(models *goModelRegistry, schema *openapi.Schema, skip map[string]struct{}) []goStructField {
+	if schema == nil {
+		return nil
+	}
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		if _, skipped := skip[name]; skipped {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fields := make([]goStructField, 0, len(names))
+	seen := map[string]struct{}{}
+	for _, name := range names {
+		fieldName := goExportedName(name)
+		if fieldName == "" {
+			continue
+		}
+		if _, exists := seen[fieldName]; exists {
+			continue
+		}
+		seen[fieldName] = struct{}{}
+		fields = append(fields, goStructField{
+			Name:    fieldName,
+			Type:    models.fieldType(models.typeForSchema(schema.Properties[name]), required[name]),
+			Tag:     goJSONTag(name, required[name]),
+			Comment: oneLineText(goSchemaDescription(schema.Properties[name])),
+		})
+	}
+	return fields
+}
+
+func goParamFieldName(raw string, aliases map[string]string) string {
+	name := raw
+	if alias := strings.TrimSpace(aliases[raw]); alias != "" {
+		name = alias
+	}
+	return goExportedName(name)
+}
+
+func goJSONTag(name string, required bool) string {
+	if required {
+		return fmt.Sprintf(`json:%q`, name)
+	}
+	return fmt.Sprintf(`json:"%s,omitempty"`, name)
+}
+
+// goFieldType makes optional scalars and model structs pointers; slices, maps,
+// interfaces and readers already have a usable zero value.
+func goFieldType(goType string, required bool) string {
+	if required || goType == "any" || !goIdentifierPattern.MatchString(goType) {
+		return goType
+	}
+	return "*" + goType
+}
+
+func goDefaultString(value string, fallback string) string {
+	if trimmed := strings.TrimSpace(value); trimmed != "" {
+		return trimmed
+	}
+	return fallback
+}
+
+func renderGoSwaggerModule(models *goModelRegistry, spec goSwaggerModuleSpec, bindings []goSwaggerOperationBinding) string {
+	coreField := strings.TrimSpace(spec.CoreFieldName)
+	if coreField == "" {
+		coreField = "core"
+	}
+
+	operations := make([]goSwaggerOperation, 0, len(bindings))
+	methodNames := map[string]struct{}{coreField: {}}
+	needsIO := false
+	for _, binding := range bindings {
+		op := buildGoSwaggerOperation(models, spec, binding)
+		operations = append(operations, op)
+		methodNames[binding.MethodName] = struct{}{}
+		for _, def := range append([]goStructDef{op.Request}, op.Types...) {
+			needsIO = needsIO || goStructUsesIO(def)
+		}
+	}
+	packageModels := models.packageModels(spec.PackageName)
+	modelDefs := make([]goStructDef, 0, len(packageModels))
+	for _, model := range packageModels {
+		def := models.modelStruct(model)
+		needsIO = needsIO || goStructUsesIO(def)
+		modelDefs = append(modelDefs, def)
+	}
+	unions := models.packageUnions(spec.PackageName)
+	needsJSON := len(unions) > 0
+	for _, op := range operations {
+		for _, def := range op.Types {
+			needsJSON = needsJSON || models.structUsesUnions(def)
+		}
+	}
+	for _, def := range modelDefs {
+		needsJSON = needsJSON || models.structUsesUnions(def)
+	}
+	children := make([]goSwaggerModuleChild, 0, len(spec.Children))
+	for _, child := range spec.Children {
+		if _, exists := methodNames[child.FieldName]; exists {
+			continue
+		}
+		children = append(children, child)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("package coze\n\n")
+	enums := models.packageEnums(spec.PackageName)
+	imports := make([]string, 0, 6)
+	if len(operations) > 0 {
+		imports = append(imports, "context")
+	}
+	if needsJSON {
+		imports = append(imports, "encoding/json")
+	}
+	if needsIO {
+		imports = append(imports, "io")
+	}
+	if len(operations) > 0 {
+		imports = append(imports, "net/http")
+	}
+	if goEnumsUseStrconv(enums) {
+		imports = append(imports, "strconv")
+	}
+	if len(imports) > 0 {
+		buf.WriteString("import (\n")
+		for _, item := range imports {
+			buf.WriteString(fmt.Sprintf("\t%q\n", item))
+		}
+		buf.WriteString(")\n\n")
+	}
+
+	for _, op := range operations {
+		writeGoSwaggerMethod(&buf, spec.TypeName, coreField, op)
+	}
+	for _, op := range operations {
+		writeGoStructDef(&buf, op.Request)
+		if op.Pagination != nil {
+			writeGoPaginationToReq(&buf, op)
+		}
+		for _, def := range op.Types {
+			writeGoStructDef(&buf, def)
+			writeGoUnionUnmarshal(&buf, models, def)
+		}
+	}
+	for _, enum := range enums {
+		writeGoEnum(&buf, enum)
+	}
+	for _, union := range unions {
+		writeGoUnion(&buf, union)
+	}
+	for _, def := range modelDefs {
+		writeGoStructDef(&buf, def)
+		writeGoUnionUnmarshal(&buf, models, def)
+	}
+
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", spec.TypeName))
+	buf.WriteString(fmt.Sprintf("\t%s *core\n", coreField))
+	for _, child := range children {
+		buf.WriteString(fmt.Sprintf("\t%s *%s\n", child.FieldName, child.TypeName))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("func %s(core *core) *%s {\n", spec.ConstructorName, spec.TypeName))
+	if len(children) == 0 {
+		buf.WriteString(fmt.Sprintf("\treturn &%s{%s: core}\n", spec.TypeName, coreField))
+		buf.WriteString("}\n")
+		return buf.String()
+	}
+
+	buf.WriteString(fmt.Sprintf("\treturn &%s{\n", spec.TypeName))
+	buf.WriteString(fmt.Sprintf("\t\t%s: core,\n", coreField))
+	for _, child := range children {
+		buf.WriteString(fmt.Sprintf("\t\t%s: %s(core),\n", child.FieldName, child.ConstructorName))
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+func writeGoSwaggerMethod(buf *bytes.Buffer, typeName string, coreField string, op goSwaggerOperation) {
+	binding := op.Binding
+	url := convertCurlyPathToColon(binding.Path)
+	if summary := strings.TrimSpace(binding.Summary); summary != "" {
+		buf.WriteString(fmt.Sprintf("// %s %s\n", binding.MethodName, summary))
+	}
+
+	if op.Pagination != nil {
+		writeGoSwaggerPagedMethod(buf, typeName, coreField, op, url)
+		return
+	}
+
+	returnType := "*" + op.ResponseType
+	if op.StreamEvent != "" {
+		returnType = fmt.Sprintf("Stream[%s]", op.StreamEvent)
+	}
+	buf.WriteString(fmt.Sprintf("func (r *%s) %s(ctx context.Context, req *%s) (%s, error) {\n", typeName, binding.MethodName, op.Request.Name, returnType))
+	writeGoRawRequest(buf, "\t", "request := ", binding, url, "req")
+	buf.WriteString(fmt.Sprintf("\tresponse := new(%s)\n", op.WireType))
+	buf.WriteString(fmt.Sprintf("\terr := r.%s.rawRequest(ctx, request, response)\n", coreField))
+	switch {
+	case op.StreamEvent != "":
+		parser := "parseChatEvent"
+		if op.StreamEvent == "WorkflowEvent" {
+			parser = "parseWorkflowEvent"
+		}
+		buf.WriteString(fmt.Sprintf("\treturn newStream(ctx, r.%s, response.HTTPResponse, %s), err\n", coreField, parser))
+	case op.Unwrap:
+		buf.WriteString("\treturn response.Data, err\n")
+	default:
+		buf.WriteString("\treturn response, err\n")
+	}
+	buf.WriteString("}\n\n")
+}
+
+func writeGoSwaggerPagedMethod(buf *bytes.Buffer, typeName string, coreField string, op goSwaggerOperation, url string) {
+	binding := op.Binding
+	pagination := op.Pagination
+	itemType := pagination.ItemType
+	pagedType := "NumberPaged"
+	if pagination.Mode == "token" {
+		pagedType = "LastIDPaged"
+	}
+	buf.WriteString(fmt.Sprintf("func (r *%s) %s(ctx context.Context, req *%s) (%s[%s], error) {\n", typeName, binding.MethodName, op.Request.Name, pagedType, itemType))
+	buf.WriteString("\tif req == nil {\n")
+	buf.WriteString(fmt.Sprintf("\t\treq = &%s{}\n", op.Request.Name))
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif req.PageSize == 0 {\n")
+	buf.WriteString("\t\treq.PageSize = 20\n")
+	buf.WriteString("\t}\n")
+	if pagination.Mode != "token" {
+		buf.WriteString("\tif req.PageNum == 0 {\n")
+		buf.WriteString("\t\treq.PageNum = 1\n")
+		buf.WriteString("\t}\n")
+		buf.WriteString("\treturn NewNumberPaged(\n")
+	} else {
+		buf.WriteString("\treturn NewLastIDPaged(\n")
+	}
+	buf.WriteString(fmt.Sprintf("\t\tfunc(request *pageRequest) (*pageResponse[%s], error) {\n", itemType))
+	buf.WriteString(fmt.Sprintf("\t\t\tresponse := new(%s)\n", op.WireType))
+	writeGoRawRequest(buf, "\t\t\t", "rawRequest := ", binding, url, "req.toReq(request)")
+	buf.WriteString(fmt.Sprintf("\t\t\tif err := r.%s.rawRequest(ctx, rawRequest, response); err != nil {\n", coreField))
+	buf.WriteString("\t\t\t\treturn nil, err\n")
+	buf.WriteString("\t\t\t}\n")
+	buf.WriteString("\t\t\tif response.Data == nil {\n")
+	buf.WriteString(fmt.Sprintf("\t\t\t\tresponse.Data = &%sData{}\n", op.WireType))
+	buf.WriteString("\t\t\t}\n")
+	buf.WriteString(fmt.Sprintf("\t\t\treturn &pageResponse[%s]{\n", itemType))
+	buf.WriteString("\t\t\t\tresponse: response.HTTPResponse,\n")
+	if pagination.TotalField != "" {
+		buf.WriteString("\t\t\t\tTotal:    response.Data.Total,\n")
+	}
+	if pagination.HasMoreField != "" {
+		buf.WriteString("\t\t\t\tHasMore:  response.Data.HasMore,\n")
+	} else {
+		buf.WriteString("\t\t\t\tHasMore:  len(response.Data.Items) >= request.PageSize,\n")
+	}
+	buf.WriteString("\t\t\t\tData:     response.Data.Items,\n")
+	if pagination.NextField != "" {
+		buf.WriteString("\t\t\t\tNextID:   response.Data.NextPageToken,\n")
+	}
+	buf.WriteString("\t\t\t\tLogID:    response.HTTPResponse.LogID(),\n")
+	buf.WriteString("\t\t\t}, nil\n")
+	if pagination.Mode == "token" {
+		buf.WriteString("\t\t}, req.PageSize, &req.PageToken)\n")
+	} else {
+		buf.WriteString("\t\t}, req.PageSize, req.PageNum)\n")
+	}
+	buf.WriteString("}\n\n")
+}
+
+func writeGoRawRequest(buf *bytes.Buffer, indent string, prefix string, binding goSwaggerOperationBinding, url string, body string) {
+	buf.WriteString(fmt.Sprintf("%s%s&RawRequestReq{\n", indent, prefix))
+	buf.WriteString(fmt.Sprintf("%s\tMethod: %s,\n", indent, goHTTPMethodConstant(binding.HTTPMethod)))
+	buf.WriteString(fmt.Sprintf("%s\tURL:    %q,\n", indent, url))
+	buf.WriteString(fmt.Sprintf("%s\tBody:   %s,\n", indent, body))
+	if binding.IsFile {
+		buf.WriteString(fmt.Sprintf("%s\tIsFile: true,\n", indent))
+	}
+	if binding.HasDetails && !binding.Details.RequiresAuth() {
+		buf.WriteString(fmt.Sprintf("%s\tNoNeedToken: true,\n", indent))
+	}
+	buf.WriteString(fmt.Sprintf("%s}\n", indent))
+}
+
+func writeGoPaginationToReq(buf *bytes.Buffer, op goSwaggerOperation) {
+	buf.WriteString(fmt.Sprintf("func (r %s) toReq(request *pageRequest) *%s {\n", op.Request.Name, op.Request.Name))
+	buf.WriteString(fmt.Sprintf("\treturn &%s{\n", op.Request.Name))
+	for _, field := range op.Request.Fields {
+		switch field.Name {
+		case "PageNum":
+			buf.WriteString("\t\tPageNum: request.PageNum,\n")
+		case "PageSize":
+			buf.WriteString("\t\tPageSize: request.PageSize,\n")
+		case "PageToken":
+			buf.WriteString("\t\tPageToken: request.PageToken,\n")
+		default:
+			buf.WriteString(fmt.Sprintf("\t\t%s: r.%s,\n", field.Name, field.Name))
+		}
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+}
+
+func writeGoStructDef(buf *bytes.Buffer, def goStructDef) {
+	if comment := strings.TrimSpace(def.Comment); comment != "" {
+		buf.WriteString(fmt.Sprintf("// %s %s\n", def.Name, comment))
+	}
+	if len(def.Embeds) == 0 && len(def.Fields) == 0 {
+		buf.WriteString(fmt.Sprintf("type %s struct{}\n\n", def.Name))
+		return
+	}
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", def.Name))
+	for _, embed := range def.Embeds {
+		buf.WriteString(fmt.Sprintf("\t%s\n", embed))
+	}
+	for _, field := range def.Fields {
+		if comment := strings.TrimSpace(field.Comment); comment != "" {
+			buf.WriteString(fmt.Sprintf("\t%s %s `%s` // %s\n", field.Name, field.Type, field.Tag, comment))
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("\t%s %s `%s`\n", field.Name, field.Type, field.Tag))
+	}
+	buf.WriteString("}\n\n")
+}
+
+func goStructUsesIO(def goStructDef) bool {
+	for _, field := range def.Fields {
+		if strings.Contains(field.Type, "io.Reader") {
+			return true
+		}
+	}
+	return false
+}
+
+// goInitialisms follows the golint list so generated identifiers read like the
+// handwritten ones (BotID, IconURL, APIApp).
+var goInitialisms = map[string]struct{}{
+	"ACL": {}, "API": {}, "ASCII": {}, "CPU": {}, "CSS": {}, "DNS": {}, "EOF": {}, "GUID": {},
+	"HTML": {}, "HTTP": {}, "HTTPS": {}, "ID": {}, "IP": {}, "JSON": {}, "LHS": {}, "QPS": {},
+	"RAM": {}, "RHS": {}, "RPC": {}, "SLA": {}, "SMTP": {}, "SQL": {}, "SSH": {}, "TCP": {},
+	"TLS": {}, "TTL": {}, "UDP": {}, "UI": {}, "UID": {}, "UUID": {}, "URI": {}, "URL": {},
+	"UTF8": {}, "VM": {}, "XML": {}, "XMPP": {}, "XSRF": {}, "XSS": {},
+}
+
+func goExportedName(value string) string {
+	words := splitIdentifierWords(value)
+	if len(words) == 0 {
+		return ""
+	}
+	var buf strings.Builder
+	for _, word := range words {
+		if _, ok := goInitialisms[strings.ToUpper(word)]; ok {
+			buf.WriteString(strings.ToUpper(word))
+			continue
+		}
+		if singular := strings.TrimSuffix(word, "s"); singular != word {
+			if _, ok := goInitialisms[strings.ToUpper(singular)]; ok {
+				buf.WriteString(strings.ToUpper(singular) + "s")
+				continue
+			}
+		}
+		runes := []rune(word)
+		buf.WriteRune(unicode.ToUpper(runes[0]))
+		buf.WriteString(string(runes[1:]))
+	}
+	result := buf.String()
+	if unicode.IsDigit([]rune(result)[0]) {
+		return "Op" + result
+	}
+	return result
+}
+
+func goUnexportedName(exported string) string {
+	runes := []rune(exported)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper == 0 {
+		return exported
+	}
+	if upper > 1 && upper < len(runes) {
+		upper--
+	}
+	for i := 0; i < upper; i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
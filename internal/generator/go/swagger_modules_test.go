@@ -0,0 +1,431 @@
+package gogen
+
+import (
+	"go/format"
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
+)
+
+func TestBuildGoSwaggerModuleSpecsNestsChildPackages(t *testing.T) {
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Packages: []config.Package{
+				{Name: "audio"},
+				{Name: "audio_rooms"},
+				{Name: "bots"},
+				{Name: "bots_versions"},
+				{Name: "workspaces"},
+				{Name: "workspaces_members"},
+			},
+		},
+	}
+
+	specs := buildGoSwaggerModuleSpecs(ir.Build(cfg, nil))
+	byName := map[string]goSwaggerModuleSpec{}
+	for _, spec := range specs {
+		byName[spec.PackageName] = spec
+	}
+	if _, ok := byName["audio"]; ok {
+		t.Fatal("expected handwritten audio package to be skipped")
+	}
+	bots, ok := byName["bots"]
+	if !ok {
+		t.Fatal("expected bots spec")
+	}
+	if bots.TypeName != "bots" || bots.ConstructorName != "newBots" || bots.FileName != "bots.go" {
+		t.Fatalf("unexpected bots spec: %+v", bots)
+	}
+	if len(bots.Children) != 1 || bots.Children[0].FieldName != "Versions" || bots.Children[0].ConstructorName != "newBotsVersions" {
+		t.Fatalf("unexpected bots children: %+v", bots.Children)
+	}
+	workspaces := byName["workspaces"]
+	if workspaces.TypeName != "workspace" || workspaces.ConstructorName != "newWorkspace" {
+		t.Fatalf("expected workspaces override, got %+v", workspaces)
+	}
+	if byName["audio_rooms"].TypeName != "audioRooms" {
+		t.Fatalf("unexpected audio_rooms spec: %+v", byName["audio_rooms"])
+	}
+}
+
+func TestRenderGoSwaggerModuleBuildsTypedOperations(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, `
+openapi: 3.0.0
+paths:
+  /v1/bots/{bot_id}:
+    post:
+      summary: Update bot
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: string
+        - in: query
+          name: is_published
+          schema:
+            type: boolean
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                icon_url:
+                  type: string
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  code:
+                    type: integer
+                  msg:
+                    type: string
+                  data:
+                    type: object
+                    properties:
+                      version:
+                        type: integer
+                        format: int64
+`)
+	cfg := &config.Config{
+		API: config.APIConfig{
+			OperationMappings: []config.OperationMapping{
+				{Path: "/v1/bots/{bot_id}", Method: "post", SDKMethods: []string{"bots.update", "bots._update_v1"}},
+			},
+		},
+	}
+	spec := goSwaggerModuleSpec{FileName: "bots.go", PackageName: "bots", TypeName: "bots", ConstructorName: "newBots"}
+
+	content := renderGoSwaggerModule(buildGoModelRegistry(cfg, doc, ir.Build(cfg, doc)), spec, buildGoSwaggerOperationBindings(ir.Build(cfg, doc), "bots"))
+	if _, err := format.Source([]byte(content)); err != nil {
+		t.Fatalf("format rendered module: %v\n%s", err, content)
+	}
+	for _, want := range []string{
+		"// Update Update bot",
+		"func (r *bots) Update(ctx context.Context, req *UpdateBotsReq) (*UpdateBotsResp, error) {",
+		"func (r *bots) updateV1(ctx context.Context, req *UpdateV1BotsReq) (*UpdateV1BotsResp, error) {",
+		"URL:    \"/v1/bots/:bot_id\",",
+		"BotID string `path:\"bot_id\" json:\"-\"`",
+		"IsPublished *bool `query:\"is_published\" json:\"-\"`",
+		"Name string `json:\"name\"`",
+		"IconURL *string `json:\"icon_url,omitempty\"`",
+		"Version *int64 `json:\"version,omitempty\"`",
+		"Data *UpdateBotsResp `json:\"data\"`",
+		"return response.Data, err",
+		"func newBots(core *core) *bots {",
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected rendered module to contain %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "\"io\"") {
+		t.Fatalf("did not expect io import, got:\n%s", content)
+	}
+}
+
+func TestRenderGoSwaggerModuleRendersPaginationAndStreams(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, `
+openapi: 3.0.0
+paths:
+  /v1/bots:
+    get:
+      parameters:
+        - in: query
+          name: workspace_id
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data:
+                    type: object
+                    properties:
+                      items:
+                        type: array
+                        items:
+                          type: object
+                      total:
+                        type: integer
+  /v1/bots/{bot_id}/versions:
+    get:
+      responses:
+        '200':
+          description: ok
+  /v1/workflow/stream_run:
+    post:
+      responses:
+        '200':
+          description: ok
+`)
+	cfg := &config.Config{
+		API: config.APIConfig{
+			OperationMappings: []config.OperationMapping{
+				{
+					Path:                    "/v1/bots",
+					Method:                  "get",
+					SDKMethods:              []string{"bots.list"},
+					Pagination:              "number",
+					PaginationPageNumField:  "page_index",
+					PaginationPageSizeField: "page_size",
+				},
+				{
+					Path:       "/v1/bots/{bot_id}/versions",
+					Method:     "get",
+					SDKMethods: []string{"bots.versions"},
+					Pagination: "token",
+				},
+				{
+					Path:          "/v1/workflow/stream_run",
+					Method:        "post",
+					SDKMethods:    []string{"bots.stream"},
+					RequestStream: true,
+				},
+			},
+		},
+	}
+	spec := goSwaggerModuleSpec{FileName: "bots.go", PackageName: "bots", TypeName: "bots", ConstructorName: "newBots"}
+
+	content := renderGoSwaggerModule(buildGoModelRegistry(cfg, doc, ir.Build(cfg, doc)), spec, buildGoSwaggerOperationBindings(ir.Build(cfg, doc), "bots"))
+	if _, err := format.Source([]byte(content)); err != nil {
+		t.Fatalf("format rendered module: %v\n%s", err, content)
+	}
+	for _, want := range []string{
+		"func (r *bots) List(ctx context.Context, req *ListBotsReq) (NumberPaged[map[string]any], error) {",
+		"PageNum int `query:\"page_index\" json:\"-\"`",
+		"Total:    response.Data.Total,",
+		"HasMore:  len(response.Data.Items) >= request.PageSize,",
+		"}, req.PageSize, req.PageNum)",
+		"func (r ListBotsReq) toReq(request *pageRequest) *ListBotsReq {",
+		"WorkspaceID: r.WorkspaceID,",
+		"func (r *bots) Versions(ctx context.Context, req *VersionsBotsReq) (LastIDPaged[map[string]any], error) {",
+		"NextID:   response.Data.NextPageToken,",
+		"}, req.PageSize, &req.PageToken)",
+		"func (r *bots) Stream(ctx context.Context, req *StreamBotsReq) (Stream[WorkflowEvent], error) {",
+		"return newStream(ctx, r.core, response.HTTPResponse, parseWorkflowEvent), err",
+		"HTTPResponse *http.Response `json:\"-\"`",
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected rendered module to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestGoExportedName(t *testing.T) {
+	cases := map[string]string{
+		"bot_id":        "BotID",
+		"icon_url":      "IconURL",
+		"api_apps":      "APIApps",
+		"connector_ids": "ConnectorIDs",
+		"2fa":           "Op2fa",
+	}
+	for input, want := range cases {
+		if got := goExportedName(input); got != want {
+			t.Fatalf("goExportedName(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if got := goUnexportedName("APIAppsEvents"); got != "apiAppsEvents" {
+		t.Fatalf("goUnexportedName() = %q", got)
+	}
+	if got := goUnexportedName("Bots"); got != "bots" {
+		t.Fatalf("goUnexportedName() = %q", got)
+	}
+}
+
+const goUnionTestSwagger = `
+openapi: 3.0.0
+paths:
+  /v1/messages/{message_id}:
+    get:
+      parameters:
+        - in: path
+          name: message_id
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  code:
+                    type: integer
+                  msg:
+                    type: string
+                  data:
+                    $ref: '#/components/schemas/ChatMessage'
+components:
+  schemas:
+    ChatMessage:
+      type: object
+      required: [content]
+      properties:
+        content:
+          $ref: '#/components/schemas/MessageContent'
+        attachments:
+          type: array
+          items:
+            $ref: '#/components/schemas/MessageContent'
+        reply:
+          $ref: '#/components/schemas/MessageContent'
+    MessageContent:
+      description: Message content.
+      oneOf:
+        - $ref: '#/components/schemas/TextContent'
+        - $ref: '#/components/schemas/ImageContent'
+      discriminator:
+        propertyName: type
+        mapping:
+          image: '#/components/schemas/ImageContent'
+          picture: '#/components/schemas/ImageContent'
+    TextContent:
+      type: object
+      properties:
+        type:
+          type: string
+          enum: [text]
+        text:
+          type: string
+    ImageContent:
+      type: object
+      properties:
+        type:
+          type: string
+        url:
+          type: string
+`
+
+func goUnionTestConfig() *config.Config {
+	return &config.Config{
+		API: config.APIConfig{
+			OperationMappings: []config.OperationMapping{
+				{Path: "/v1/messages/{message_id}", Method: "get", SDKMethods: []string{"messages.retrieve"}},
+			},
+		},
+	}
+}
+
+func TestRenderGoSwaggerModuleRendersDiscriminatedUnions(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, goUnionTestSwagger)
+	cfg := goUnionTestConfig()
+	spec := goSwaggerModuleSpec{FileName: "messages.go", PackageName: "messages", TypeName: "messages", ConstructorName: "newMessages"}
+
+	models := buildGoModelRegistry(cfg, doc, ir.Build(cfg, doc))
+	content := renderGoSwaggerModule(models, spec, buildGoSwaggerOperationBindings(ir.Build(cfg, doc), "messages"))
+	formatted, err := format.Source([]byte(content))
+	if err != nil {
+		t.Fatalf("format rendered module: %v\n%s", err, content)
+	}
+	content = string(formatted)
+	for _, fragment := range []string{
+		"\t\"context\"\n\t\"encoding/json\"\n\t\"net/http\"\n",
+		"// MessageContent Message content.\ntype MessageContent interface {\n\tisMessageContent()\n}",
+		"func (*TextContent) isMessageContent() ",
+		"func unmarshalMessageContent(data []byte) (MessageContent, error) {",
+		"\tcase \"text\":\n\t\tvalue = &TextContent{}",
+		"\tcase \"image\", \"picture\":\n\t\tvalue = &ImageContent{}",
+		"func (*MessageContentUnknown) isMessageContent() {}",
+		"return &MessageContentUnknown{Tag: probe.Tag, Raw: data}, nil",
+		"Attachments []MessageContent `json:\"attachments,omitempty\"`",
+		"Content     MessageContent   `json:\"content\"`",
+		"Reply       MessageContent   `json:\"reply,omitempty\"`",
+		"func (c *ChatMessage) UnmarshalJSON(data []byte) error {",
+		"Attachments []json.RawMessage `json:\"attachments,omitempty\"`",
+		"c.Attachments = append(c.Attachments, value)",
+		"value, err := unmarshalMessageContent(aux.Reply)",
+	} {
+		if !strings.Contains(content, fragment) {
+			t.Fatalf("expected rendered module to contain %q, got:\n%s", fragment, content)
+		}
+	}
+	if models.bySchema["TextContent"] == nil || models.bySchema["ImageContent"] == nil {
+		t.Fatal("expected union variants to be registered as models")
+	}
+}
+
+func TestRenderGoSwaggerModuleSkipsTokenForAnonymousOperations(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, `
+openapi: 3.0.0
+security:
+  - bearer: []
+paths:
+  /api/permission/oauth2/token:
+    post:
+      security: []
+      responses:
+        '200':
+          description: ok
+  /v1/bots:
+    get:
+      responses:
+        '200':
+          description: ok
+`)
+	cfg := &config.Config{
+		API: config.APIConfig{
+			OperationMappings: []config.OperationMapping{
+				{Path: "/api/permission/oauth2/token", Method: "post", SDKMethods: []string{"auth.token"}},
+				{Path: "/v1/bots", Method: "get", SDKMethods: []string{"auth.bots"}},
+			},
+		},
+	}
+	spec := goSwaggerModuleSpec{FileName: "auth.go", PackageName: "auth", TypeName: "auth", ConstructorName: "newAuth"}
+
+	content := renderGoSwaggerModule(buildGoModelRegistry(cfg, doc, ir.Build(cfg, doc)), spec, buildGoSwaggerOperationBindings(ir.Build(cfg, doc), "auth"))
+	if got := strings.Count(content, "NoNeedToken: true,"); got != 1 {
+		t.Fatalf("expected only the anonymous operation to skip the token, got %d:\n%s", got, content)
+	}
+}
+
+func TestRenderGoRuntimeConstUsesSpecServers(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, `
+openapi: 3.0.0
+servers:
+  - url: https://api.coze.cn
+  - url: https://api-sandbox.coze.cn
+    x-coze-region: sandbox
+paths: {}
+`)
+	data, err := newGoRuntimeData(doc)
+	if err != nil {
+		t.Fatalf("newGoRuntimeData() error = %v", err)
+	}
+	if data.DefaultBaseURL != "ComBaseURL" {
+		t.Fatalf("expected com to stay the default, got %q", data.DefaultBaseURL)
+	}
+	content, err := renderGoRuntimeTemplate("const.go.tpl", data)
+	if err != nil {
+		t.Fatalf("renderGoRuntimeTemplate() error = %v", err)
+	}
+	formatted, err := format.Source([]byte(content))
+	if err != nil {
+		t.Fatalf("format rendered const.go: %v\n%s", err, content)
+	}
+	for _, want := range []string{
+		"ComBaseURL     = \"https://api.coze.com\"",
+		"CnBaseURL      = \"https://api.coze.cn\"",
+		"SandboxBaseURL = \"https://api-sandbox.coze.cn\"",
+	} {
+		if !strings.Contains(string(formatted), want) {
+			t.Fatalf("expected const.go to contain %q, got:\n%s", want, formatted)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"path"
 	"strings"
+	"text/template"
 )
 
 //go:embed all:templates/go_runtime
@@ -23,6 +24,24 @@ func renderGoRuntimeAsset(assetName string) (string, error) {
 	return string(content), nil
 }
 
+// renderGoRuntimeTemplate executes a runtime asset that depends on the spec,
+// such as the base-URL constants.
+func renderGoRuntimeTemplate(assetName string, data any) (string, error) {
+	content, err := renderGoRuntimeAsset(assetName)
+	if err != nil {
+		return "", err
+	}
+	tpl, err := template.New(assetName).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parse go runtime template %q: %w", assetName, err)
+	}
+	var buf strings.Builder
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render go runtime template %q: %w", assetName, err)
+	}
+	return buf.String(), nil
+}
+
 func listGoExtraAssets() ([]string, error) {
 	root := path.Join("templates", "go_extra")
 	assets := make([]string, 0)
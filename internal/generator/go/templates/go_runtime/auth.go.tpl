@@ -234,7 +234,7 @@ func WithAuthHttpClient(client HTTPClient) OAuthClientOption {
 // newOAuthClient creates a new OAuth core
 func newOAuthClient(clientID, clientSecret string, opts ...OAuthClientOption) (*OAuthClient, error) {
 	initSettings := &oauthOption{
-		baseURL:    ComBaseURL,
+		baseURL:    {{ .DefaultBaseURL }},
 		wwwURL:     "",
 		httpClient: nil,
 	}
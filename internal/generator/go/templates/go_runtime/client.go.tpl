@@ -79,7 +79,7 @@ func WithHeaders(headers http.Header) CozeAPIOption {
 
 func NewCozeAPI(auth Auth, opts ...CozeAPIOption) CozeAPI {
 	opt := &clientOption{
-		baseURL:  ComBaseURL,
+		baseURL:  {{ .DefaultBaseURL }},
 		client:   nil,
 		logLevel: LogLevelInfo, // Default log level is Info
 		auth:     auth,
@@ -1,8 +1,9 @@
 package coze
 
 const (
-	ComBaseURL = "https://api.coze.com"
-	CnBaseURL  = "https://api.coze.cn"
+{{- range .BaseURLs }}
+	{{ .Const }} = {{ printf "%q" .URL }}
+{{- end }}
 )
 
 const (
@@ -0,0 +1,107 @@
+package coze
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ToolOutput is the result of a tool call submitted back to a chat.
+type ToolOutput struct {
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	Output     string `json:"output,omitempty"`
+}
+
+// readStreamEvent collects the fields of the server-sent event starting at
+// line, up to the blank line that ends it.
+func readStreamEvent(line []byte, reader *bufio.Reader) (map[string]string, error) {
+	fields := map[string]string{}
+	for {
+		if key, value, ok := strings.Cut(string(line), ":"); ok {
+			key = strings.TrimSpace(key)
+			value = strings.TrimSpace(value)
+			if previous, ok := fields[key]; ok && key == "data" {
+				value = previous + "\n" + value
+			}
+			fields[key] = value
+		}
+		next, _, err := reader.ReadLine()
+		if errors.Is(err, io.EOF) || (err == nil && len(next) == 0) {
+			return fields, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line = next
+	}
+}
+
+func parseChatEvent(_ context.Context, _ *core, line []byte, reader *bufio.Reader) (*ChatEvent, bool, error) {
+	fields, err := readStreamEvent(line, reader)
+	if err != nil {
+		return nil, false, err
+	}
+	name, ok := fields["event"]
+	if !ok {
+		return nil, false, nil
+	}
+	data := []byte(fields["data"])
+	event := &ChatEvent{Event: ChatEventType(name)}
+	switch {
+	case name == "done":
+		return event, true, nil
+	case strings.HasPrefix(name, "conversation.chat."):
+		event.Chat = &Chat{}
+		err = json.Unmarshal(data, event.Chat)
+	case strings.HasPrefix(name, "conversation.message."), strings.HasPrefix(name, "conversation.audio."):
+		event.Message = &ChatMessage{}
+		err = json.Unmarshal(data, event.Message)
+	default:
+		event.Event = ChatEventType("unknown")
+		event.Unknown = fields
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return event, false, nil
+}
+
+func parseWorkflowEvent(_ context.Context, _ *core, line []byte, reader *bufio.Reader) (*WorkflowEvent, bool, error) {
+	fields, err := readStreamEvent(line, reader)
+	if err != nil {
+		return nil, false, err
+	}
+	name, ok := fields["event"]
+	if !ok {
+		return nil, false, nil
+	}
+	data := []byte(fields["data"])
+	event := &WorkflowEvent{Event: WorkflowEventType(name)}
+	if id, ok := fields["id"]; ok {
+		event.ID, _ = strconv.Atoi(id)
+	}
+	switch name {
+	case "Done":
+		return event, true, nil
+	case "Message":
+		event.Message = &WorkflowEventMessage{}
+		err = json.Unmarshal(data, event.Message)
+	case "Interrupt":
+		event.Interrupt = &WorkflowEventInterrupt{}
+		err = json.Unmarshal(data, event.Interrupt)
+	case "Error":
+		event.Error = &WorkflowEventError{}
+		err = json.Unmarshal(data, event.Error)
+	default:
+		event.Event = WorkflowEventType("unknown")
+		event.Unknown = fields
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return event, false, nil
+}
@@ -0,0 +1,182 @@
+package gogen
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+type goUnionVariant struct {
+	Tags  []string
+	Model string
+}
+
+// goUnionDefinition is a discriminated oneOf/anyOf rendered as a sealed
+// interface implemented by its variant model structs.
+type goUnionDefinition struct {
+	SchemaName  string
+	Name        string
+	PackageName string
+	Comment     string
+	Property    string
+	Variants    []goUnionVariant
+	// UnknownName is the variant that keeps the raw JSON of tags this SDK
+	// version does not know.
+	UnknownName string
+}
+
+func (r *goModelRegistry) addUnion(packageName string, configName string, schemaName string, schema *openapi.Schema) bool {
+	variants, ok := r.doc.UnionVariants(schema)
+	if !ok {
+		return false
+	}
+	for _, variant := range variants {
+		effective := r.doc.EffectiveSchema(variant.Schema)
+		if r.unionBySchema[variant.SchemaName] != nil || ir.IsEnumSchema(effective) || effective == nil || len(effective.Properties) == 0 {
+			// Only struct variants can implement the union interface.
+			return false
+		}
+	}
+	union := &goUnionDefinition{
+		SchemaName:  schemaName,
+		Name:        r.uniqueName(packageName, goModelTypeName(configName)),
+		PackageName: packageName,
+		Comment:     oneLineText(goSchemaDescription(schema)),
+		Property:    strings.TrimSpace(schema.Discriminator.PropertyName),
+	}
+	r.unions = append(r.unions, union)
+	r.unionByName[union.Name] = union
+	r.unionBySchema[schemaName] = union
+	union.UnknownName = r.uniqueName(packageName, union.Name+"Unknown")
+	r.reserved[union.UnknownName] = struct{}{}
+	r.alias(packageName, configName, union.Name)
+	for _, variant := range variants {
+		r.addSchemaModel(packageName, variant.SchemaName)
+		if model := r.bySchema[variant.SchemaName]; model != nil {
+			union.Variants = append(union.Variants, goUnionVariant{Tags: variant.Tags, Model: model.Name})
+		}
+	}
+	return true
+}
+
+func (r *goModelRegistry) isUnion(goType string) bool {
+	if r == nil {
+		return false
+	}
+	_, ok := r.unionByName[goType]
+	return ok
+}
+
+// fieldType is goFieldType for registry types: union interfaces are nil-able
+// and never get a pointer.
+func (r *goModelRegistry) fieldType(goType string, required bool) string {
+	if r.isUnion(goType) {
+		return goType
+	}
+	return goFieldType(goType, required)
+}
+
+func (r *goModelRegistry) packageUnions(packageName string) []*goUnionDefinition {
+	if r == nil {
+		return nil
+	}
+	unions := make([]*goUnionDefinition, 0)
+	for _, union := range r.unions {
+		if union.PackageName == packageName {
+			unions = append(unions, union)
+		}
+	}
+	return unions
+}
+
+// structUsesUnions reports whether def holds union fields and so needs a
+// generated UnmarshalJSON.
+func (r *goModelRegistry) structUsesUnions(def goStructDef) bool {
+	for _, field := range def.Fields {
+		if r.isUnion(strings.TrimPrefix(field.Type, "[]")) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeGoUnion(buf *bytes.Buffer, union *goUnionDefinition) {
+	if comment := strings.TrimSpace(union.Comment); comment != "" {
+		buf.WriteString(fmt.Sprintf("// %s %s\n", union.Name, comment))
+	}
+	marker := "is" + union.Name
+	buf.WriteString(fmt.Sprintf("type %s interface {\n\t%s()\n}\n\n", union.Name, marker))
+	for _, variant := range union.Variants {
+		buf.WriteString(fmt.Sprintf("func (*%s) %s() {}\n", variant.Model, marker))
+	}
+	buf.WriteString(fmt.Sprintf("func (*%s) %s() {}\n\n", union.UnknownName, marker))
+
+	buf.WriteString(fmt.Sprintf("// %s is a %s whose %s is not known to this SDK version.\n", union.UnknownName, union.Name, union.Property))
+	buf.WriteString(fmt.Sprintf("type %s struct {\n\tTag string\n\tRaw json.RawMessage\n}\n\n", union.UnknownName))
+	buf.WriteString(fmt.Sprintf("func (u *%s) MarshalJSON() ([]byte, error) {\n", union.UnknownName))
+	buf.WriteString("\tif len(u.Raw) == 0 {\n\t\treturn []byte(\"null\"), nil\n\t}\n")
+	buf.WriteString("\treturn u.Raw, nil\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("func unmarshal%s(data []byte) (%s, error) {\n", union.Name, union.Name))
+	buf.WriteString("\tif string(data) == \"null\" {\n\t\treturn nil, nil\n\t}\n")
+	buf.WriteString(fmt.Sprintf("\tvar probe struct {\n\t\tTag string `json:%q`\n\t}\n", union.Property))
+	buf.WriteString("\tif err := json.Unmarshal(data, &probe); err != nil {\n\t\treturn nil, err\n\t}\n")
+	buf.WriteString(fmt.Sprintf("\tvar value %s\n", union.Name))
+	buf.WriteString("\tswitch probe.Tag {\n")
+	for _, variant := range union.Variants {
+		tags := make([]string, 0, len(variant.Tags))
+		for _, tag := range variant.Tags {
+			tags = append(tags, fmt.Sprintf("%q", tag))
+		}
+		buf.WriteString(fmt.Sprintf("\tcase %s:\n\t\tvalue = &%s{}\n", strings.Join(tags, ", "), variant.Model))
+	}
+	buf.WriteString("\tdefault:\n")
+	buf.WriteString(fmt.Sprintf("\t\treturn &%s{Tag: probe.Tag, Raw: data}, nil\n", union.UnknownName))
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif err := json.Unmarshal(data, value); err != nil {\n\t\treturn nil, err\n\t}\n")
+	buf.WriteString("\treturn value, nil\n")
+	buf.WriteString("}\n\n")
+}
+
+// writeGoUnionUnmarshal decodes the union fields of def through their
+// unmarshal helpers; every other field goes through the default decoder.
+func writeGoUnionUnmarshal(buf *bytes.Buffer, models *goModelRegistry, def goStructDef) {
+	if !models.structUsesUnions(def) {
+		return
+	}
+	receiver := strings.ToLower(def.Name[:1])
+	buf.WriteString(fmt.Sprintf("func (%s *%s) UnmarshalJSON(data []byte) error {\n", receiver, def.Name))
+	buf.WriteString(fmt.Sprintf("\ttype alias %s\n", def.Name))
+	buf.WriteString("\taux := struct {\n\t\t*alias\n")
+	for _, field := range def.Fields {
+		if models.isUnion(field.Type) {
+			buf.WriteString(fmt.Sprintf("\t\t%s json.RawMessage `%s`\n", field.Name, field.Tag))
+		} else if models.isUnion(strings.TrimPrefix(field.Type, "[]")) {
+			buf.WriteString(fmt.Sprintf("\t\t%s []json.RawMessage `%s`\n", field.Name, field.Tag))
+		}
+	}
+	buf.WriteString(fmt.Sprintf("\t}{alias: (*alias)(%s)}\n", receiver))
+	buf.WriteString("\tif err := json.Unmarshal(data, &aux); err != nil {\n\t\treturn err\n\t}\n")
+	for _, field := range def.Fields {
+		switch {
+		case models.isUnion(field.Type):
+			buf.WriteString(fmt.Sprintf("\tif len(aux.%s) > 0 {\n", field.Name))
+			buf.WriteString(fmt.Sprintf("\t\tvalue, err := unmarshal%s(aux.%s)\n", field.Type, field.Name))
+			buf.WriteString("\t\tif err != nil {\n\t\t\treturn err\n\t\t}\n")
+			buf.WriteString(fmt.Sprintf("\t\t%s.%s = value\n", receiver, field.Name))
+			buf.WriteString("\t}\n")
+		case models.isUnion(strings.TrimPrefix(field.Type, "[]")):
+			buf.WriteString(fmt.Sprintf("\tfor _, item := range aux.%s {\n", field.Name))
+			buf.WriteString(fmt.Sprintf("\t\tvalue, err := unmarshal%s(item)\n", strings.TrimPrefix(field.Type, "[]")))
+			buf.WriteString("\t\tif err != nil {\n\t\t\treturn err\n\t\t}\n")
+			buf.WriteString(fmt.Sprintf("\t\t%s.%s = append(%s.%s, value)\n", receiver, field.Name, receiver, field.Name))
+			buf.WriteString("\t}\n")
+		}
+	}
+	buf.WriteString("\treturn nil\n")
+	buf.WriteString("}\n\n")
+}
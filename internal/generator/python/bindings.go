@@ -6,7 +6,7 @@ import (
 	"strings"
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
-	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
 )
 
 var childAttributeLexicon = map[string]string{
@@ -32,130 +32,21 @@ var childAttributeLexicon = map[string]string{
 	"workflow":     "workflows",
 }
 
-func buildOperationBindings(cfg *config.Config, doc *openapi.Document) []OperationBinding {
-	allOps := doc.ListOperationDetails()
-	bindings := make([]OperationBinding, 0)
-	existingOps := map[string]struct{}{}
-
-	for _, details := range allOps {
-		existingOps[strings.ToLower(details.Method)+" "+details.Path] = struct{}{}
-
-		mappings := cfg.FindOperationMappings(details.Path, details.Method)
-		if cfg.API.GenerateOnlyMapped && len(mappings) == 0 {
-			continue
-		}
-
-		if len(mappings) > 0 {
-			for _, mapping := range mappings {
-				mappingCopy := mapping
-				for methodIndex, sdkMethod := range mapping.SDKMethods {
-					pkgName, methodName, ok := config.ParseSDKMethod(sdkMethod)
-					if !ok {
-						continue
-					}
-					pkg, ok := cfg.ResolvePackage(details.Path, pkgName)
-					if !ok {
-						continue
-					}
-					order := len(bindings)
-					if mappingCopy.Order > 0 {
-						order = mappingCopy.Order + methodIndex
-					}
-					bindings = append(bindings, OperationBinding{
-						PackageName: NormalizePackageName(pkg.Name),
-						MethodName:  NormalizeMethodName(methodName),
-						Details:     details,
-						Mapping:     &mappingCopy,
-						Order:       order,
-					})
-				}
-			}
-			continue
-		}
-
-		pkg, ok := cfg.ResolvePackage(details.Path, "")
-		if !ok {
-			continue
-		}
+func buildOperationBindings(api *ir.API) []OperationBinding {
+	ops := api.OperationsFor(Language)
+	bindings := make([]OperationBinding, 0, len(ops))
+	for _, op := range ops {
 		bindings = append(bindings, OperationBinding{
-			PackageName: NormalizePackageName(pkg.Name),
-			MethodName:  DefaultMethodName(details.OperationID, details.Path, details.Method),
-			Details:     details,
-			Order:       len(bindings),
+			PackageName: NormalizePackageName(op.PackageName),
+			MethodName:  NormalizeMethodName(op.MethodName),
+			Details:     op.Details,
+			Mapping:     op.Mapping,
+			Order:       op.Order,
 		})
 	}
-
-	for _, mapping := range cfg.API.OperationMappings {
-		if !mapping.AllowMissingInSwagger {
-			continue
-		}
-		key := strings.ToLower(strings.TrimSpace(mapping.Method)) + " " + strings.TrimSpace(mapping.Path)
-		if _, ok := existingOps[key]; ok {
-			continue
-		}
-		if len(mapping.SDKMethods) == 0 {
-			continue
-		}
-		mappingCopy := mapping
-		details := syntheticOperationDetails(mappingCopy)
-		for methodIndex, sdkMethod := range mappingCopy.SDKMethods {
-			pkgName, methodName, ok := config.ParseSDKMethod(sdkMethod)
-			if !ok {
-				continue
-			}
-			pkg, ok := cfg.ResolvePackage(details.Path, pkgName)
-			if !ok {
-				continue
-			}
-			order := len(bindings)
-			if mappingCopy.Order > 0 {
-				order = mappingCopy.Order + methodIndex
-			}
-			bindings = append(bindings, OperationBinding{
-				PackageName: NormalizePackageName(pkg.Name),
-				MethodName:  NormalizeMethodName(methodName),
-				Details:     details,
-				Mapping:     &mappingCopy,
-				Order:       order,
-			})
-		}
-	}
-
 	return DeduplicateBindings(bindings)
 }
 
-func syntheticOperationDetails(mapping config.OperationMapping) openapi.OperationDetails {
-	details := openapi.OperationDetails{
-		Path:   strings.TrimSpace(mapping.Path),
-		Method: strings.ToLower(strings.TrimSpace(mapping.Method)),
-	}
-	path := details.Path
-	for {
-		start := strings.Index(path, "{")
-		if start < 0 {
-			break
-		}
-		endOffset := strings.Index(path[start:], "}")
-		if endOffset <= 1 {
-			break
-		}
-		end := start + endOffset
-		paramName := strings.TrimSpace(path[start+1 : end])
-		if paramName == "" {
-			path = path[end+1:]
-			continue
-		}
-		details.PathParameters = append(details.PathParameters, openapi.ParameterSpec{
-			Name:     paramName,
-			In:       "path",
-			Required: true,
-			Schema:   &openapi.Schema{Type: "string"},
-		})
-		path = path[end+1:]
-	}
-	return details
-}
-
 func DeduplicateBindings(bindings []OperationBinding) []OperationBinding {
 	syncSeen := map[string]int{}
 	asyncSeen := map[string]int{}
@@ -200,7 +91,7 @@ func groupBindingsByPackage(bindings []OperationBinding) map[string][]OperationB
 	return pkgOps
 }
 
-func buildPackageMeta(cfg *config.Config, packages map[string][]OperationBinding) map[string]PackageMeta {
+func buildPackageMeta(cfg *config.Config, api *ir.API, packages map[string][]OperationBinding) map[string]PackageMeta {
 	metas := map[string]PackageMeta{}
 	inferredDirs := inferPackageDirsFromNames(cfg.API.Packages)
 	for _, pkg := range cfg.API.Packages {
@@ -211,12 +102,16 @@ func buildPackageMeta(cfg *config.Config, packages map[string][]OperationBinding
 			fallbackDir = inferred
 		}
 		dir := NormalizePackageDir(pkg.SourceDir, fallbackDir)
-		metas[name] = PackageMeta{
+		meta := PackageMeta{
 			Name:       name,
 			ModulePath: strings.ReplaceAll(dir, "/", "."),
 			DirPath:    dir,
 			Package:    &pkgCopy,
 		}
+		if irPkg, ok := api.Package(pkg.Name); ok {
+			meta.Models = irPkg.Models
+		}
+		metas[name] = meta
 	}
 	for name := range packages {
 		if _, ok := metas[name]; ok {
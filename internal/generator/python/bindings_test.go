@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
 )
 
 func TestBuildPackageMetaInfersDirectChildClients(t *testing.T) {
@@ -30,7 +31,7 @@ func TestBuildPackageMetaInfersDirectChildClients(t *testing.T) {
 		},
 	}
 
-	metas := buildPackageMeta(cfg, nil)
+	metas := buildPackageMeta(cfg, ir.Build(cfg, nil), nil)
 	parent, ok := metas["workflows"]
 	if !ok || parent.Package == nil {
 		t.Fatalf("missing workflows package meta: %+v", parent)
@@ -77,7 +78,7 @@ def feedback(self):
 		},
 	}
 
-	metas := buildPackageMeta(cfg, nil)
+	metas := buildPackageMeta(cfg, ir.Build(cfg, nil), nil)
 	parent, ok := metas["conversations_message"]
 	if !ok || parent.Package == nil {
 		t.Fatalf("missing conversations_message package meta: %+v", parent)
@@ -107,7 +108,7 @@ func TestBuildPackageMetaInferredChildUsesPluralLexicon(t *testing.T) {
 		},
 	}
 
-	metas := buildPackageMeta(cfg, nil)
+	metas := buildPackageMeta(cfg, ir.Build(cfg, nil), nil)
 	parent, ok := metas["chat"]
 	if !ok || parent.Package == nil {
 		t.Fatalf("missing chat package meta: %+v", parent)
@@ -143,7 +144,7 @@ func TestBuildPackageMetaInfersDirHierarchyWhenSourceDirMissing(t *testing.T) {
 		},
 	}
 
-	metas := buildPackageMeta(cfg, nil)
+	metas := buildPackageMeta(cfg, ir.Build(cfg, nil), nil)
 	if got := metas["workflows"].DirPath; got != "workflows" {
 		t.Fatalf("workflows DirPath=%q", got)
 	}
@@ -168,7 +169,7 @@ func TestBuildPackageMetaUsesExplicitSourceDirWhenProvided(t *testing.T) {
 		},
 	}
 
-	metas := buildPackageMeta(cfg, nil)
+	metas := buildPackageMeta(cfg, ir.Build(cfg, nil), nil)
 	if got := metas["chat_message"].DirPath; got != "custom/message" {
 		t.Fatalf("chat_message DirPath=%q", got)
 	}
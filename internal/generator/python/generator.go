@@ -10,9 +10,29 @@ import (
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
 	"github.com/coze-dev/coze-sdk-gen/internal/generator/fsutil"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
 	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
 )
 
+const Language = "python"
+
+// DefaultDiffIgnorePaths are the coze-py entries a regeneration leaves alone.
+var DefaultDiffIgnorePaths = []string{
+	".git",
+	".github",
+	".gitignore",
+	".pre-commit-config.yaml",
+	".vscode",
+	"CONTRIBUTING.md",
+	"LICENSE",
+	"README.md",
+	"codecov.yml",
+	"examples",
+	"poetry.lock",
+	"__pycache__",
+	"tests",
+}
+
 type OperationBinding struct {
 	PackageName string
 	MethodName  string
@@ -26,6 +46,7 @@ type PackageMeta struct {
 	ModulePath   string
 	DirPath      string
 	Package      *config.Package
+	Models       []*ir.Model
 	ChildClients []childClient
 }
 
@@ -59,15 +80,16 @@ func GeneratePython(cfg *config.Config, doc *openapi.Document) (Result, error) {
 		return Result{}, fmt.Errorf("config and swagger mismatch: %s", report.Error())
 	}
 
-	bindings := buildOperationBindings(cfg, doc)
+	api := ir.Build(cfg, doc)
+	bindings := buildOperationBindings(api)
 	if len(bindings) == 0 {
 		return Result{}, fmt.Errorf("no operations selected for generation")
 	}
 
 	packages := groupBindingsByPackage(bindings)
-	packageMetas := buildPackageMeta(cfg, packages)
+	packageMetas := buildPackageMeta(cfg, api, packages)
 
-	if err := fsutil.CleanOutputDirPreserveEntries(cfg.OutputSDK, cfg.DiffIgnorePathsForLanguage("python")); err != nil {
+	if err := fsutil.CleanOutputDirPreserveEntries(cfg.OutputSDK, cfg.DiffIgnorePathsForLanguage(Language)); err != nil {
 		return Result{}, fmt.Errorf("prepare output directory %q: %w", cfg.OutputSDK, err)
 	}
 
@@ -117,7 +139,13 @@ func writePythonSDK(
 		render      func() (string, error)
 		skipIfEmpty bool
 	}{
-		{baseDir: rootDir, name: "config.py", render: RenderConfigPy},
+		{
+			baseDir: rootDir,
+			name:    "config.py",
+			render: func() (string, error) {
+				return RenderConfigPy(doc)
+			},
+		},
 		{baseDir: rootDir, name: "util.py", render: RenderUtilPy},
 		{baseDir: rootDir, name: "model.py", render: RenderModelPy},
 		{baseDir: rootDir, name: "request.py", render: RenderRequestPy},
@@ -542,8 +570,32 @@ func (w *fileWriter) writeBytes(path string, content []byte) error {
 	return nil
 }
 
-func RenderConfigPy() (string, error) {
-	return RenderPythonTemplate("config.py.tpl", map[string]any{})
+type pythonBaseURL struct {
+	Comment string
+	Const   string
+	URL     string
+}
+
+// RenderConfigPy renders a COZE_<REGION>_BASE_URL constant per spec server
+// region; the first one is the client default.
+func RenderConfigPy(doc *openapi.Document) (string, error) {
+	regions, err := ir.BaseURLs(doc)
+	if err != nil {
+		return "", err
+	}
+	baseURLs := make([]pythonBaseURL, 0)
+	for i, baseURL := range regions {
+		comment := "support change to " + baseURL.Host
+		if i == 0 {
+			comment = "default coze base_url is " + baseURL.Host
+		}
+		baseURLs = append(baseURLs, pythonBaseURL{
+			Comment: comment,
+			Const:   "COZE_" + strings.ToUpper(NormalizePythonIdentifier(baseURL.Region)) + "_BASE_URL",
+			URL:     baseURL.URL,
+		})
+	}
+	return RenderPythonTemplate("config.py.tpl", map[string]any{"BaseURLs": baseURLs})
 }
 
 func RenderUtilPy() (string, error) {
@@ -1,10 +1,12 @@
 package python
 
 import (
+	"fmt"
 	"strings"
 	"unicode"
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
 	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
 )
 
@@ -170,6 +172,9 @@ func PythonTypeForSchemaRequiredWithAliases(doc *openapi.Document, schema *opena
 	if schema == nil {
 		return "Any"
 	}
+	if resolved := doc.ResolveSchema(schema); isUnionSchema(resolved) {
+		return pythonUnionType(doc, resolved, aliases)
+	}
 	if typeName, ok := SchemaTypeNameWithAliases(doc, schema, aliases); ok {
 		return typeName
 	}
@@ -181,6 +186,13 @@ func PythonTypeForSchemaRequiredWithAliases(doc *openapi.Document, schema *opena
 	if typeName, ok := SchemaTypeNameWithAliases(doc, resolved, aliases); ok {
 		return typeName
 	}
+	if len(resolved.AllOf) > 0 {
+		// A lone allOf member is a wrapper that only adds docs to a ref.
+		if len(resolved.AllOf) == 1 && len(resolved.Properties) == 0 {
+			return PythonTypeForSchemaRequiredWithAliases(doc, resolved.AllOf[0], aliases)
+		}
+		resolved = doc.EffectiveSchema(resolved)
+	}
 
 	switch resolved.Type {
 	case "string":
@@ -203,6 +215,49 @@ func PythonTypeForSchemaRequiredWithAliases(doc *openapi.Document, schema *opena
 	}
 }
 
+// isUnionSchema reports whether schema is only a oneOf/anyOf of variants and
+// so renders as a Union rather than a model.
+func isUnionSchema(schema *openapi.Schema) bool {
+	if schema == nil || len(schema.Properties) > 0 || len(schema.AllOf) > 0 {
+		return false
+	}
+	if schema.Type != "" && schema.Type != "object" {
+		return false
+	}
+	return len(schema.OneOf) > 0 || len(schema.AnyOf) > 0
+}
+
+// pythonUnionType renders a oneOf/anyOf as Union[...]. Discriminated unions of
+// named models are annotated so pydantic selects the variant by its tag.
+func pythonUnionType(doc *openapi.Document, schema *openapi.Schema, aliases map[string]string) string {
+	members := schema.OneOf
+	if len(members) == 0 {
+		members = schema.AnyOf
+	}
+	types := make([]string, 0, len(members))
+	seen := map[string]struct{}{}
+	for _, member := range members {
+		typeName := PythonTypeForSchemaRequiredWithAliases(doc, member, aliases)
+		if typeName == "Any" {
+			return "Any"
+		}
+		if _, ok := seen[typeName]; ok {
+			continue
+		}
+		seen[typeName] = struct{}{}
+		types = append(types, typeName)
+	}
+	if len(types) == 1 {
+		return types[0]
+	}
+	union := "Union[" + strings.Join(types, ", ") + "]"
+	if _, ok := doc.UnionVariants(schema); ok && len(types) == len(members) {
+		property := NormalizePythonIdentifier(schema.Discriminator.PropertyName)
+		return fmt.Sprintf("Annotated[%s, Field(discriminator=%q)]", union, property)
+	}
+	return union
+}
+
 func SchemaTypeNameWithAliases(doc *openapi.Document, schema *openapi.Schema, aliases map[string]string) (string, bool) {
 	if schema == nil {
 		return "", false
@@ -263,31 +318,7 @@ func PackageClassName(pkgName string) string {
 }
 
 func DefaultMethodName(operationID string, path string, method string) string {
-	if operationID != "" {
-		op := strings.TrimSpace(operationID)
-		prefixes := []string{"OpenAPI", "OpenApi", "Openapi", "API", "Api"}
-		for _, prefix := range prefixes {
-			op = strings.TrimPrefix(op, prefix)
-		}
-		opSnake := ToSnake(op)
-		opSnake = strings.TrimPrefix(opSnake, "open_api_")
-		if opSnake != "" {
-			return NormalizeMethodName(opSnake)
-		}
-	}
-
-	parts := strings.Split(path, "/")
-	for i := len(parts) - 1; i >= 0; i-- {
-		part := strings.TrimSpace(parts[i])
-		if part == "" {
-			continue
-		}
-		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
-			continue
-		}
-		return NormalizeMethodName(ToSnake(part))
-	}
-	return NormalizeMethodName(method)
+	return NormalizeMethodName(ir.DefaultMethodName(operationID, path, method))
 }
 
 func NormalizeMethodName(value string) string {
@@ -362,33 +393,7 @@ func NormalizePythonIdentifier(value string) string {
 }
 
 func ToSnake(value string) string {
-	value = strings.TrimSpace(value)
-	if value == "" {
-		return ""
-	}
-
-	var out []rune
-	prevLowerOrDigit := false
-	for _, r := range value {
-		if unicode.IsUpper(r) {
-			if prevLowerOrDigit && len(out) > 0 && out[len(out)-1] != '_' {
-				out = append(out, '_')
-			}
-			out = append(out, unicode.ToLower(r))
-			prevLowerOrDigit = false
-			continue
-		}
-		if unicode.IsLetter(r) || unicode.IsDigit(r) {
-			out = append(out, unicode.ToLower(r))
-			prevLowerOrDigit = unicode.IsLower(r) || unicode.IsDigit(r)
-			continue
-		}
-		if len(out) > 0 && out[len(out)-1] != '_' {
-			out = append(out, '_')
-		}
-		prevLowerOrDigit = false
-	}
-	return strings.Trim(CollapseUnderscore(string(out)), "_")
+	return ir.ToSnake(value)
 }
 
 func SplitIdentifier(value string) []string {
@@ -107,6 +107,38 @@ func TestSchemaAndTypeHelpers(t *testing.T) {
 	}
 }
 
+func TestPythonTypesForOpenAPI31Schemas(t *testing.T) {
+	doc, err := openapi.Parse([]byte(`openapi: 3.1.0
+components:
+  schemas:
+    User:
+      type: object
+    Bot:
+      type: object
+      properties:
+        name:
+          type: [string, "null"]
+        owner:
+          anyOf:
+            - $ref: "#/components/schemas/User"
+            - type: "null"
+        tags:
+          type: [array, "null"]
+          items:
+            type: string
+`))
+	if err != nil {
+		t.Fatalf("openapi.Parse() error = %v", err)
+	}
+	props := doc.Components.Schemas["Bot"].Properties
+	cases := map[string]string{"name": "str", "owner": "User", "tags": "List[str]"}
+	for name, want := range cases {
+		if got := PythonTypeForSchemaRequiredWithAliases(doc, props[name], nil); got != want {
+			t.Fatalf("PythonTypeForSchemaRequiredWithAliases(%s)=%q, want %q", name, got, want)
+		}
+	}
+}
+
 func TestInferResponseCast(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -445,3 +477,35 @@ func TestRenderOperationMethodAutoDelegatesToPrivateCreate(t *testing.T) {
 		t.Fatalf("did not expect direct request code for delegated stream:\n%s", streamCode)
 	}
 }
+
+func TestRenderOperationMethodSkipsAuthForAnonymousOperations(t *testing.T) {
+	render := func(details openapi.OperationDetails) string {
+		return renderOperationMethodWithContext(
+			&openapi.Document{},
+			OperationBinding{
+				PackageName: "auth",
+				MethodName:  "token",
+				Details:     details,
+				Mapping:     &config.OperationMapping{ResponseType: "OAuthToken"},
+			},
+			false,
+			"",
+			"",
+			config.CommentOverrides{},
+			nil,
+		)
+	}
+
+	anonymous := render(openapi.OperationDetails{
+		Path:     "/api/permission/oauth2/token",
+		Method:   "post",
+		Security: []openapi.SecurityRequirement{},
+	})
+	if !strings.Contains(anonymous, "auth=False") {
+		t.Fatalf("expected anonymous operation to skip auth:\n%s", anonymous)
+	}
+	secured := render(openapi.OperationDetails{Path: "/v1/bots", Method: "get"})
+	if strings.Contains(secured, "auth=False") {
+		t.Fatalf("did not expect secured operation to skip auth:\n%s", secured)
+	}
+}
@@ -46,7 +46,7 @@ func normalizeAutoInferredImports(content string) string {
 		IncludeQuotedAnnotation bool
 	}{
 		{Module: "typing", Symbols: []string{"Any", "AsyncIterator", "Dict", "IO", "List", "Optional", "TYPE_CHECKING", "Tuple", "Union", "overload"}},
-		{Module: "typing_extensions", Symbols: []string{"Literal"}},
+		{Module: "typing_extensions", Symbols: []string{"Annotated", "Literal"}},
 		{Module: "pathlib", Symbols: []string{"Path"}},
 		{Module: "pydantic", Symbols: []string{"Field", "field_validator"}},
 		{Module: "cozepy", Symbols: []string{"AudioFormat"}},
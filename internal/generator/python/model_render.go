@@ -10,6 +10,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
 	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
 )
 
@@ -40,13 +41,12 @@ func packageSchemaAliases(doc *openapi.Document, meta PackageMeta) map[string]st
 	if meta.Package == nil {
 		return aliases
 	}
-	for _, model := range meta.Package.ModelSchemas {
-		schemaName := strings.TrimSpace(model.Schema)
-		modelName := inferConfiguredModelName(doc, meta.Package, model)
-		if schemaName == "" || modelName == "" {
+	for _, model := range meta.Models {
+		modelName := inferConfiguredModelName(doc, meta.Package, *model.Config)
+		if model.SchemaName == "" || modelName == "" {
 			continue
 		}
-		aliases[schemaName] = modelName
+		aliases[model.SchemaName] = modelName
 	}
 	return aliases
 }
@@ -56,11 +56,11 @@ func resolvePackageModelDefinitions(doc *openapi.Document, meta PackageMeta, bin
 		return nil, nil
 	}
 	inferredAliases := map[string]string{}
-	result := make([]packageModelDefinition, 0, len(meta.Package.ModelSchemas))
+	result := make([]packageModelDefinition, 0, len(meta.Models))
 	includedSchemaNames := map[string]struct{}{}
 	usedModelNames := map[string]struct{}{}
 	modelSignatures := map[string]string{}
-	for _, model := range meta.Package.ModelSchemas {
+	for _, model := range meta.Models {
 		definition, ok := resolveConfiguredModelDefinition(doc, meta.Package, model)
 		if !ok {
 			continue
@@ -76,7 +76,7 @@ func resolvePackageModelDefinitions(doc *openapi.Document, meta PackageMeta, bin
 			inferredAliases[schemaName] = definition.Name
 		}
 	}
-	if len(meta.Package.ModelSchemas) == 0 {
+	if len(meta.Models) == 0 {
 		autoSeeds := inferOperationRootModels(doc, meta.Package, bindings)
 		for _, definition := range autoSeeds {
 			result = append(result, definition)
@@ -109,16 +109,20 @@ func resolvePackageModelDefinitions(doc *openapi.Document, meta PackageMeta, bin
 			if !exists || schema == nil {
 				continue
 			}
-			resolved := doc.ResolveSchema(schema)
+			resolved := doc.EffectiveSchema(schema)
 			if resolved == nil {
 				continue
 			}
 			includedSchemaNames[schemaName] = struct{}{}
+			if isUnionSchema(resolved) {
+				// Unions render inline as Union[...] over their variant models.
+				continue
+			}
 			next := packageModelDefinition{
 				SchemaName:    schemaName,
 				Name:          inferModelNameFromSchema(meta.Package, schemaName, resolved),
 				Schema:        resolved,
-				IsEnum:        isSchemaEnum(resolved, nil),
+				IsEnum:        ir.IsEnumSchema(resolved),
 				FieldTypes:    map[string]string{},
 				FieldDefaults: map[string]string{},
 			}
@@ -142,12 +146,15 @@ func resolvePackageModelDefinitions(doc *openapi.Document, meta PackageMeta, bin
 	return orderModelDefinitionsByDependencies(doc, result), inferredAliases
 }
 
-func resolveConfiguredModelDefinition(doc *openapi.Document, pkg *config.Package, model config.ModelSchema) (packageModelDefinition, bool) {
-	schemaName := strings.TrimSpace(model.Schema)
+func resolveConfiguredModelDefinition(doc *openapi.Document, pkg *config.Package, irModel *ir.Model) (packageModelDefinition, bool) {
+	model := *irModel.Config
 	modelName := inferConfiguredModelName(doc, pkg, model)
 	if modelName == "" {
 		return packageModelDefinition{}, false
 	}
+	if irModel.Schema == nil && !model.AllowMissingInSwagger {
+		return packageModelDefinition{}, false
+	}
 	fieldTypes := map[string]string{}
 	for k, v := range model.FieldTypes {
 		fieldTypes[k] = v
@@ -156,13 +163,12 @@ func resolveConfiguredModelDefinition(doc *openapi.Document, pkg *config.Package
 	for k, v := range model.FieldDefaults {
 		fieldDefaults[k] = v
 	}
-	enumValues := append([]config.ModelEnumValue(nil), model.EnumValues...)
-	definition := packageModelDefinition{
-		SchemaName:            schemaName,
+	return packageModelDefinition{
+		SchemaName:            irModel.SchemaName,
 		Name:                  modelName,
 		BaseClasses:           append([]string(nil), model.BaseClasses...),
-		Schema:                nil,
-		IsEnum:                false,
+		Schema:                irModel.Schema,
+		IsEnum:                irModel.IsEnum,
 		BeforeCode:            append([]string(nil), model.BeforeCode...),
 		PrependCode:           append([]string(nil), model.PrependCode...),
 		Builders:              append([]config.ModelBuilder(nil), model.Builders...),
@@ -172,39 +178,12 @@ func resolveConfiguredModelDefinition(doc *openapi.Document, pkg *config.Package
 		FieldTypes:            fieldTypes,
 		FieldDefaults:         fieldDefaults,
 		EnumBase:              strings.TrimSpace(model.EnumBase),
-		EnumValues:            enumValues,
+		EnumValues:            append([]config.ModelEnumValue(nil), model.EnumValues...),
 		ExtraFields:           append([]config.ModelField(nil), model.ExtraFields...),
 		ExtraCode:             append([]string(nil), model.ExtraCode...),
 		AllowMissingInSwagger: model.AllowMissingInSwagger,
 		ExcludeUnordered:      model.ExcludeUnorderedFields,
-	}
-
-	if schemaName == "" {
-		if !model.AllowMissingInSwagger {
-			return packageModelDefinition{}, false
-		}
-		definition.IsEnum = isSchemaEnum(nil, enumValues)
-		return definition, true
-	}
-	schema, ok := doc.Components.Schemas[schemaName]
-	if !ok || schema == nil {
-		if !model.AllowMissingInSwagger {
-			return packageModelDefinition{}, false
-		}
-		definition.IsEnum = isSchemaEnum(nil, enumValues)
-		return definition, true
-	}
-	resolved := doc.ResolveSchema(schema)
-	if resolved == nil {
-		if !model.AllowMissingInSwagger {
-			return packageModelDefinition{}, false
-		}
-		definition.IsEnum = isSchemaEnum(nil, enumValues)
-		return definition, true
-	}
-	definition.Schema = resolved
-	definition.IsEnum = isSchemaEnum(resolved, enumValues)
-	return definition, true
+	}, true
 }
 
 func inferConfiguredModelName(doc *openapi.Document, pkg *config.Package, model config.ModelSchema) string {
@@ -359,7 +338,7 @@ func inferOperationRootModels(doc *openapi.Document, pkg *config.Package, bindin
 		if _, exists := seenSchema[schemaName]; exists {
 			continue
 		}
-		schema := doc.ResolveSchema(responseDataSchema)
+		schema := doc.EffectiveSchema(responseDataSchema)
 		if schema == nil {
 			continue
 		}
@@ -368,7 +347,7 @@ func inferOperationRootModels(doc *openapi.Document, pkg *config.Package, bindin
 			SchemaName:    schemaName,
 			Name:          modelName,
 			Schema:        schema,
-			IsEnum:        isSchemaEnum(schema, nil),
+			IsEnum:        ir.IsEnumSchema(schema),
 			FieldTypes:    map[string]string{},
 			FieldDefaults: map[string]string{},
 		})
@@ -539,16 +518,6 @@ func schemaStructuralSignature(doc *openapi.Document, schema *openapi.Schema) st
 	return encode(schema)
 }
 
-func isSchemaEnum(schema *openapi.Schema, explicitEnumValues []config.ModelEnumValue) bool {
-	if len(explicitEnumValues) > 0 {
-		return true
-	}
-	if schema == nil {
-		return false
-	}
-	return len(schema.Enum) > 0 && (schema.Type == "string" || schema.Type == "integer" || schema.Type == "")
-}
-
 func collectModelSchemaRefs(doc *openapi.Document, model packageModelDefinition) []string {
 	if doc == nil || model.Schema == nil {
 		return nil
@@ -723,6 +692,7 @@ func renderPackageModelDefinitions(
 ) string {
 	var buf bytes.Buffer
 	modulePrefix := "cozepy." + meta.ModulePath
+	discriminatorTags := doc.DiscriminatorTags()
 
 	for _, model := range models {
 		classKey := modulePrefix + "." + model.Name
@@ -916,6 +886,15 @@ func renderPackageModelDefinitions(
 		for _, fieldName := range fieldNames {
 			if propertySchema, ok := properties[fieldName]; ok {
 				typeName := modelFieldType(model, fieldName, PythonTypeForSchemaWithAliases(doc, propertySchema, requiredSet[fieldName], schemaAliases))
+				tagLiteral := ""
+				if tag, ok := discriminatorTags[model.SchemaName]; ok && tag.PropertyName == fieldName && !hasModelFieldTypeOverride(model, fieldName) {
+					literals := make([]string, 0, len(tag.Values))
+					for _, value := range tag.Values {
+						literals = append(literals, RenderEnumValueLiteral(value))
+					}
+					tagLiteral = literals[0]
+					typeName = "Literal[" + strings.Join(literals, ", ") + "]"
+				}
 				normalizedFieldName := NormalizePythonIdentifier(fieldName)
 				inlineFieldComment := ""
 				fieldComment := schemaCommentLines(doc, propertySchema)
@@ -939,6 +918,9 @@ func renderPackageModelDefinitions(
 					hasRenderedField = true
 				} else {
 					defaultValue := modelFieldDefault(model, fieldName)
+					if defaultValue == "None" && tagLiteral != "" {
+						defaultValue = tagLiteral
+					}
 					if defaultValue == "None" && !strings.HasPrefix(typeName, "Optional[") {
 						typeName = "Optional[" + typeName + "]"
 					} else if defaultValue != "None" {
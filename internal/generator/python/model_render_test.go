@@ -1,12 +1,21 @@
 package python
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
 	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
 )
 
+// testPackageMeta resolves pkg and its models through the IR.
+func testPackageMeta(doc *openapi.Document, pkg config.Package) PackageMeta {
+	cfg := &config.Config{API: config.APIConfig{Packages: []config.Package{pkg}}}
+	irPkg, _ := ir.Build(cfg, doc).Package(pkg.Name)
+	return PackageMeta{Package: irPkg.Config, Models: irPkg.Models}
+}
+
 func TestInferConfiguredModelNameForSyntheticBenefitSchemas(t *testing.T) {
 	doc := &openapi.Document{
 		Components: openapi.Components{
@@ -84,14 +93,12 @@ func TestPackageSchemaAliasesUsesInferredNames(t *testing.T) {
 			},
 		},
 	}
-	meta := PackageMeta{
-		Package: &config.Package{
-			Name: "benefits",
-			ModelSchemas: []config.ModelSchema{
-				{Schema: "properties_data_properties_basic_info"},
-			},
+	meta := testPackageMeta(doc, config.Package{
+		Name: "benefits",
+		ModelSchemas: []config.ModelSchema{
+			{Schema: "properties_data_properties_basic_info"},
 		},
-	}
+	})
 
 	aliases := packageSchemaAliases(doc, meta)
 	if got := aliases["properties_data_properties_basic_info"]; got != "BenefitBasicInfo" {
@@ -265,3 +272,114 @@ func TestInferBindingResponseModelName(t *testing.T) {
 		t.Fatalf("inferBindingResponseModelName() = %q, want %q", got, "BenefitData")
 	}
 }
+
+func TestConfiguredModelFlattensAllOf(t *testing.T) {
+	doc, err := openapi.Parse([]byte(`components:
+  schemas:
+    Entity:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+    Bot:
+      allOf:
+        - $ref: "#/components/schemas/Entity"
+        - type: object
+          properties:
+            owner:
+              description: Bot owner.
+              allOf:
+                - $ref: "#/components/schemas/Owner"
+`))
+	if err != nil {
+		t.Fatalf("openapi.Parse() error = %v", err)
+	}
+	meta := testPackageMeta(doc, config.Package{
+		Name:         "bots",
+		SourceDir:    "cozepy/bots",
+		ModelSchemas: []config.ModelSchema{{Schema: "Bot", Name: "Bot"}},
+	})
+	definition, ok := resolveConfiguredModelDefinition(doc, meta.Package, meta.Models[0])
+	if !ok || definition.Schema == nil {
+		t.Fatal("expected Bot model definition")
+	}
+	if _, ok := definition.Schema.Properties["id"]; !ok || len(definition.Schema.Required) != 1 {
+		t.Fatalf("expected inherited id field, got %+v", definition.Schema)
+	}
+	owner := definition.Schema.Properties["owner"]
+	if got := PythonTypeForSchemaRequiredWithAliases(doc, owner, nil); got != "Owner" {
+		t.Fatalf("expected allOf wrapper to map to its member, got %q", got)
+	}
+	if got := collectModelSchemaRefs(doc, definition); strings.Join(got, ",") != "Owner" {
+		t.Fatalf("unexpected model refs: %v", got)
+	}
+}
+
+func TestDiscriminatedUnionRendersAnnotatedUnion(t *testing.T) {
+	doc, err := openapi.Parse([]byte(`components:
+  schemas:
+    Message:
+      type: object
+      properties:
+        content:
+          $ref: "#/components/schemas/MessageContent"
+    MessageContent:
+      oneOf:
+        - $ref: "#/components/schemas/TextContent"
+        - $ref: "#/components/schemas/ImageContent"
+      discriminator:
+        propertyName: type
+        mapping:
+          image: "#/components/schemas/ImageContent"
+    TextContent:
+      type: object
+      required: [type]
+      properties:
+        type:
+          type: string
+          const: text
+        text:
+          type: string
+    ImageContent:
+      type: object
+      properties:
+        type:
+          type: string
+        url:
+          type: string
+`))
+	if err != nil {
+		t.Fatalf("openapi.Parse() error = %v", err)
+	}
+	meta := testPackageMeta(doc, config.Package{
+		Name:         "chat",
+		SourceDir:    "cozepy/chat",
+		ModelSchemas: []config.ModelSchema{{Schema: "Message", Name: "Message"}},
+	})
+	meta.ModulePath = "chat"
+	models, aliases := resolvePackageModelDefinitions(doc, meta, nil)
+	names := make([]string, 0, len(models))
+	for _, model := range models {
+		names = append(names, model.Name)
+	}
+	if got := strings.Join(names, ","); got != "ImageContent,TextContent,Message" {
+		t.Fatalf("unexpected models: %s", got)
+	}
+
+	content := renderPackageModelDefinitions(doc, meta, models, aliases, config.CommentOverrides{})
+	for _, want := range []string{
+		`content: Optional[Annotated[Union[TextContent, ImageContent], Field(discriminator="type")]] = None`,
+		`type: Literal["text"]`,
+		`type: Literal["image"] = "image"`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in rendered models:\n%s", want, content)
+		}
+	}
+}
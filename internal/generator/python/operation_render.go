@@ -906,6 +906,9 @@ func renderOperationMethodWithContext(
 				buf.WriteString(fmt.Sprintf("                data_field=%q,\n", dataField))
 			}
 			buf.WriteString("                stream=False,\n")
+			if !details.RequiresAuth() {
+				buf.WriteString("                auth=False,\n")
+			}
 			buf.WriteString("            )\n\n")
 			buf.WriteString("        return await AsyncTokenPaged.build(\n")
 			buf.WriteString(fmt.Sprintf("            page_token=%s,\n", tokenExpr))
@@ -955,6 +958,9 @@ func renderOperationMethodWithContext(
 				buf.WriteString(fmt.Sprintf("                data_field=%q,\n", dataField))
 			}
 			buf.WriteString("                stream=False,\n")
+			if !details.RequiresAuth() {
+				buf.WriteString("                auth=False,\n")
+			}
 			buf.WriteString("            )\n\n")
 			buf.WriteString("        return TokenPaged(\n")
 			buf.WriteString(fmt.Sprintf("            page_token=%s,\n", tokenExpr))
@@ -1028,6 +1034,9 @@ func renderOperationMethodWithContext(
 				buf.WriteString(fmt.Sprintf("                data_field=%q,\n", dataField))
 			}
 			buf.WriteString("                stream=False,\n")
+			if !details.RequiresAuth() {
+				buf.WriteString("                auth=False,\n")
+			}
 			buf.WriteString("            )\n\n")
 			buf.WriteString("        return await AsyncNumberPaged.build(\n")
 			buf.WriteString(fmt.Sprintf("            page_num=%s,\n", pageNumExpr))
@@ -1077,6 +1086,9 @@ func renderOperationMethodWithContext(
 				buf.WriteString(fmt.Sprintf("                data_field=%q,\n", dataField))
 			}
 			buf.WriteString("                stream=False,\n")
+			if !details.RequiresAuth() {
+				buf.WriteString("                auth=False,\n")
+			}
 			buf.WriteString("            )\n\n")
 			buf.WriteString("        return NumberPaged(\n")
 			buf.WriteString(fmt.Sprintf("            page_num=%s,\n", pageNumExpr))
@@ -1260,6 +1272,9 @@ func renderOperationMethodWithContext(
 	if dataField != "" {
 		optionalArgs = append(optionalArgs, requestCallArg{Expr: fmt.Sprintf("data_field=%q", dataField)})
 	}
+	if !details.RequiresAuth() {
+		optionalArgs = append(optionalArgs, requestCallArg{Expr: "auth=False"})
+	}
 	for _, item := range optionalArgs {
 		callArgs = append(callArgs, item.Expr)
 	}
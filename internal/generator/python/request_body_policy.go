@@ -27,7 +27,7 @@ func shouldGenerateImplicitRequestBody(defaultMethod string, mapping *config.Ope
 	if hasExplicitPayloadConfig(mapping) {
 		return false
 	}
-	return !isEffectivelyEmptyObjectSchema(requestBodySchema)
+	return !requestBodySchema.IsEmptyObject()
 }
 
 func hasExplicitPayloadConfig(mapping *config.OperationMapping) bool {
@@ -43,28 +43,6 @@ func hasExplicitPayloadConfig(mapping *config.OperationMapping) bool {
 	return len(mapping.BodyFieldValues) > 0
 }
 
-func isEffectivelyEmptyObjectSchema(schema *openapi.Schema) bool {
-	if schema == nil {
-		return false
-	}
-	if len(schema.Properties) > 0 || len(schema.Required) > 0 {
-		return false
-	}
-	if schema.Items != nil || len(schema.AllOf) > 0 || len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
-		return false
-	}
-	if len(schema.Enum) > 0 {
-		return false
-	}
-	if schema.AdditionalProperties != nil {
-		if allow, ok := schema.AdditionalProperties.(bool); !ok || allow {
-			return false
-		}
-	}
-	schemaType := strings.ToLower(strings.TrimSpace(schema.Type))
-	return schemaType == "" || schemaType == "object"
-}
-
 func isSafeWithoutBodyMethod(method string) bool {
 	switch strings.ToLower(strings.TrimSpace(method)) {
 	case "get", "delete", "head", "options", "trace":
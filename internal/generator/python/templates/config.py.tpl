@@ -1,9 +1,8 @@
 import httpx
-
-# default coze base_url is api.coze.com
-COZE_COM_BASE_URL = "https://api.coze.com"
-# support change to api.coze.cn
-COZE_CN_BASE_URL = "https://api.coze.cn"
+{{ range .BaseURLs }}
+# {{ .Comment }}
+{{ .Const }} = "{{ .URL }}"
+{{- end }}
 
 # default timeout is 10 minutes, with 5 seconds connect timeout
 DEFAULT_TIMEOUT = httpx.Timeout(timeout=600.0, connect=5.0)
@@ -83,13 +83,14 @@ class Requester(object):
         files: Optional[dict] = None,
         cast: Union[Type[T], List[Type[T]], Type[ListResponse[T]], Type[FileHTTPResponse], None] = None,
         data_field: str = "data",
+        auth: bool = True,
         stream: bool = False,
     ) -> HTTPRequest:
         if headers is None:
             headers = {}
         headers["User-Agent"] = user_agent()
         headers["X-Coze-Client-User-Agent"] = coze_client_user_agent()
-        if self._auth:
+        if self._auth and auth:
             self._auth.authentication(headers)
 
         log_debug(
@@ -125,6 +126,7 @@ class Requester(object):
         files: Optional[dict] = None,
         cast: Union[Type[T], List[Type[T]], Type[ListResponse[T]], Type[FileHTTPResponse], None] = None,
         data_field: str = "data",
+        auth: bool = True,
         stream: bool = False,
     ) -> HTTPRequest:
         if headers is None:
@@ -132,7 +134,7 @@ class Requester(object):
         headers["User-Agent"] = user_agent()
         headers["X-Coze-Client-User-Agent"] = coze_client_user_agent()
 
-        if self._auth:
+        if self._auth and auth:
             await self._auth.aauthentication(headers)
 
         log_debug(
@@ -170,6 +172,7 @@ class Requester(object):
         body: dict = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> T: ...
 
     @overload
@@ -184,6 +187,7 @@ class Requester(object):
         body: dict = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> List[T]: ...
 
     @overload
@@ -198,6 +202,7 @@ class Requester(object):
         body: dict = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> ListResponse[T]: ...
 
     @overload
@@ -212,6 +217,7 @@ class Requester(object):
         body: dict = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> FileHTTPResponse: ...
 
     @overload
@@ -226,6 +232,7 @@ class Requester(object):
         body: dict = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> IteratorHTTPResponse[str]: ...
 
     @overload
@@ -240,6 +247,7 @@ class Requester(object):
         body: dict = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> None: ...
 
     def request(
@@ -253,6 +261,7 @@ class Requester(object):
         body: Optional[dict] = None,
         files: Optional[dict] = None,
         data_field: str = "data",
+        auth: bool = True,
     ) -> Union[T, List[T], ListResponse[T], IteratorHTTPResponse[str], FileHTTPResponse, None]:
         method = method.upper()
 
@@ -265,6 +274,7 @@ class Requester(object):
             files=files,
             cast=cast,
             data_field=data_field,
+            auth=auth,
             stream=stream,
         )
 
@@ -282,6 +292,7 @@ class Requester(object):
         body: dict = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> T: ...
 
     @overload
@@ -296,6 +307,7 @@ class Requester(object):
         body: dict = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> List[T]: ...
 
     @overload
@@ -310,6 +322,7 @@ class Requester(object):
         body: dict = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> ListResponse[T]: ...
 
     @overload
@@ -324,6 +337,7 @@ class Requester(object):
         body: dict = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> FileHTTPResponse: ...
 
     @overload
@@ -338,6 +352,7 @@ class Requester(object):
         body: Optional[dict] = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> None: ...
 
     @overload
@@ -352,6 +367,7 @@ class Requester(object):
         body: Optional[dict] = ...,
         files: Optional[dict] = ...,
         data_field: str = ...,
+        auth: bool = ...,
     ) -> AsyncIteratorHTTPResponse[str]: ...
 
     async def arequest(
@@ -365,6 +381,7 @@ class Requester(object):
         body: Optional[dict] = None,
         files: Optional[dict] = None,
         data_field: str = "data",
+        auth: bool = True,
     ) -> Union[T, List[T], ListResponse[T], AsyncIteratorHTTPResponse[str], FileHTTPResponse, None]:
         method = method.upper()
         request = await self.amake_request(
@@ -376,6 +393,7 @@ class Requester(object):
             files=files,
             cast=cast,
             data_field=data_field,
+            auth=auth,
             stream=stream,
         )
 
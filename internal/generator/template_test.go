@@ -1,4 +1,4 @@
-package generator
+package generator_test
 
 import (
 	pygen "github.com/coze-dev/coze-sdk-gen/internal/generator/python"
@@ -17,9 +17,9 @@ func TestRenderRequestTemplate(t *testing.T) {
 }
 
 func TestRenderStaticPythonTemplates(t *testing.T) {
-	configContent, err := pygen.RenderConfigPy()
+	configContent, err := pygen.RenderConfigPy(nil)
 	if err != nil {
-		t.Fatalf("pygen.RenderConfigPy() error = %v", err)
+		t.Fatalf("pygen.RenderConfigPy(nil) error = %v", err)
 	}
 	if !strings.Contains(configContent, "COZE_CN_BASE_URL") {
 		t.Fatalf("expected coze config constants in config template, got: %q", configContent)
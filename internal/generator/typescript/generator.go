@@ -0,0 +1,156 @@
+package tsgen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/generator/fsutil"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+const Language = "typescript"
+
+// DefaultDiffIgnorePaths are the coze-js entries a regeneration leaves alone.
+var DefaultDiffIgnorePaths = []string{
+	".git",
+	".github",
+	"README.md",
+	"node_modules",
+	"dist",
+	"package-lock.json",
+	"*.test.ts",
+}
+
+type Result struct {
+	GeneratedFiles int
+	GeneratedOps   int
+}
+
+type fileWriter struct {
+	count   int
+	written map[string]struct{}
+}
+
+func GenerateTypeScript(cfg *config.Config, doc *openapi.Document) (Result, error) {
+	if cfg == nil {
+		return Result{}, fmt.Errorf("config is required")
+	}
+	if doc == nil {
+		return Result{}, fmt.Errorf("swagger document is required")
+	}
+	report := cfg.ValidateAgainstSwagger(doc)
+	if report.HasErrors() {
+		return Result{}, fmt.Errorf("config and swagger mismatch: %s", report.Error())
+	}
+
+	api := ir.Build(cfg, doc)
+	resources := buildTSResources(api, doc)
+	if len(resources) == 0 {
+		return Result{}, fmt.Errorf("no operations selected for generation")
+	}
+
+	if err := fsutil.CleanOutputDirPreserveEntries(cfg.OutputSDK, cfg.DiffIgnorePathsForLanguage(Language)); err != nil {
+		return Result{}, fmt.Errorf("prepare output directory %q: %w", cfg.OutputSDK, err)
+	}
+
+	writer := &fileWriter{written: map[string]struct{}{}}
+	if err := writeTSRuntime(cfg.OutputSDK, doc, writer); err != nil {
+		return Result{}, err
+	}
+	models := newTSModelSet(doc)
+	generatedOps := 0
+	for _, resource := range resources {
+		content := renderTSResource(models, resource)
+		if err := writer.write(filepath.Join(cfg.OutputSDK, "src", "resources", resource.FileName), content); err != nil {
+			return Result{}, err
+		}
+		generatedOps += len(resource.Methods)
+	}
+	if err := writer.write(filepath.Join(cfg.OutputSDK, "src", "models.ts"), renderTSModels(models)); err != nil {
+		return Result{}, err
+	}
+	if err := writer.write(filepath.Join(cfg.OutputSDK, "src", "index.ts"), renderTSIndex(resources)); err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		GeneratedFiles: writer.count,
+		GeneratedOps:   generatedOps,
+	}, nil
+}
+
+func writeTSRuntime(outputDir string, doc *openapi.Document, writer *fileWriter) error {
+	runtimeData, err := newTSRuntimeData(doc)
+	if err != nil {
+		return err
+	}
+	assets := map[string]string{
+		".gitignore":    "gitignore.tpl",
+		"package.json":  "package.json.tpl",
+		"tsconfig.json": "tsconfig.json.tpl",
+		"src/core.ts":   "src/core.ts.tpl",
+	}
+	for target, asset := range assets {
+		content, err := renderTSRuntimeAsset(asset)
+		if asset == "src/core.ts.tpl" {
+			content, err = renderTSRuntimeTemplate(asset, runtimeData)
+		}
+		if err != nil {
+			return err
+		}
+		if err := writer.write(filepath.Join(outputDir, filepath.FromSlash(target)), content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type tsBaseURL struct {
+	Const string
+	URL   string
+}
+
+type tsRuntimeData struct {
+	BaseURLs       []tsBaseURL
+	DefaultBaseURL string
+}
+
+// newTSRuntimeData names a COZE_<REGION>_BASE_URL constant per spec server
+// region; the first one is the client default.
+func newTSRuntimeData(doc *openapi.Document) (tsRuntimeData, error) {
+	baseURLs, err := ir.BaseURLs(doc)
+	if err != nil {
+		return tsRuntimeData{}, err
+	}
+	data := tsRuntimeData{}
+	for _, baseURL := range baseURLs {
+		words := strings.FieldsFunc(strings.ToUpper(baseURL.Region), func(r rune) bool {
+			return !(unicode.IsLetter(r) || unicode.IsDigit(r))
+		})
+		data.BaseURLs = append(data.BaseURLs, tsBaseURL{
+			Const: "COZE_" + strings.Join(words, "_") + "_BASE_URL",
+			URL:   baseURL.URL,
+		})
+	}
+	if len(data.BaseURLs) > 0 {
+		data.DefaultBaseURL = data.BaseURLs[0].Const
+	}
+	return data, nil
+}
+
+func (w *fileWriter) write(path string, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create parent directory for %q: %w", path, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write file %q: %w", path, err)
+	}
+	w.count++
+	w.written[path] = struct{}{}
+	return nil
+}
@@ -0,0 +1,285 @@
+package tsgen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+	"gopkg.in/yaml.v3"
+)
+
+const tsSchemaRefPrefix = "#/components/schemas/"
+
+// tsModelSet names component schemas and records the ones the resources
+// reference, so models.ts only declares what the SDK uses.
+type tsModelSet struct {
+	doc   *openapi.Document
+	names map[string]string
+	used  map[string]struct{}
+}
+
+func newTSModelSet(doc *openapi.Document) *tsModelSet {
+	models := &tsModelSet{
+		doc:   doc,
+		names: map[string]string{},
+		used:  map[string]struct{}{},
+	}
+	if doc == nil {
+		return models
+	}
+	schemaNames := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		schemaNames = append(schemaNames, name)
+	}
+	sort.Strings(schemaNames)
+	taken := map[string]struct{}{}
+	for _, name := range schemaNames {
+		base := tsPascalName(name)
+		if base == "" {
+			base = "Model"
+		}
+		candidate := base
+		for i := 2; ; i++ {
+			if _, exists := taken[candidate]; !exists {
+				break
+			}
+			candidate = fmt.Sprintf("%s%d", base, i)
+		}
+		taken[candidate] = struct{}{}
+		models.names[name] = candidate
+	}
+	return models
+}
+
+func (m *tsModelSet) use(schemaName string) (string, bool) {
+	name, ok := m.names[schemaName]
+	if !ok {
+		return "", false
+	}
+	m.used[schemaName] = struct{}{}
+	return name, true
+}
+
+// typeOf renders a schema as a TypeScript type. Component references are
+// prefixed with qualifier ("models." inside resource modules).
+func (m *tsModelSet) typeOf(schema *openapi.Schema, qualifier string, indent string) string {
+	if schema == nil {
+		return "unknown"
+	}
+	if schema.Ref != "" {
+		if name, ok := m.use(strings.TrimPrefix(schema.Ref, tsSchemaRefPrefix)); ok {
+			return tsNullable(qualifier+name, schema.Nullable)
+		}
+		return "unknown"
+	}
+	if len(schema.Enum) > 0 {
+		return tsNullable(tsEnumUnion(schema), schema.Nullable)
+	}
+	if len(schema.AllOf) > 0 {
+		parts := make([]string, 0, len(schema.AllOf))
+		for _, item := range schema.AllOf {
+			parts = append(parts, tsParenthesize(m.typeOf(item, qualifier, indent)))
+		}
+		return tsNullable(strings.Join(tsUnique(parts), " & "), schema.Nullable)
+	}
+	if variants := append(append([]*openapi.Schema(nil), schema.OneOf...), schema.AnyOf...); len(variants) > 0 {
+		parts := make([]string, 0, len(variants))
+		for _, item := range variants {
+			parts = append(parts, m.typeOf(item, qualifier, indent))
+		}
+		return tsNullable(strings.Join(tsUnique(parts), " | "), schema.Nullable)
+	}
+
+	var result string
+	switch schema.Type {
+	case "string":
+		result = "string"
+		if schema.Format == "binary" {
+			result = "Blob"
+		}
+	case "integer", "number":
+		result = "number"
+	case "boolean":
+		result = "boolean"
+	case "array":
+		element := m.typeOf(schema.Items, qualifier, indent)
+		if strings.ContainsAny(element, " {") {
+			result = "Array<" + element + ">"
+		} else {
+			result = element + "[]"
+		}
+	default:
+		switch {
+		case len(schema.Properties) > 0:
+			result = m.objectLiteral(schema, qualifier, indent)
+		case schema.Type == "object":
+			value := "unknown"
+			if additional, ok := tsAdditionalProperties(schema); ok {
+				value = m.typeOf(additional, qualifier, indent)
+			}
+			result = "Record<string, " + value + ">"
+		default:
+			result = "unknown"
+		}
+	}
+	return tsNullable(result, schema.Nullable)
+}
+
+func (m *tsModelSet) objectLiteral(schema *openapi.Schema, qualifier string, indent string) string {
+	var buf strings.Builder
+	buf.WriteString("{\n")
+	m.writeProperties(&buf, schema, qualifier, indent+"  ")
+	buf.WriteString(indent + "}")
+	return buf.String()
+}
+
+func (m *tsModelSet) writeProperties(buf *strings.Builder, schema *openapi.Schema, qualifier string, indent string) {
+	required := map[string]struct{}{}
+	for _, name := range schema.Required {
+		required[name] = struct{}{}
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property := schema.Properties[name]
+		description := ""
+		if property != nil {
+			description = property.Description
+			if description == "" && property.Ref != "" {
+				if resolved := m.doc.ResolveSchema(property); resolved != nil {
+					description = resolved.Description
+				}
+			}
+		}
+		writeTSDocComment(buf, indent, description)
+		optional := "?"
+		if _, ok := required[name]; ok {
+			optional = ""
+		}
+		buf.WriteString(fmt.Sprintf("%s%s%s: %s;\n", indent, tsPropertyKey(name), optional, m.typeOf(property, qualifier, indent)))
+	}
+}
+
+func renderTSModels(models *tsModelSet) string {
+	rendered := map[string]string{}
+	for {
+		pending := make([]string, 0)
+		for schemaName := range models.used {
+			if _, done := rendered[schemaName]; !done {
+				pending = append(pending, schemaName)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		sort.Strings(pending)
+		for _, schemaName := range pending {
+			rendered[schemaName] = renderTSModel(models, schemaName)
+		}
+	}
+
+	schemaNames := make([]string, 0, len(rendered))
+	for schemaName := range rendered {
+		schemaNames = append(schemaNames, schemaName)
+	}
+	sort.Slice(schemaNames, func(i, j int) bool {
+		return models.names[schemaNames[i]] < models.names[schemaNames[j]]
+	})
+	blocks := make([]string, 0, len(schemaNames))
+	for _, schemaName := range schemaNames {
+		blocks = append(blocks, rendered[schemaName])
+	}
+	if len(blocks) == 0 {
+		return "export {};\n"
+	}
+	return strings.Join(blocks, "\n")
+}
+
+func renderTSModel(models *tsModelSet, schemaName string) string {
+	name := models.names[schemaName]
+	schema := models.doc.ResolveSchema(models.doc.Components.Schemas[schemaName])
+	var buf strings.Builder
+	if schema != nil {
+		writeTSDocComment(&buf, "", schema.Description)
+	}
+	if schema != nil && schema.Type != "array" && len(schema.Enum) == 0 && len(schema.Properties) > 0 &&
+		len(schema.AllOf) == 0 && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 && !schema.Nullable {
+		buf.WriteString(fmt.Sprintf("export interface %s {\n", name))
+		models.writeProperties(&buf, schema, "", "  ")
+		buf.WriteString("}\n")
+		return buf.String()
+	}
+	buf.WriteString(fmt.Sprintf("export type %s = %s;\n", name, models.typeOf(schema, "", "")))
+	return buf.String()
+}
+
+func tsEnumUnion(schema *openapi.Schema) string {
+	literals := make([]string, 0, len(schema.Enum))
+	for _, value := range schema.Enum {
+		switch typed := value.(type) {
+		case string:
+			literals = append(literals, tsStringLiteral(typed))
+		case int:
+			literals = append(literals, strconv.Itoa(typed))
+		case int64:
+			literals = append(literals, strconv.FormatInt(typed, 10))
+		case float64:
+			literals = append(literals, strconv.FormatFloat(typed, 'f', -1, 64))
+		case bool:
+			literals = append(literals, strconv.FormatBool(typed))
+		case nil:
+			literals = append(literals, "null")
+		default:
+			literals = append(literals, tsStringLiteral(fmt.Sprint(typed)))
+		}
+	}
+	return strings.Join(tsUnique(literals), " | ")
+}
+
+func tsAdditionalProperties(schema *openapi.Schema) (*openapi.Schema, bool) {
+	raw, ok := schema.AdditionalProperties.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	content, err := yaml.Marshal(raw)
+	if err != nil {
+		return nil, false
+	}
+	var additional openapi.Schema
+	if err := yaml.Unmarshal(content, &additional); err != nil {
+		return nil, false
+	}
+	return &additional, true
+}
+
+func tsNullable(value string, nullable bool) string {
+	if !nullable || value == "unknown" {
+		return value
+	}
+	return value + " | null"
+}
+
+func tsParenthesize(value string) string {
+	if strings.Contains(value, " | ") {
+		return "(" + value + ")"
+	}
+	return value
+}
+
+func tsUnique(values []string) []string {
+	seen := map[string]struct{}{}
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, exists := seen[value]; exists {
+			continue
+		}
+		seen[value] = struct{}{}
+		result = append(result, value)
+	}
+	return result
+}
@@ -0,0 +1,605 @@
+package tsgen
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+var tsIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsReservedMemberNames are class members every resource already declares.
+var tsReservedMemberNames = map[string]struct{}{
+	"constructor": {},
+	"core":        {},
+}
+
+type tsResource struct {
+	PackageName string
+	ClassName   string
+	FileName    string
+	Parent      string
+	Children    []tsResourceChild
+	Methods     []tsMethod
+}
+
+type tsResourceChild struct {
+	Property  string
+	ClassName string
+	FileName  string
+}
+
+type tsMethod struct {
+	Name      string
+	Operation *ir.Operation
+}
+
+type tsField struct {
+	Key      string
+	Wire     string
+	Type     string
+	In       string
+	Required bool
+	Comment  string
+}
+
+// buildTSResources groups the visible operations into one resource class per
+// package. Packages without operations are kept when a descendant has some,
+// so the client mirrors the api.packages hierarchy.
+func buildTSResources(api *ir.API, doc *openapi.Document) []*tsResource {
+	included := map[string]struct{}{}
+	for _, pkg := range api.Packages {
+		if len(pkg.OperationsFor(Language)) == 0 {
+			continue
+		}
+		for name := pkg.Name; name != ""; {
+			if _, exists := included[name]; exists {
+				break
+			}
+			included[name] = struct{}{}
+			parent, ok := api.Package(name)
+			if !ok {
+				break
+			}
+			name = parent.Parent
+		}
+	}
+
+	resources := make([]*tsResource, 0, len(included))
+	byName := map[string]*tsResource{}
+	for _, pkg := range api.Packages {
+		if _, ok := included[pkg.Name]; !ok {
+			continue
+		}
+		resource := &tsResource{
+			PackageName: pkg.Name,
+			ClassName:   tsPascalName(pkg.Name),
+			FileName:    pkg.Name + ".ts",
+			Parent:      pkg.Parent,
+		}
+		resources = append(resources, resource)
+		byName[pkg.Name] = resource
+	}
+	for _, resource := range resources {
+		parent := byName[resource.Parent]
+		if parent == nil {
+			continue
+		}
+		parent.Children = append(parent.Children, tsResourceChild{
+			Property:  tsCamelName(strings.TrimPrefix(resource.PackageName, parent.PackageName+"_")),
+			ClassName: resource.ClassName,
+			FileName:  resource.FileName,
+		})
+	}
+	for _, resource := range resources {
+		sort.Slice(resource.Children, func(i, j int) bool {
+			return resource.Children[i].Property < resource.Children[j].Property
+		})
+		taken := map[string]struct{}{}
+		for name := range tsReservedMemberNames {
+			taken[name] = struct{}{}
+		}
+		for _, child := range resource.Children {
+			taken[child.Property] = struct{}{}
+		}
+		pkg, _ := api.Package(resource.PackageName)
+		for _, op := range pkg.OperationsFor(Language) {
+			base := tsCamelName(op.MethodName)
+			if base == "" {
+				base = "call"
+			}
+			name := base
+			for i := 2; ; i++ {
+				if _, exists := taken[name]; !exists {
+					break
+				}
+				name = fmt.Sprintf("%s%d", base, i)
+			}
+			taken[name] = struct{}{}
+			resource.Methods = append(resource.Methods, tsMethod{
+				Name:      name,
+				Operation: op,
+			})
+		}
+	}
+	return resources
+}
+
+func renderTSResource(models *tsModelSet, resource *tsResource) string {
+	usesPathParam := false
+	usesStream := false
+	var types strings.Builder
+	var methods strings.Builder
+	for _, method := range resource.Methods {
+		typeBase := tsPascalName(method.Name) + resource.ClassName
+		fields := buildTSFields(models, method.Operation)
+
+		reqName := ""
+		reqOptional := true
+		if len(fields) > 0 {
+			reqName = typeBase + "Req"
+			types.WriteString(fmt.Sprintf("export interface %s {\n", reqName))
+			for _, field := range fields {
+				writeTSDocComment(&types, "  ", field.Comment)
+				optional := "?"
+				if field.Required {
+					optional = ""
+					reqOptional = false
+				}
+				types.WriteString(fmt.Sprintf("  %s%s: %s;\n", tsPropertyKey(field.Key), optional, field.Type))
+			}
+			types.WriteString("}\n\n")
+		}
+
+		op := method.Operation
+		dataKey := ""
+		respName := ""
+		if !op.Streaming {
+			if schema, key := tsResponseSchema(models.doc, op); schema != nil {
+				respName = typeBase + "Resp"
+				dataKey = key
+				types.WriteString(fmt.Sprintf("export type %s = %s;\n\n", respName, models.typeOf(schema, "models.", "")))
+			}
+		}
+
+		args := make([]string, 0, 2)
+		if reqName != "" {
+			if reqOptional {
+				args = append(args, fmt.Sprintf("req: %s = {}", reqName))
+			} else {
+				args = append(args, fmt.Sprintf("req: %s", reqName))
+			}
+		}
+		args = append(args, "options?: RequestOptions")
+
+		request, hasPathParam := renderTSRequestLiteral(op, fields)
+		usesPathParam = usesPathParam || hasPathParam
+
+		methods.WriteString("\n")
+		writeTSDocComment(&methods, "  ", op.Summary)
+		switch {
+		case op.Streaming:
+			usesStream = true
+			methods.WriteString(fmt.Sprintf("  %s(%s): AsyncGenerator<StreamEvent> {\n", method.Name, strings.Join(args, ", ")))
+			methods.WriteString(fmt.Sprintf("    return this.core.stream(%s, options);\n", request))
+		case respName == "":
+			methods.WriteString(fmt.Sprintf("  async %s(%s): Promise<void> {\n", method.Name, strings.Join(args, ", ")))
+			methods.WriteString(fmt.Sprintf("    await this.core.request(%s, options);\n", request))
+		case dataKey != "":
+			methods.WriteString(fmt.Sprintf("  async %s(%s): Promise<%s> {\n", method.Name, strings.Join(args, ", "), respName))
+			methods.WriteString(fmt.Sprintf("    const resp = await this.core.request<{ %s: %s }>(%s, options);\n", tsPropertyKey(dataKey), respName, request))
+			methods.WriteString(fmt.Sprintf("    return %s;\n", tsAccess("resp", dataKey)))
+		default:
+			methods.WriteString(fmt.Sprintf("  async %s(%s): Promise<%s> {\n", method.Name, strings.Join(args, ", "), respName))
+			methods.WriteString(fmt.Sprintf("    return this.core.request<%s>(%s, options);\n", respName, request))
+		}
+		methods.WriteString("  }\n")
+	}
+	usesModels := strings.Contains(types.String(), "models.")
+
+	var buf strings.Builder
+	coreTypes := []string{"Core"}
+	if len(resource.Methods) > 0 {
+		coreTypes = append(coreTypes, "RequestOptions")
+	}
+	if usesStream {
+		coreTypes = append(coreTypes, "StreamEvent")
+	}
+	buf.WriteString(fmt.Sprintf("import type { %s } from '../core.js';\n", strings.Join(coreTypes, ", ")))
+	if usesPathParam {
+		buf.WriteString("import { pathParam } from '../core.js';\n")
+	}
+	if usesModels {
+		buf.WriteString("import type * as models from '../models.js';\n")
+	}
+	for _, child := range resource.Children {
+		buf.WriteString(fmt.Sprintf("import { %s } from './%s';\n", child.ClassName, tsModulePath(child.FileName)))
+	}
+	buf.WriteString("\n")
+	buf.WriteString(types.String())
+	buf.WriteString(fmt.Sprintf("export class %s {\n", resource.ClassName))
+	for _, child := range resource.Children {
+		buf.WriteString(fmt.Sprintf("  readonly %s: %s;\n", child.Property, child.ClassName))
+	}
+	if len(resource.Children) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("  constructor(private readonly core: Core) {\n")
+	for _, child := range resource.Children {
+		buf.WriteString(fmt.Sprintf("    this.%s = new %s(core);\n", child.Property, child.ClassName))
+	}
+	buf.WriteString("  }\n")
+	buf.WriteString(methods.String())
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+func buildTSFields(models *tsModelSet, op *ir.Operation) []tsField {
+	doc := models.doc
+	fields := make([]tsField, 0)
+	seen := map[string]struct{}{}
+	appendField := func(field tsField) {
+		if _, exists := seen[field.Key]; exists || field.Key == "" {
+			return
+		}
+		seen[field.Key] = struct{}{}
+		fields = append(fields, field)
+	}
+	typeOf := func(schema *openapi.Schema) string {
+		return models.typeOf(schema, "models.", "  ")
+	}
+
+	var aliases map[string]string
+	mapping := op.Mapping
+	if mapping != nil {
+		aliases = mapping.ParamAliases
+	}
+	queryNames := map[string]struct{}{}
+	for _, param := range op.Parameters {
+		if param.In != "path" && param.In != "query" {
+			continue
+		}
+		if param.In == "query" {
+			queryNames[param.Name] = struct{}{}
+		}
+		appendField(tsField{
+			Key:      param.FieldName,
+			Wire:     param.Name,
+			Type:     typeOf(param.Schema),
+			In:       param.In,
+			Required: param.Required || param.In == "path",
+			Comment:  tsSchemaDescription(doc, param.Schema),
+		})
+	}
+	if mapping != nil {
+		for _, field := range mapping.QueryFields {
+			name := strings.TrimSpace(field.Name)
+			if _, exists := queryNames[name]; exists || name == "" {
+				continue
+			}
+			appendField(tsField{
+				Key:      tsAliasedName(name, aliases),
+				Wire:     name,
+				Type:     tsConfigType(field.Type),
+				In:       "query",
+				Required: field.Required && !strings.HasPrefix(strings.TrimSpace(field.Type), "Optional["),
+			})
+		}
+	}
+
+	bodySchema := doc.ResolveSchema(op.Details.RequestBodySchema)
+	bodyIn := "body"
+	if op.IsFile {
+		bodyIn = "form"
+	}
+	var bodyNames []string
+	required := map[string]bool{}
+	files := map[string]struct{}{}
+	if mapping != nil {
+		for _, name := range mapping.BodyFields {
+			bodyNames = append(bodyNames, strings.TrimSpace(name))
+		}
+		for _, name := range mapping.BodyRequiredFields {
+			required[strings.TrimSpace(name)] = true
+		}
+		for _, name := range mapping.FilesFields {
+			files[strings.TrimSpace(name)] = struct{}{}
+		}
+	}
+	if bodySchema != nil {
+		if len(bodyNames) == 0 {
+			for name := range bodySchema.Properties {
+				bodyNames = append(bodyNames, name)
+			}
+			sort.Strings(bodyNames)
+		}
+		if mapping == nil || len(mapping.BodyRequiredFields) == 0 {
+			for _, name := range bodySchema.Required {
+				required[name] = true
+			}
+		}
+	}
+	for _, name := range bodyNames {
+		var property *openapi.Schema
+		if bodySchema != nil {
+			property = bodySchema.Properties[name]
+		}
+		fieldType := typeOf(property)
+		if _, ok := files[name]; ok && op.IsFile {
+			fieldType = "Blob"
+		}
+		appendField(tsField{
+			Key:      tsAliasedName(name, aliases),
+			Wire:     name,
+			Type:     fieldType,
+			In:       bodyIn,
+			Required: required[name],
+			Comment:  tsSchemaDescription(doc, property),
+		})
+	}
+	return fields
+}
+
+func renderTSRequestLiteral(op *ir.Operation, fields []tsField) (string, bool) {
+	path := op.Path
+	hasPathParam := false
+	byWire := map[string]tsField{}
+	var query, body []tsField
+	for _, field := range fields {
+		switch field.In {
+		case "path":
+			byWire[field.Wire] = field
+		case "query":
+			query = append(query, field)
+		default:
+			body = append(body, field)
+		}
+	}
+	for wire, field := range byWire {
+		placeholder := "{" + wire + "}"
+		if strings.Contains(path, placeholder) {
+			path = strings.ReplaceAll(path, placeholder, "${pathParam("+tsAccess("req", field.Key)+")}")
+			hasPathParam = true
+		}
+	}
+
+	var buf strings.Builder
+	buf.WriteString("{\n")
+	buf.WriteString(fmt.Sprintf("      method: %s,\n", tsStringLiteral(op.HTTPMethod)))
+	if hasPathParam {
+		buf.WriteString(fmt.Sprintf("      path: `%s`,\n", strings.ReplaceAll(path, "`", "\\`")))
+	} else {
+		buf.WriteString(fmt.Sprintf("      path: %s,\n", tsStringLiteral(path)))
+	}
+	if len(query) > 0 {
+		buf.WriteString("      query: {\n")
+		for _, field := range query {
+			buf.WriteString(fmt.Sprintf("        %s: %s,\n", tsPropertyKey(field.Wire), tsAccess("req", field.Key)))
+		}
+		buf.WriteString("      },\n")
+	}
+	fixed := tsFixedBodyValues(op, body)
+	if len(body) > 0 || len(fixed) > 0 {
+		key := "body"
+		if op.IsFile {
+			key = "form"
+		}
+		buf.WriteString(fmt.Sprintf("      %s: {\n", key))
+		for _, field := range body {
+			buf.WriteString(fmt.Sprintf("        %s: %s,\n", tsPropertyKey(field.Wire), tsAccess("req", field.Key)))
+		}
+		for _, entry := range fixed {
+			buf.WriteString(fmt.Sprintf("        %s: %s,\n", tsPropertyKey(entry[0]), entry[1]))
+		}
+		buf.WriteString("      },\n")
+	}
+	if op.HasDetails && !op.Details.RequiresAuth() {
+		buf.WriteString("      anonymous: true,\n")
+	}
+	buf.WriteString("    }")
+	return buf.String(), hasPathParam
+}
+
+// tsFixedBodyValues converts body_fixed_values that are plain Python literals;
+// expressions are left to the language-specific config.
+func tsFixedBodyValues(op *ir.Operation, body []tsField) [][2]string {
+	if op.Mapping == nil || len(op.Mapping.BodyFixedValues) == 0 {
+		return nil
+	}
+	declared := map[string]struct{}{}
+	for _, field := range body {
+		declared[field.Wire] = struct{}{}
+	}
+	names := make([]string, 0, len(op.Mapping.BodyFixedValues))
+	for name := range op.Mapping.BodyFixedValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := make([][2]string, 0, len(names))
+	for _, name := range names {
+		if _, exists := declared[name]; exists {
+			continue
+		}
+		if literal, ok := tsLiteralFromPython(op.Mapping.BodyFixedValues[name]); ok {
+			values = append(values, [2]string{name, literal})
+		}
+	}
+	return values
+}
+
+func tsLiteralFromPython(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "True":
+		return "true", true
+	case "False":
+		return "false", true
+	case "None":
+		return "null", true
+	}
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return tsStringLiteral(value[1 : len(value)-1]), true
+	}
+	if value != "" && strings.Trim(value, "0123456789.-") == "" {
+		return value, true
+	}
+	return "", false
+}
+
+// tsResponseSchema returns the schema a method resolves to and the envelope key
+// to unwrap, if any.
+func tsResponseSchema(doc *openapi.Document, op *ir.Operation) (*openapi.Schema, string) {
+	response := doc.ResolveSchema(op.Details.ResponseSchema)
+	if response == nil {
+		return nil, ""
+	}
+	dataField := "data"
+	if op.Mapping != nil && strings.TrimSpace(op.Mapping.DataField) != "" {
+		dataField = strings.TrimSpace(op.Mapping.DataField)
+	}
+	if data, ok := response.Properties[dataField]; ok && !strings.Contains(dataField, ".") {
+		return data, dataField
+	}
+	return op.Details.ResponseSchema, ""
+}
+
+func renderTSIndex(resources []*tsResource) string {
+	var buf strings.Builder
+	buf.WriteString("import { Core } from './core.js';\n")
+	buf.WriteString("import type { ClientOptions } from './core.js';\n")
+	roots := make([]*tsResource, 0)
+	for _, resource := range resources {
+		if resource.Parent == "" {
+			roots = append(roots, resource)
+			buf.WriteString(fmt.Sprintf("import { %s } from './resources/%s';\n", resource.ClassName, tsModulePath(resource.FileName)))
+		}
+	}
+	buf.WriteString("\n")
+	buf.WriteString("export * from './core.js';\n")
+	buf.WriteString("export * as models from './models.js';\n")
+	for _, resource := range resources {
+		buf.WriteString(fmt.Sprintf("export * from './resources/%s';\n", tsModulePath(resource.FileName)))
+	}
+	buf.WriteString("\n")
+	buf.WriteString("export class CozeAPI {\n")
+	for _, resource := range roots {
+		buf.WriteString(fmt.Sprintf("  readonly %s: %s;\n", tsCamelName(resource.PackageName), resource.ClassName))
+	}
+	buf.WriteString("\n")
+	buf.WriteString("  constructor(options: ClientOptions) {\n")
+	buf.WriteString("    const core = new Core(options);\n")
+	for _, resource := range roots {
+		buf.WriteString(fmt.Sprintf("    this.%s = new %s(core);\n", tsCamelName(resource.PackageName), resource.ClassName))
+	}
+	buf.WriteString("  }\n")
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+func tsConfigType(pythonType string) string {
+	value := strings.TrimSpace(pythonType)
+	if strings.HasPrefix(value, "Optional[") && strings.HasSuffix(value, "]") {
+		value = strings.TrimSpace(value[len("Optional[") : len(value)-1])
+	}
+	switch {
+	case value == "str":
+		return "string"
+	case value == "int" || value == "float":
+		return "number"
+	case value == "bool":
+		return "boolean"
+	case strings.HasPrefix(value, "List[") && strings.HasSuffix(value, "]"):
+		element := tsConfigType(value[len("List[") : len(value)-1])
+		if element == "unknown" {
+			return "unknown[]"
+		}
+		return element + "[]"
+	case strings.HasPrefix(value, "Dict["):
+		return "Record<string, unknown>"
+	default:
+		return "unknown"
+	}
+}
+
+func tsSchemaDescription(doc *openapi.Document, schema *openapi.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	if schema.Description != "" {
+		return schema.Description
+	}
+	if resolved := doc.ResolveSchema(schema); resolved != nil {
+		return resolved.Description
+	}
+	return ""
+}
+
+func tsAliasedName(name string, aliases map[string]string) string {
+	if alias := strings.TrimSpace(aliases[name]); alias != "" {
+		return alias
+	}
+	return name
+}
+
+func tsModulePath(fileName string) string {
+	return strings.TrimSuffix(fileName, ".ts") + ".js"
+}
+
+func tsPascalName(value string) string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+	var buf strings.Builder
+	for _, field := range fields {
+		runes := []rune(field)
+		buf.WriteRune(unicode.ToUpper(runes[0]))
+		buf.WriteString(string(runes[1:]))
+	}
+	result := buf.String()
+	if result != "" && unicode.IsDigit([]rune(result)[0]) {
+		return "Model" + result
+	}
+	return result
+}
+
+func tsCamelName(value string) string {
+	pascal := tsPascalName(value)
+	if pascal == "" {
+		return ""
+	}
+	runes := []rune(pascal)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+func tsPropertyKey(name string) string {
+	if tsIdentifierPattern.MatchString(name) {
+		return name
+	}
+	return tsStringLiteral(name)
+}
+
+func tsAccess(object string, key string) string {
+	if tsIdentifierPattern.MatchString(key) {
+		return object + "." + key
+	}
+	return object + "[" + tsStringLiteral(key) + "]"
+}
+
+func tsStringLiteral(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+	return "'" + replacer.Replace(value) + "'"
+}
+
+func writeTSDocComment(buf *strings.Builder, indent string, text string) {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return
+	}
+	buf.WriteString(fmt.Sprintf("%s/** %s */\n", indent, strings.ReplaceAll(text, "*/", "*\\/")))
+}
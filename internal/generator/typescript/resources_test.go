@@ -0,0 +1,271 @@
+package tsgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/ir"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+func TestRenderTSResourceBuildsTypedOperations(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, `
+openapi: 3.0.0
+paths:
+  /v1/bots/{bot_id}:
+    post:
+      summary: Update bot
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: string
+        - in: query
+          name: is_published
+          schema:
+            type: boolean
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                mode:
+                  $ref: '#/components/schemas/BotMode'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  code:
+                    type: integer
+                  msg:
+                    type: string
+                  data:
+                    $ref: '#/components/schemas/BotInfo'
+  /v1/bots/chat:
+    post:
+      summary: Chat with bot
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                query:
+                  type: string
+      responses:
+        '200':
+          description: ok
+          content:
+            text/event-stream:
+              schema:
+                type: string
+  /v1/bots/versions:
+    get:
+      summary: List versions
+      responses:
+        '200':
+          description: ok
+  /v1/bots/icon:
+    post:
+      summary: Upload icon
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              properties:
+                file:
+                  type: string
+                  format: binary
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    BotMode:
+      type: integer
+      enum: [0, 1]
+    BotInfo:
+      type: object
+      description: Bot detail
+      properties:
+        bot_id:
+          type: string
+        mode:
+          $ref: '#/components/schemas/BotMode'
+`)
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Packages: []config.Package{
+				{Name: "bots", SourceDir: "cozepy/bots"},
+				{Name: "bots_versions", SourceDir: "cozepy/bots/versions"},
+			},
+			OperationMappings: []config.OperationMapping{
+				{Path: "/v1/bots/{bot_id}", Method: "post", SDKMethods: []string{"bots.update"}},
+				{Path: "/v1/bots/chat", Method: "post", SDKMethods: []string{"bots.stream"}, BodyFixedValues: map[string]string{"stream": "True"}},
+				{Path: "/v1/bots/versions", Method: "get", SDKMethods: []string{"bots_versions.list"}},
+				{Path: "/v1/bots/icon", Method: "post", SDKMethods: []string{"bots.upload_icon"}, FilesFields: []string{"file"}},
+			},
+		},
+	}
+
+	resources := buildTSResources(ir.Build(cfg, doc), doc)
+	if len(resources) != 2 {
+		t.Fatalf("expected 2 resources, got %+v", resources)
+	}
+	bots := resources[0]
+	if bots.ClassName != "Bots" || len(bots.Children) != 1 || bots.Children[0].Property != "versions" {
+		t.Fatalf("unexpected bots resource: %+v", bots)
+	}
+
+	models := newTSModelSet(doc)
+	content := renderTSResource(models, bots)
+	for _, want := range []string{
+		"import type { Core, RequestOptions, StreamEvent } from '../core.js';",
+		"import { pathParam } from '../core.js';",
+		"import type * as models from '../models.js';",
+		"import { BotsVersions } from './bots_versions.js';",
+		"export interface UpdateBotsReq {\n  bot_id: string;\n  is_published?: boolean;\n  mode?: models.BotMode;\n  name: string;\n}",
+		"export type UpdateBotsResp = models.BotInfo;",
+		"  /** Update bot */\n  async update(req: UpdateBotsReq, options?: RequestOptions): Promise<UpdateBotsResp> {",
+		"path: `/v1/bots/${pathParam(req.bot_id)}`,",
+		"query: {\n        is_published: req.is_published,\n      },",
+		"const resp = await this.core.request<{ data: UpdateBotsResp }>(",
+		"return resp.data;",
+		"stream(req: StreamBotsReq = {}, options?: RequestOptions): AsyncGenerator<StreamEvent> {",
+		"        query: req.query,\n        stream: true,",
+		"async uploadIcon(req: UploadIconBotsReq = {}, options?: RequestOptions): Promise<void> {",
+		"  file?: Blob;",
+		"form: {\n        file: req.file,\n      },",
+		"this.versions = new BotsVersions(core);",
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected rendered resource to contain %q, got:\n%s", want, content)
+		}
+	}
+
+	versions := renderTSResource(models, resources[1])
+	if !strings.Contains(versions, "async list(options?: RequestOptions): Promise<void> {") {
+		t.Fatalf("expected parameterless list method, got:\n%s", versions)
+	}
+
+	modelsContent := renderTSModels(models)
+	for _, want := range []string{
+		"/** Bot detail */\nexport interface BotInfo {\n  bot_id?: string;\n  mode?: BotMode;\n}",
+		"export type BotMode = 0 | 1;",
+	} {
+		if !strings.Contains(modelsContent, want) {
+			t.Fatalf("expected models to contain %q, got:\n%s", want, modelsContent)
+		}
+	}
+
+	index := renderTSIndex(resources)
+	for _, want := range []string{
+		"export * as models from './models.js';",
+		"export * from './resources/bots_versions.js';",
+		"  readonly bots: Bots;",
+		"    this.bots = new Bots(core);",
+	} {
+		if !strings.Contains(index, want) {
+			t.Fatalf("expected index to contain %q, got:\n%s", want, index)
+		}
+	}
+	if strings.Contains(index, "readonly botsVersions") {
+		t.Fatalf("expected nested resource to hang off its parent, got:\n%s", index)
+	}
+}
+
+func TestTSNames(t *testing.T) {
+	cases := map[string][2]string{
+		"workspaces_members": {"WorkspacesMembers", "workspacesMembers"},
+		"_retrieve_v1":       {"RetrieveV1", "retrieveV1"},
+		"OpenAPIBot":         {"OpenAPIBot", "openAPIBot"},
+		"2fa":                {"Model2fa", "model2fa"},
+	}
+	for input, want := range cases {
+		if got := tsPascalName(input); got != want[0] {
+			t.Fatalf("tsPascalName(%q) = %q, want %q", input, got, want[0])
+		}
+		if got := tsCamelName(input); got != want[1] {
+			t.Fatalf("tsCamelName(%q) = %q, want %q", input, got, want[1])
+		}
+	}
+	if got := tsPropertyKey("x-tt-logid"); got != "'x-tt-logid'" {
+		t.Fatalf("unexpected property key %q", got)
+	}
+	if got, ok := tsLiteralFromPython(`"auto"`); !ok || got != "'auto'" {
+		t.Fatalf("unexpected literal %q", got)
+	}
+	if got := tsConfigType("Optional[List[int]]"); got != "number[]" {
+		t.Fatalf("unexpected config type %q", got)
+	}
+}
+
+func mustParseOpenAPIDoc(t *testing.T, content string) *openapi.Document {
+	t.Helper()
+	doc, err := openapi.Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("openapi.Parse() error = %v", err)
+	}
+	return doc
+}
+
+func TestRenderTSResourceMarksAnonymousOperations(t *testing.T) {
+	doc := mustParseOpenAPIDoc(t, `
+openapi: 3.0.0
+servers:
+  - url: https://api.coze.cn
+  - url: https://api-sandbox.coze.cn
+    x-coze-region: sandbox
+paths:
+  /api/permission/oauth2/token:
+    post:
+      security: []
+      responses:
+        '200':
+          description: ok
+`)
+	cfg := &config.Config{
+		API: config.APIConfig{
+			OperationMappings: []config.OperationMapping{
+				{Path: "/api/permission/oauth2/token", Method: "post", SDKMethods: []string{"oauth.token"}},
+			},
+		},
+	}
+
+	resources := buildTSResources(ir.Build(cfg, doc), doc)
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 resource, got %+v", resources)
+	}
+	if content := renderTSResource(newTSModelSet(doc), resources[0]); !strings.Contains(content, "anonymous: true,") {
+		t.Fatalf("expected anonymous request, got:\n%s", content)
+	}
+
+	runtimeData, err := newTSRuntimeData(doc)
+	if err != nil {
+		t.Fatalf("newTSRuntimeData() error = %v", err)
+	}
+	core, err := renderTSRuntimeTemplate("src/core.ts.tpl", runtimeData)
+	if err != nil {
+		t.Fatalf("renderTSRuntimeTemplate() error = %v", err)
+	}
+	for _, want := range []string{
+		"export const COZE_CN_BASE_URL = 'https://api.coze.cn';",
+		"export const COZE_SANDBOX_BASE_URL = 'https://api-sandbox.coze.cn';",
+		"options.baseURL ?? COZE_COM_BASE_URL",
+	} {
+		if !strings.Contains(core, want) {
+			t.Fatalf("expected core.ts to contain %q, got:\n%s", want, core)
+		}
+	}
+}
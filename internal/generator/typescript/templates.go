@@ -0,0 +1,39 @@
+package tsgen
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+)
+
+//go:embed all:templates/ts_runtime
+var tsRuntimeFS embed.FS
+
+func renderTSRuntimeAsset(assetName string) (string, error) {
+	assetPath := path.Join("templates", "ts_runtime", assetName)
+	content, err := tsRuntimeFS.ReadFile(assetPath)
+	if err != nil {
+		return "", fmt.Errorf("read typescript runtime asset %q: %w", assetPath, err)
+	}
+	return string(content), nil
+}
+
+// renderTSRuntimeTemplate executes a runtime asset that depends on the spec,
+// such as the base-URL constants.
+func renderTSRuntimeTemplate(assetName string, data any) (string, error) {
+	content, err := renderTSRuntimeAsset(assetName)
+	if err != nil {
+		return "", err
+	}
+	tpl, err := template.New(assetName).Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("parse typescript runtime template %q: %w", assetName, err)
+	}
+	var buf strings.Builder
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render typescript runtime template %q: %w", assetName, err)
+	}
+	return buf.String(), nil
+}
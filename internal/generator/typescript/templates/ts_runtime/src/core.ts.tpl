@@ -0,0 +1,212 @@
+{{ range .BaseURLs }}export const {{ .Const }} = '{{ .URL }}';
+{{ end }}
+export type TokenProvider = string | (() => string | Promise<string>);
+
+export interface ClientOptions {
+  token: TokenProvider;
+  baseURL?: string;
+  headers?: Record<string, string>;
+  fetch?: typeof fetch;
+}
+
+export interface RequestOptions {
+  headers?: Record<string, string>;
+  signal?: AbortSignal;
+}
+
+export interface APIRequest {
+  method: string;
+  path: string;
+  query?: Record<string, unknown>;
+  body?: unknown;
+  form?: Record<string, unknown>;
+  anonymous?: boolean;
+}
+
+export interface StreamEvent {
+  event: string;
+  data: string;
+  id?: string;
+}
+
+export class CozeAPIError extends Error {
+  readonly status: number;
+  readonly code?: number;
+  readonly logID?: string;
+
+  constructor(message: string, status: number, code?: number, logID?: string) {
+    super(message);
+    this.name = 'CozeAPIError';
+    this.status = status;
+    this.code = code;
+    this.logID = logID;
+  }
+}
+
+export class Core {
+  readonly baseURL: string;
+  private readonly token: TokenProvider;
+  private readonly headers: Record<string, string>;
+  private readonly fetchImpl: typeof fetch;
+
+  constructor(options: ClientOptions) {
+    this.baseURL = (options.baseURL ?? {{ .DefaultBaseURL }}).replace(/\/+$/, '');
+    this.token = options.token;
+    this.headers = options.headers ?? {};
+    this.fetchImpl = options.fetch ?? globalThis.fetch.bind(globalThis);
+  }
+
+  async request<T>(req: APIRequest, options?: RequestOptions): Promise<T> {
+    const response = await this.send(req, options);
+    const text = await response.text();
+    const payload = text === '' ? {} : (JSON.parse(text) as Record<string, unknown>);
+    const code = typeof payload.code === 'number' ? payload.code : 0;
+    if (!response.ok || code !== 0) {
+      const message = typeof payload.msg === 'string' && payload.msg !== '' ? payload.msg : response.statusText;
+      throw new CozeAPIError(message, response.status, code, logIDOf(response));
+    }
+    return payload as T;
+  }
+
+  async *stream(req: APIRequest, options?: RequestOptions): AsyncGenerator<StreamEvent> {
+    const response = await this.send(req, options, 'text/event-stream');
+    const contentType = response.headers.get('content-type') ?? '';
+    if (!response.ok || !contentType.includes('text/event-stream')) {
+      const payload = (await response.json().catch(() => ({}))) as Record<string, unknown>;
+      const code = typeof payload.code === 'number' ? payload.code : undefined;
+      const message = typeof payload.msg === 'string' && payload.msg !== '' ? payload.msg : response.statusText;
+      throw new CozeAPIError(message, response.status, code, logIDOf(response));
+    }
+    if (response.body === null) {
+      return;
+    }
+    yield* parseSSE(response.body);
+  }
+
+  private async send(req: APIRequest, options?: RequestOptions, accept?: string): Promise<Response> {
+    const headers: Record<string, string> = { ...this.headers };
+    if (req.anonymous !== true) {
+      headers.Authorization = `Bearer ${await resolveToken(this.token)}`;
+    }
+    Object.assign(headers, options?.headers ?? {});
+    if (accept !== undefined) {
+      headers.Accept = accept;
+    }
+    let body: BodyInit | undefined;
+    if (req.form !== undefined) {
+      body = toFormData(req.form);
+    } else if (req.body !== undefined) {
+      headers['Content-Type'] = 'application/json';
+      body = JSON.stringify(req.body);
+    }
+    return this.fetchImpl(this.url(req.path, req.query), {
+      method: req.method,
+      headers,
+      body,
+      signal: options?.signal,
+    });
+  }
+
+  private url(path: string, query?: Record<string, unknown>): string {
+    const url = new URL(this.baseURL + path);
+    for (const [key, value] of Object.entries(query ?? {})) {
+      if (value === undefined || value === null) {
+        continue;
+      }
+      const values = Array.isArray(value) ? value : [value];
+      for (const item of values) {
+        url.searchParams.append(key, String(item));
+      }
+    }
+    return url.toString();
+  }
+}
+
+export function pathParam(value: unknown): string {
+  return encodeURIComponent(String(value));
+}
+
+export async function* parseSSE(body: ReadableStream<Uint8Array>): AsyncGenerator<StreamEvent> {
+  const reader = body.getReader();
+  const decoder = new TextDecoder();
+  let buffer = '';
+  let event: Partial<StreamEvent> = {};
+  let data: string[] = [];
+  const flush = (): StreamEvent | undefined => {
+    if (data.length === 0 && event.event === undefined) {
+      return undefined;
+    }
+    const result: StreamEvent = { event: event.event ?? 'message', data: data.join('\n') };
+    if (event.id !== undefined) {
+      result.id = event.id;
+    }
+    event = {};
+    data = [];
+    return result;
+  };
+  try {
+    for (;;) {
+      const { done, value } = await reader.read();
+      buffer += decoder.decode(value, { stream: !done });
+      let index: number;
+      while ((index = buffer.search(/\r?\n/)) >= 0) {
+        const line = buffer.slice(0, index);
+        buffer = buffer.slice(buffer[index] === '\r' ? index + 2 : index + 1);
+        if (line === '') {
+          const next = flush();
+          if (next !== undefined) {
+            yield next;
+          }
+          continue;
+        }
+        if (line.startsWith(':')) {
+          continue;
+        }
+        const colon = line.indexOf(':');
+        const field = colon < 0 ? line : line.slice(0, colon);
+        const fieldValue = colon < 0 ? '' : line.slice(colon + 1).replace(/^ /, '');
+        if (field === 'event') {
+          event.event = fieldValue;
+        } else if (field === 'data') {
+          data.push(fieldValue);
+        } else if (field === 'id') {
+          event.id = fieldValue;
+        }
+      }
+      if (done) {
+        break;
+      }
+    }
+    const last = flush();
+    if (last !== undefined) {
+      yield last;
+    }
+  } finally {
+    reader.releaseLock();
+  }
+}
+
+async function resolveToken(token: TokenProvider): Promise<string> {
+  return typeof token === 'function' ? token() : token;
+}
+
+function logIDOf(response: Response): string | undefined {
+  return response.headers.get('x-tt-logid') ?? undefined;
+}
+
+function toFormData(form: Record<string, unknown>): FormData {
+  const data = new FormData();
+  for (const [key, value] of Object.entries(form)) {
+    if (value === undefined || value === null) {
+      continue;
+    }
+    if (value instanceof Blob) {
+      data.append(key, value);
+    } else if (typeof value === 'object') {
+      data.append(key, JSON.stringify(value));
+    } else {
+      data.append(key, String(value));
+    }
+  }
+  return data;
+}
@@ -0,0 +1,130 @@
+package ir
+
+import (
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+// Spec extensions honored as operation mapping defaults. Explicit
+// api.operation_mappings fields win; extensions only fill the fields a
+// mapping leaves empty, and an operation with x-coze-sdk-method counts as
+// mapped even without a config entry. The boolean extensions are the
+// exception: request_stream and stream_wrap cannot tell false from unset, so
+// x-coze-stream and x-coze-stream-wrap set to true turn streaming on even
+// over a mapping that sets them false, and false is ignored. Remove the
+// extension from the spec to turn streaming off.
+const (
+	// ExtSDKMethod names the sdk methods, as a string or a list of
+	// sdk_methods entries ("package.method").
+	ExtSDKMethod = "x-coze-sdk-method"
+	// ExtPagination is a pagination mode ("token", "number",
+	// "number_has_more") or an object with `mode` plus the pagination_* keys
+	// of an operation mapping without their prefix (`data_class`,
+	// `item_type`, `items_field`, ...).
+	ExtPagination = "x-coze-pagination"
+	// ExtStream marks an event-stream response (request_stream).
+	ExtStream = "x-coze-stream"
+	// ExtStreamWrap wraps the stream in the stream handler (stream_wrap); it
+	// implies x-coze-stream.
+	ExtStreamWrap = "x-coze-stream-wrap"
+	// ExtDataField names the response envelope field (data_field).
+	ExtDataField = "x-coze-data-field"
+	// ExtParamAlias renames a parameter in the sdk (param_aliases).
+	ExtParamAlias = "x-coze-param-alias"
+)
+
+// extensionMapping builds a mapping for an operation that only the spec
+// describes. It reports false when the operation names no sdk method.
+func extensionMapping(details openapi.OperationDetails) (*config.OperationMapping, bool) {
+	if details.Operation == nil {
+		return nil, false
+	}
+	if _, ok := details.Operation.Extensions.Strings(ExtSDKMethod); !ok {
+		return nil, false
+	}
+	mapping := &config.OperationMapping{Path: details.Path, Method: details.Method}
+	applyExtensionDefaults(mapping, details)
+	return mapping, true
+}
+
+// applyExtensionDefaults fills the fields of mapping left empty by config from
+// the operation and parameter extensions.
+func applyExtensionDefaults(mapping *config.OperationMapping, details openapi.OperationDetails) {
+	if details.Operation != nil {
+		ext := details.Operation.Extensions
+		if methods, ok := ext.Strings(ExtSDKMethod); ok && len(mapping.SDKMethods) == 0 {
+			mapping.SDKMethods = methods
+		}
+		if field, ok := ext.String(ExtDataField); ok && strings.TrimSpace(mapping.DataField) == "" {
+			mapping.DataField = field
+		}
+		if wrap, ok := ext.Bool(ExtStreamWrap); ok && wrap {
+			mapping.RequestStream = true
+			mapping.StreamWrap = true
+		}
+		if stream, ok := ext.Bool(ExtStream); ok && stream {
+			mapping.RequestStream = true
+		}
+		applyPaginationExtension(mapping, ext)
+	}
+
+	aliases := map[string]string{}
+	for _, param := range details.Parameters {
+		alias, ok := param.Extensions.String(ExtParamAlias)
+		if !ok || strings.TrimSpace(mapping.ParamAliases[param.Name]) != "" {
+			continue
+		}
+		aliases[param.Name] = alias
+	}
+	if len(aliases) == 0 {
+		return
+	}
+	// The mapping's alias map is shared with config; copy before adding.
+	merged := make(map[string]string, len(mapping.ParamAliases)+len(aliases))
+	for name, alias := range mapping.ParamAliases {
+		merged[name] = alias
+	}
+	for name, alias := range aliases {
+		merged[name] = alias
+	}
+	mapping.ParamAliases = merged
+}
+
+func applyPaginationExtension(mapping *config.OperationMapping, ext openapi.Extensions) {
+	if mode, ok := ext.String(ExtPagination); ok {
+		if strings.TrimSpace(mapping.Pagination) == "" {
+			mapping.Pagination = mode
+		}
+		return
+	}
+	values, ok := ext.Map(ExtPagination)
+	if !ok {
+		return
+	}
+	text := func(key string) string {
+		value, _ := values[key].(string)
+		return strings.TrimSpace(value)
+	}
+	fields := []struct {
+		key    string
+		target *string
+	}{
+		{"mode", &mapping.Pagination},
+		{"data_class", &mapping.PaginationDataClass},
+		{"item_type", &mapping.PaginationItemType},
+		{"items_field", &mapping.PaginationItemsField},
+		{"total_field", &mapping.PaginationTotalField},
+		{"has_more_field", &mapping.PaginationHasMoreField},
+		{"next_token_field", &mapping.PaginationNextTokenField},
+		{"page_num_field", &mapping.PaginationPageNumField},
+		{"page_size_field", &mapping.PaginationPageSizeField},
+		{"page_token_field", &mapping.PaginationPageTokenField},
+	}
+	for _, field := range fields {
+		if strings.TrimSpace(*field.target) == "" {
+			*field.target = text(field.key)
+		}
+	}
+}
@@ -0,0 +1,426 @@
+// Package ir resolves a generator config against a swagger document into a
+// language-agnostic view of packages, operations and models. Backends consume
+// the IR instead of re-walking config.Config and openapi.Document themselves.
+package ir
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+type API struct {
+	Packages   []*Package
+	Operations []*Operation
+
+	packages map[string]*Package
+}
+
+type Package struct {
+	Name       string
+	Parent     string
+	Config     *config.Package
+	Operations []*Operation
+	Models     []*Model
+}
+
+type Operation struct {
+	PackageName string
+	MethodName  string
+	// Language is set when the sdk method is qualified as "<language>.<package>.<method>";
+	// such operations are only visible to that backend.
+	Language   string
+	HTTPMethod string
+	Path       string
+	Summary    string
+	Details    openapi.OperationDetails
+	HasDetails bool
+	Mapping    *config.OperationMapping
+	Order      int
+	Parameters []Parameter
+	IsFile     bool
+	Streaming  bool
+	Pagination *Pagination
+}
+
+type Parameter struct {
+	Name      string
+	FieldName string
+	In        string
+	Required  bool
+	Schema    *openapi.Schema
+}
+
+type Pagination struct {
+	Mode           string
+	DataClass      string
+	ItemType       string
+	ItemsField     string
+	TotalField     string
+	HasMoreField   string
+	NextTokenField string
+	PageNumField   string
+	PageSizeField  string
+	PageTokenField string
+	// Items is the swagger schema of a single page item, when the response declares it.
+	Items *openapi.Schema
+}
+
+// Model is a model_schemas entry of a package. Name is the configured name and
+// may be empty, in which case each backend derives one from SchemaName.
+type Model struct {
+	Name       string
+	SchemaName string
+	// Schema is the component schema with its allOf members merged, or nil
+	// when the swagger has no such component.
+	Schema *openapi.Schema
+	Config *config.ModelSchema
+	IsEnum bool
+}
+
+func Build(cfg *config.Config, doc *openapi.Document) *API {
+	api := &API{packages: map[string]*Package{}}
+	if cfg == nil {
+		return api
+	}
+	for i := range cfg.API.Packages {
+		pkg := &cfg.API.Packages[i]
+		name := strings.TrimSpace(pkg.Name)
+		if name == "" || api.packages[name] != nil {
+			continue
+		}
+		api.addPackage(name).Config = pkg
+	}
+
+	for _, op := range buildOperations(cfg, doc) {
+		pkg := api.packages[op.PackageName]
+		if pkg == nil {
+			pkg = api.addPackage(op.PackageName)
+		}
+		pkg.Operations = append(pkg.Operations, op)
+		api.Operations = append(api.Operations, op)
+	}
+
+	known := make(map[string]struct{}, len(api.Packages))
+	for _, pkg := range api.Packages {
+		known[pkg.Name] = struct{}{}
+	}
+	for _, pkg := range api.Packages {
+		pkg.Parent = ParentPackageName(pkg.Name, known)
+		pkg.Models = buildModels(pkg.Config, doc)
+		sortOperations(pkg.Operations)
+	}
+	sort.Slice(api.Packages, func(i, j int) bool {
+		return api.Packages[i].Name < api.Packages[j].Name
+	})
+	return api
+}
+
+func (a *API) addPackage(name string) *Package {
+	pkg := &Package{Name: name}
+	a.Packages = append(a.Packages, pkg)
+	a.packages[name] = pkg
+	return pkg
+}
+
+func (a *API) Package(name string) (*Package, bool) {
+	if a == nil {
+		return nil, false
+	}
+	pkg, ok := a.packages[strings.TrimSpace(name)]
+	return pkg, ok
+}
+
+// OperationsFor returns the operations visible to a backend, in binding order.
+func (a *API) OperationsFor(language string) []*Operation {
+	if a == nil {
+		return nil
+	}
+	ops := make([]*Operation, 0, len(a.Operations))
+	for _, op := range a.Operations {
+		if op.VisibleTo(language) {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+func (p *Package) OperationsFor(language string) []*Operation {
+	if p == nil {
+		return nil
+	}
+	ops := make([]*Operation, 0, len(p.Operations))
+	for _, op := range p.Operations {
+		if op.VisibleTo(language) {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+func (o *Operation) VisibleTo(language string) bool {
+	return o.Language == "" || strings.EqualFold(o.Language, language)
+}
+
+// ParentPackageName returns the longest known package name that prefixes name
+// followed by "_", e.g. "workspaces" for "workspaces_members".
+func ParentPackageName(name string, known map[string]struct{}) string {
+	parent := ""
+	for candidate := range known {
+		if candidate == name || !strings.HasPrefix(name, candidate+"_") {
+			continue
+		}
+		if len(candidate) > len(parent) {
+			parent = candidate
+		}
+	}
+	return parent
+}
+
+func buildOperations(cfg *config.Config, doc *openapi.Document) []*Operation {
+	ops := make([]*Operation, 0)
+	existing := map[string]struct{}{}
+	if doc != nil {
+		for _, details := range doc.ListOperationDetails() {
+			existing[strings.ToLower(details.Method)+" "+details.Path] = struct{}{}
+
+			mappings := cfg.FindOperationMappings(details.Path, details.Method)
+			if len(mappings) > 0 {
+				for _, mapping := range mappings {
+					mappingCopy := mapping
+					applyExtensionDefaults(&mappingCopy, details)
+					ops = appendMappedOperations(ops, cfg, doc, &mappingCopy, details, true)
+				}
+				continue
+			}
+			if mapping, ok := extensionMapping(details); ok {
+				ops = appendMappedOperations(ops, cfg, doc, mapping, details, true)
+				continue
+			}
+			if cfg.API.GenerateOnlyMapped {
+				continue
+			}
+			pkg, ok := cfg.ResolvePackage(details.Path, "")
+			if !ok {
+				continue
+			}
+			ops = append(ops, newOperation(doc, strings.TrimSpace(pkg.Name), DefaultMethodName(details.OperationID, details.Path, details.Method), nil, details, true, len(ops)))
+		}
+	}
+
+	for _, mapping := range cfg.API.OperationMappings {
+		key := strings.ToLower(strings.TrimSpace(mapping.Method)) + " " + strings.TrimSpace(mapping.Path)
+		if _, ok := existing[key]; ok || !mapping.AllowMissingInSwagger {
+			continue
+		}
+		mappingCopy := mapping
+		ops = appendMappedOperations(ops, cfg, doc, &mappingCopy, SyntheticOperationDetails(mappingCopy), false)
+	}
+	return ops
+}
+
+func appendMappedOperations(
+	ops []*Operation,
+	cfg *config.Config,
+	doc *openapi.Document,
+	mapping *config.OperationMapping,
+	details openapi.OperationDetails,
+	hasDetails bool,
+) []*Operation {
+	for methodIndex, sdkMethod := range mapping.SDKMethods {
+		language, pkgName, methodName, ok := ResolveSDKMethod(cfg, details.Path, sdkMethod)
+		if !ok {
+			continue
+		}
+		order := len(ops)
+		if mapping.Order > 0 {
+			order = mapping.Order + methodIndex
+		}
+		op := newOperation(doc, pkgName, methodName, mapping, details, hasDetails, order)
+		op.Language = language
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+func newOperation(
+	doc *openapi.Document,
+	packageName string,
+	methodName string,
+	mapping *config.OperationMapping,
+	details openapi.OperationDetails,
+	hasDetails bool,
+	order int,
+) *Operation {
+	op := &Operation{
+		PackageName: packageName,
+		MethodName:  methodName,
+		HTTPMethod:  strings.ToUpper(strings.TrimSpace(details.Method)),
+		Path:        strings.TrimSpace(details.Path),
+		Details:     details,
+		HasDetails:  hasDetails,
+		Mapping:     mapping,
+		Order:       order,
+	}
+	if mapping != nil {
+		if override := strings.TrimSpace(mapping.HTTPMethodOverride); override != "" {
+			op.HTTPMethod = strings.ToUpper(override)
+		}
+	}
+	if op.HTTPMethod == "" {
+		op.HTTPMethod = http.MethodGet
+	}
+	if hasDetails {
+		op.Summary = operationSummary(details)
+		op.IsFile = strings.Contains(strings.ToLower(details.RequestBodyContentType), "multipart/form-data")
+	}
+	op.Parameters = buildParameters(details, mapping)
+	op.Streaming = isStreaming(details, mapping)
+	op.Pagination = buildPagination(doc, details, mapping)
+	return op
+}
+
+func buildParameters(details openapi.OperationDetails, mapping *config.OperationMapping) []Parameter {
+	var aliases map[string]string
+	ignoreHeaders := false
+	if mapping != nil {
+		aliases = mapping.ParamAliases
+		ignoreHeaders = mapping.IgnoreHeaderParams
+	}
+	groups := [][]openapi.ParameterSpec{details.PathParameters, details.QueryParameters}
+	if !ignoreHeaders {
+		groups = append(groups, details.HeaderParameters)
+	}
+	params := make([]Parameter, 0)
+	for _, group := range groups {
+		for _, spec := range group {
+			name := strings.TrimSpace(spec.Name)
+			if name == "" {
+				continue
+			}
+			fieldName := name
+			if alias := strings.TrimSpace(aliases[name]); alias != "" {
+				fieldName = alias
+			}
+			params = append(params, Parameter{
+				Name:      name,
+				FieldName: fieldName,
+				In:        spec.In,
+				Required:  spec.Required,
+				Schema:    spec.Schema,
+			})
+		}
+	}
+	return params
+}
+
+func isStreaming(details openapi.OperationDetails, mapping *config.OperationMapping) bool {
+	if mapping != nil && (mapping.RequestStream || mapping.StreamWrap) {
+		return true
+	}
+	return strings.Contains(strings.ToLower(details.ResponseContentType), "text/event-stream")
+}
+
+func buildPagination(doc *openapi.Document, details openapi.OperationDetails, mapping *config.OperationMapping) *Pagination {
+	if mapping == nil {
+		return nil
+	}
+	mode := strings.TrimSpace(mapping.Pagination)
+	if mode == "" {
+		return nil
+	}
+	pagination := &Pagination{
+		Mode:           mode,
+		DataClass:      strings.TrimSpace(mapping.PaginationDataClass),
+		ItemType:       strings.TrimSpace(mapping.PaginationItemType),
+		ItemsField:     defaultString(mapping.PaginationItemsField, "items"),
+		PageNumField:   defaultString(mapping.PaginationPageNumField, "page_num"),
+		PageSizeField:  defaultString(mapping.PaginationPageSizeField, "page_size"),
+		PageTokenField: defaultString(mapping.PaginationPageTokenField, "page_token"),
+	}
+	dataSchema := ResponseDataSchema(doc, details.ResponseSchema, defaultString(mapping.DataField, "data"))
+	if dataSchema != nil {
+		if items := doc.ResolveSchema(dataSchema.Properties[pagination.ItemsField]); items != nil {
+			pagination.Items = items.Items
+		}
+	}
+	hasProperty := func(name string) bool {
+		return dataSchema != nil && dataSchema.Properties[name] != nil
+	}
+	if field := defaultString(mapping.PaginationTotalField, "total"); hasProperty(field) || strings.TrimSpace(mapping.PaginationTotalField) != "" {
+		pagination.TotalField = field
+	}
+	if field := defaultString(mapping.PaginationHasMoreField, "has_more"); hasProperty(field) || strings.TrimSpace(mapping.PaginationHasMoreField) != "" {
+		pagination.HasMoreField = field
+	}
+	if mode == "token" {
+		pagination.NextTokenField = defaultString(mapping.PaginationNextTokenField, "next_page_token")
+		if pagination.HasMoreField == "" {
+			pagination.HasMoreField = "has_more"
+		}
+	}
+	return pagination
+}
+
+// ResponseDataSchema resolves the envelope field (usually "data") of a
+// response schema. Dotted data fields are not resolved.
+func ResponseDataSchema(doc *openapi.Document, responseSchema *openapi.Schema, dataField string) *openapi.Schema {
+	responseSchema = doc.ResolveSchema(responseSchema)
+	if responseSchema == nil || strings.Contains(dataField, ".") {
+		return nil
+	}
+	return doc.ResolveSchema(responseSchema.Properties[dataField])
+}
+
+func buildModels(pkg *config.Package, doc *openapi.Document) []*Model {
+	if pkg == nil {
+		return nil
+	}
+	models := make([]*Model, 0, len(pkg.ModelSchemas))
+	for i := range pkg.ModelSchemas {
+		modelConfig := &pkg.ModelSchemas[i]
+		model := &Model{
+			Name:       strings.TrimSpace(modelConfig.Name),
+			SchemaName: strings.TrimSpace(modelConfig.Schema),
+			Config:     modelConfig,
+		}
+		if model.SchemaName != "" && doc != nil {
+			if component := doc.Components.Schemas[model.SchemaName]; component != nil {
+				model.Schema = doc.EffectiveSchema(component)
+			}
+		}
+		model.IsEnum = len(modelConfig.EnumValues) > 0 || IsEnumSchema(model.Schema)
+		models = append(models, model)
+	}
+	return models
+}
+
+// IsEnumSchema reports whether schema renders as an enum rather than a
+// constrained scalar.
+func IsEnumSchema(schema *openapi.Schema) bool {
+	return schema != nil && len(schema.Enum) > 0 && (schema.Type == "string" || schema.Type == "integer" || schema.Type == "")
+}
+
+func sortOperations(ops []*Operation) {
+	sort.SliceStable(ops, func(i, j int) bool {
+		return ops[i].Order < ops[j].Order
+	})
+}
+
+func operationSummary(details openapi.OperationDetails) string {
+	summary := strings.TrimSpace(details.Summary)
+	if summary == "" {
+		summary = strings.TrimSpace(details.Description)
+	}
+	return strings.Join(strings.Fields(summary), " ")
+}
+
+func defaultString(value string, fallback string) string {
+	if trimmed := strings.TrimSpace(value); trimmed != "" {
+		return trimmed
+	}
+	return fallback
+}
@@ -0,0 +1,338 @@
+package ir
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+const testSwagger = `
+openapi: 3.0.0
+paths:
+  /v1/bots:
+    get:
+      operationId: OpenApiBotList
+      parameters:
+        - in: query
+          name: space_id
+          required: true
+          schema:
+            type: string
+        - in: header
+          name: X-Trace
+          schema:
+            type: string
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data:
+                    type: object
+                    properties:
+                      items:
+                        type: array
+                        items:
+                          $ref: '#/components/schemas/Bot'
+                      total:
+                        type: integer
+  /v1/bots/{bot_id}:
+    get:
+      summary: |
+        Retrieve
+        bot
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: ok
+  /v1/chat:
+    post:
+      responses:
+        '200':
+          description: ok
+          content:
+            text/event-stream:
+              schema:
+                type: string
+components:
+  schemas:
+    Bot:
+      type: object
+      properties:
+        bot_id:
+          type: string
+    BotMode:
+      type: integer
+      enum: [0, 1]
+`
+
+func TestBuildResolvesOperations(t *testing.T) {
+	doc, err := openapi.Parse([]byte(testSwagger))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	cfg := &config.Config{
+		API: config.APIConfig{
+			Packages: []config.Package{
+				{
+					Name:         "bots",
+					PathPrefixes: []string{"/v1/bots"},
+					ModelSchemas: []config.ModelSchema{{Schema: "Bot"}, {Name: "BotMode", Schema: "BotMode"}},
+				},
+				{Name: "bots_versions"},
+				{Name: "chat", PathPrefixes: []string{"/v1/chat"}},
+			},
+			OperationMappings: []config.OperationMapping{
+				{
+					Path:         "/v1/bots",
+					Method:       "get",
+					Order:        1,
+					SDKMethods:   []string{"bots.list", "go.bots.list_all"},
+					ParamAliases: map[string]string{"space_id": "workspace_id"},
+					Pagination:   "number",
+				},
+				{
+					Path:                  "/v1/bots/{bot_id}/versions/{version}",
+					Method:                "get",
+					Order:                 20,
+					SDKMethods:            []string{"bots_versions.retrieve"},
+					AllowMissingInSwagger: true,
+				},
+			},
+		},
+	}
+
+	api := Build(cfg, doc)
+	if len(api.Operations) != 5 {
+		t.Fatalf("expected 5 operations, got %d", len(api.Operations))
+	}
+	if got := len(api.OperationsFor("python")); got != 4 {
+		t.Fatalf("expected the go-only operation to be hidden from python, got %d operations", got)
+	}
+
+	bots, ok := api.Package("bots")
+	if !ok {
+		t.Fatal("expected bots package")
+	}
+	if len(bots.Models) != 2 || bots.Models[0].Name != "" || bots.Models[0].SchemaName != "Bot" || bots.Models[0].Schema == nil || bots.Models[0].IsEnum || !bots.Models[1].IsEnum {
+		t.Fatalf("unexpected bots models: %+v", bots.Models)
+	}
+	list := bots.Operations[0]
+	if list.MethodName != "list" || list.HTTPMethod != "GET" || list.Order != 1 {
+		t.Fatalf("unexpected list operation: %+v", list)
+	}
+	if len(list.Parameters) != 2 || list.Parameters[0].FieldName != "workspace_id" || !list.Parameters[0].Required || list.Parameters[1].In != "header" {
+		t.Fatalf("unexpected list parameters: %+v", list.Parameters)
+	}
+	pagination := list.Pagination
+	if pagination == nil || pagination.ItemsField != "items" || pagination.TotalField != "total" || pagination.HasMoreField != "" || pagination.PageSizeField != "page_size" {
+		t.Fatalf("unexpected pagination: %+v", pagination)
+	}
+	if pagination.Items == nil || pagination.Items.Ref != "#/components/schemas/Bot" {
+		t.Fatalf("expected pagination items schema, got %+v", pagination.Items)
+	}
+	if listAll := bots.Operations[1]; listAll.Language != "go" || listAll.VisibleTo("python") || !listAll.VisibleTo("go") {
+		t.Fatalf("unexpected language-qualified operation: %+v", listAll)
+	}
+
+	var retrieve *Operation
+	for _, op := range bots.Operations {
+		if op.Mapping == nil {
+			retrieve = op
+		}
+	}
+	if retrieve == nil || retrieve.MethodName != "bots" || retrieve.Summary != "Retrieve bot" {
+		t.Fatalf("expected unmapped operation with a default name, got %+v", retrieve)
+	}
+
+	versions, _ := api.Package("bots_versions")
+	if versions.Parent != "bots" || len(versions.Operations) != 1 {
+		t.Fatalf("unexpected bots_versions package: %+v", versions)
+	}
+	synthetic := versions.Operations[0]
+	if synthetic.HasDetails || len(synthetic.Parameters) != 2 || synthetic.Parameters[1].Name != "version" {
+		t.Fatalf("unexpected synthetic operation: %+v", synthetic)
+	}
+
+	chat, _ := api.Package("chat")
+	if len(chat.Operations) != 1 || !chat.Operations[0].Streaming || chat.Operations[0].MethodName != "chat" {
+		t.Fatalf("expected streaming chat operation, got %+v", chat.Operations)
+	}
+}
+
+func TestResolveSDKMethod(t *testing.T) {
+	cfg := &config.Config{API: config.APIConfig{Packages: []config.Package{{Name: "bots", PathPrefixes: []string{"/v1/bots"}}}}}
+	cases := []struct {
+		value    string
+		language string
+		pkg      string
+		method   string
+		ok       bool
+	}{
+		{value: "retrieve", pkg: "bots", method: "retrieve", ok: true},
+		{value: "unknown.list", pkg: "bots", method: "list", ok: true},
+		{value: "go.stores.list", language: "go", pkg: "stores", method: "list", ok: true},
+		{value: "bots.", ok: false},
+		{value: "a.b.c.d", ok: false},
+	}
+	for _, tc := range cases {
+		language, pkg, method, ok := ResolveSDKMethod(cfg, "/v1/bots", tc.value)
+		if language != tc.language || pkg != tc.pkg || method != tc.method || ok != tc.ok {
+			t.Fatalf("ResolveSDKMethod(%q) = %q, %q, %q, %v", tc.value, language, pkg, method, ok)
+		}
+	}
+	if _, pkg, _, ok := ResolveSDKMethod(cfg, "/v1/files", "files.upload"); !ok || pkg != "files" {
+		t.Fatalf("expected unknown package to be kept as written, got %q", pkg)
+	}
+}
+
+func TestDefaultMethodName(t *testing.T) {
+	if got := DefaultMethodName("OpenApiChatCancel", "/v3/chat/cancel", "post"); got != "chat_cancel" {
+		t.Fatalf("DefaultMethodName() = %q", got)
+	}
+	if got := DefaultMethodName("", "/v1/workspaces/{workspace_id}", "get"); got != "workspaces" {
+		t.Fatalf("DefaultMethodName() = %q", got)
+	}
+	if got := DefaultMethodName("", "/", "get"); got != "get" {
+		t.Fatalf("DefaultMethodName() = %q", got)
+	}
+}
+
+func TestBuildAppliesSpecExtensions(t *testing.T) {
+	doc, err := openapi.Parse([]byte(`
+openapi: 3.0.0
+paths:
+  /v1/workflows/run:
+    post:
+      x-coze-sdk-method: workflows.create
+      x-coze-stream-wrap: true
+      responses:
+        '200':
+          description: ok
+  /v1/workflows:
+    get:
+      x-coze-sdk-method: [workflows.list]
+      x-coze-data-field: result
+      x-coze-pagination:
+        mode: token
+        item_type: Workflow
+        items_field: workflows
+      parameters:
+        - in: query
+          name: space_id
+          x-coze-param-alias: workspace_id
+          schema:
+            type: string
+        - in: query
+          name: page_size
+          x-coze-param-alias: size
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: ok
+  /v1/hidden:
+    get:
+      responses:
+        '200':
+          description: ok
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	aliases := map[string]string{"page_size": "limit"}
+	cfg := &config.Config{
+		API: config.APIConfig{
+			GenerateOnlyMapped: true,
+			Packages:           []config.Package{{Name: "workflows", PathPrefixes: []string{"/v1/workflows"}}},
+			OperationMappings: []config.OperationMapping{
+				{
+					Path:                 "/v1/workflows",
+					Method:               "get",
+					SDKMethods:           []string{"workflows.list_all"},
+					ParamAliases:         aliases,
+					PaginationItemsField: "items",
+				},
+			},
+		},
+	}
+
+	api := Build(cfg, doc)
+	if len(api.Operations) != 2 {
+		t.Fatalf("expected 2 operations, got %d", len(api.Operations))
+	}
+	workflows, _ := api.Package("workflows")
+	var run, list *Operation
+	for _, op := range workflows.Operations {
+		switch op.MethodName {
+		case "create":
+			run = op
+		case "list_all":
+			list = op
+		}
+	}
+	if run == nil || !run.Streaming || !run.Mapping.StreamWrap || !run.Mapping.RequestStream {
+		t.Fatalf("expected streaming run operation from x-coze-sdk-method, got %+v", run)
+	}
+	if list == nil {
+		t.Fatalf("expected config sdk method to win, got %+v", workflows.Operations)
+	}
+	if list.Mapping.DataField != "result" || list.Pagination == nil || list.Pagination.Mode != "token" || list.Pagination.ItemsField != "items" || list.Mapping.PaginationItemType != "Workflow" {
+		t.Fatalf("unexpected list mapping defaults: %+v", list.Mapping)
+	}
+	if list.Parameters[0].FieldName != "limit" || list.Parameters[1].FieldName != "workspace_id" {
+		t.Fatalf("unexpected parameter aliases: %+v", list.Parameters)
+	}
+	if len(aliases) != 1 {
+		t.Fatalf("expected config aliases to stay untouched, got %v", aliases)
+	}
+}
+
+func TestBaseURLs(t *testing.T) {
+	defaults, err := BaseURLs(nil)
+	if err != nil {
+		t.Fatalf("BaseURLs(nil) error = %v", err)
+	}
+	if len(defaults) != 2 || defaults[0].Region != "com" || defaults[1].URL != "https://api.coze.cn" {
+		t.Fatalf("unexpected default base urls: %+v", defaults)
+	}
+
+	doc := &openapi.Document{Servers: []openapi.Server{
+		{URL: "https://api.coze.cn/"},
+		{URL: "https://cn.coze-proxy.example.net", Extensions: openapi.Extensions{"x-coze-region": "CN"}},
+		{URL: "https://api-sandbox.coze.cn", Extensions: openapi.Extensions{"x-coze-region": "Sandbox"}},
+		{URL: "/relative"},
+	}}
+	got, err := BaseURLs(doc)
+	if err != nil {
+		t.Fatalf("BaseURLs() error = %v", err)
+	}
+	want := []BaseURL{
+		{Region: "com", URL: "https://api.coze.com", Host: "api.coze.com"},
+		{Region: "cn", URL: "https://cn.coze-proxy.example.net", Host: "cn.coze-proxy.example.net"},
+		{Region: "sandbox", URL: "https://api-sandbox.coze.cn", Host: "api-sandbox.coze.cn"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("BaseURLs() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("BaseURLs()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	unknown := &openapi.Document{Servers: []openapi.Server{{URL: "https://api.coze.cn.example.net"}}}
+	if _, err := BaseURLs(unknown); err == nil || !strings.Contains(err.Error(), "x-coze-region") {
+		t.Fatalf("expected unknown host to be rejected, got %v", err)
+	}
+}
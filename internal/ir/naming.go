@@ -0,0 +1,144 @@
+package ir
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+// ParseSDKMethod splits an sdk_methods entry. Entries are "method",
+// "package.method" or "language.package.method".
+func ParseSDKMethod(value string) (string, string, string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", "", "", false
+	}
+	parts := strings.Split(value, ".")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+		if parts[i] == "" {
+			return "", "", "", false
+		}
+	}
+	switch len(parts) {
+	case 1:
+		return "", "", parts[0], true
+	case 2:
+		return "", parts[0], parts[1], true
+	case 3:
+		return strings.ToLower(parts[0]), parts[1], parts[2], true
+	default:
+		return "", "", "", false
+	}
+}
+
+// ResolveSDKMethod parses an sdk_methods entry and resolves its package.
+// Unqualified entries resolve against the configured packages, then path
+// prefixes; otherwise, and for language-qualified entries, the package is kept
+// as written.
+func ResolveSDKMethod(cfg *config.Config, path string, sdkMethod string) (string, string, string, bool) {
+	language, pkgName, methodName, ok := ParseSDKMethod(sdkMethod)
+	if !ok || cfg == nil {
+		return "", "", "", false
+	}
+	if language != "" {
+		return language, pkgName, methodName, pkgName != ""
+	}
+	pkg, ok := cfg.ResolvePackage(strings.TrimSpace(path), pkgName)
+	if !ok || strings.TrimSpace(pkg.Name) == "" {
+		return "", pkgName, methodName, pkgName != ""
+	}
+	return "", strings.TrimSpace(pkg.Name), methodName, true
+}
+
+// DefaultMethodName derives a snake_case method name for an operation that has
+// no sdk_methods mapping. Backends apply their own identifier rules on top.
+func DefaultMethodName(operationID string, path string, method string) string {
+	if op := strings.TrimSpace(operationID); op != "" {
+		for _, prefix := range []string{"OpenAPI", "OpenApi", "Openapi", "API", "Api"} {
+			op = strings.TrimPrefix(op, prefix)
+		}
+		if name := strings.TrimPrefix(ToSnake(op), "open_api_"); name != "" {
+			return name
+		}
+	}
+
+	parts := strings.Split(path, "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		part := strings.TrimSpace(parts[i])
+		if part == "" {
+			continue
+		}
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			continue
+		}
+		return ToSnake(part)
+	}
+	return strings.TrimSpace(method)
+}
+
+func ToSnake(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+
+	var out []rune
+	prevLowerOrDigit := false
+	for _, r := range value {
+		if unicode.IsUpper(r) {
+			if prevLowerOrDigit && len(out) > 0 && out[len(out)-1] != '_' {
+				out = append(out, '_')
+			}
+			out = append(out, unicode.ToLower(r))
+			prevLowerOrDigit = false
+			continue
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			out = append(out, unicode.ToLower(r))
+			prevLowerOrDigit = unicode.IsLower(r) || unicode.IsDigit(r)
+			continue
+		}
+		if len(out) > 0 && out[len(out)-1] != '_' {
+			out = append(out, '_')
+		}
+		prevLowerOrDigit = false
+	}
+	return strings.Trim(string(out), "_")
+}
+
+// SyntheticOperationDetails builds operation details for a mapping that is
+// allowed to be missing from the swagger document.
+func SyntheticOperationDetails(mapping config.OperationMapping) openapi.OperationDetails {
+	details := openapi.OperationDetails{
+		Path:   strings.TrimSpace(mapping.Path),
+		Method: strings.ToLower(strings.TrimSpace(mapping.Method)),
+	}
+	path := details.Path
+	for {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			break
+		}
+		endOffset := strings.Index(path[start:], "}")
+		if endOffset <= 1 {
+			break
+		}
+		end := start + endOffset
+		paramName := strings.TrimSpace(path[start+1 : end])
+		if paramName == "" {
+			path = path[end+1:]
+			continue
+		}
+		details.PathParameters = append(details.PathParameters, openapi.ParameterSpec{
+			Name:     paramName,
+			In:       "path",
+			Required: true,
+			Schema:   &openapi.Schema{Type: "string"},
+		})
+		path = path[end+1:]
+	}
+	return details
+}
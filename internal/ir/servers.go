@@ -0,0 +1,74 @@
+package ir
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+// BaseURL is a region the generated clients expose as a base-URL constant.
+type BaseURL struct {
+	Region string
+	URL    string
+	Host   string
+}
+
+// defaultBaseURLs are the regions every sdk exposes; the first one is the
+// client default. Their hosts are also the only ones a region is derived
+// from without x-coze-region.
+var defaultBaseURLs = []BaseURL{
+	{Region: "com", URL: "https://api.coze.com", Host: "api.coze.com"},
+	{Region: "cn", URL: "https://api.coze.cn", Host: "api.coze.cn"},
+}
+
+// BaseURLs merges the spec servers into the default regions: a server for a
+// known region replaces its URL, one for a new region is appended. The region
+// is x-coze-region, else the region of a known host; relative servers are
+// skipped and any other server is an error.
+func BaseURLs(doc *openapi.Document) ([]BaseURL, error) {
+	urls := append([]BaseURL(nil), defaultBaseURLs...)
+	if doc == nil {
+		return urls, nil
+	}
+	for _, server := range doc.Servers {
+		raw := strings.TrimRight(strings.TrimSpace(server.URL), "/")
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parse server url %q: %w", server.URL, err)
+		}
+		if parsed.Host == "" {
+			continue
+		}
+		region := strings.ToLower(server.Region())
+		if region == "" {
+			region = knownHostRegion(parsed.Hostname())
+		}
+		if region == "" {
+			return nil, fmt.Errorf("server %q: unknown host %q, set x-coze-region to name its region", server.URL, parsed.Hostname())
+		}
+		baseURL := BaseURL{Region: region, URL: raw, Host: parsed.Host}
+		replaced := false
+		for i := range urls {
+			if urls[i].Region == region {
+				urls[i] = baseURL
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			urls = append(urls, baseURL)
+		}
+	}
+	return urls, nil
+}
+
+func knownHostRegion(host string) string {
+	for _, baseURL := range defaultBaseURLs {
+		if strings.EqualFold(baseURL.Host, host) {
+			return baseURL.Region
+		}
+	}
+	return ""
+}
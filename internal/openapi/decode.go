@@ -0,0 +1,295 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+var (
+	yamlLinePattern     = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	yamlTypeErrorValue  = regexp.MustCompile("`([^`]*)`")
+	utf8ByteOrderMarker = []byte("\xef\xbb\xbf")
+)
+
+// ParseError reports where a document failed to decode. Column is 0 when the
+// decoder only knows the line.
+type ParseError struct {
+	Path    string
+	Format  Format
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	var buf strings.Builder
+	buf.WriteString("parse openapi " + string(e.Format))
+	if e.Path != "" {
+		buf.WriteString(fmt.Sprintf(" %q", e.Path))
+	}
+	buf.WriteString(": ")
+	switch {
+	case e.Line > 0 && e.Column > 0:
+		buf.WriteString(fmt.Sprintf("line %d, column %d: ", e.Line, e.Column))
+	case e.Line > 0:
+		buf.WriteString(fmt.Sprintf("line %d: ", e.Line))
+	}
+	buf.WriteString(e.Message)
+	return buf.String()
+}
+
+// DetectFormat picks the decoder for a document: a .json/.yaml/.yml extension
+// wins, otherwise content starting with '{' or '[' is treated as JSON.
+func DetectFormat(path string, content []byte) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	}
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(content, utf8ByteOrderMarker), " \t\r\n")
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return FormatJSON
+	}
+	return FormatYAML
+}
+
+// decodeNode parses content into a node tree. It returns nil for an empty
+// document.
+func decodeNode(content []byte, format Format) (*yaml.Node, error) {
+	switch format {
+	case FormatJSON:
+		return jsonToNode(content)
+	case FormatYAML:
+		return yamlToNode(content)
+	default:
+		return nil, &ParseError{Format: format, Message: "unsupported format"}
+	}
+}
+
+func decodeInto(root *yaml.Node, format Format, out interface{}) error {
+	if root == nil {
+		return nil
+	}
+	if err := root.Decode(out); err != nil {
+		return typeErrorToParseError(format, root, err)
+	}
+	return nil
+}
+
+func yamlToNode(content []byte) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		parseErr := &ParseError{Format: FormatYAML, Message: strings.TrimPrefix(err.Error(), "yaml: ")}
+		if match := yamlLinePattern.FindStringSubmatch(err.Error()); match != nil {
+			parseErr.Line, _ = strconv.Atoi(match[1])
+			parseErr.Message = match[2]
+		}
+		return nil, parseErr
+	}
+	if root.Kind == 0 {
+		return nil, nil
+	}
+	return &root, nil
+}
+
+// typeErrorToParseError keeps the first decode failure and recovers its
+// column from the node tree, since yaml.TypeError only reports lines.
+func typeErrorToParseError(format Format, root *yaml.Node, err error) error {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) || len(typeErr.Errors) == 0 {
+		return &ParseError{Format: format, Message: err.Error()}
+	}
+	parseErr := &ParseError{Format: format, Message: typeErr.Errors[0]}
+	if match := yamlLinePattern.FindStringSubmatch(typeErr.Errors[0]); match != nil {
+		parseErr.Line, _ = strconv.Atoi(match[1])
+		parseErr.Message = match[2]
+		value := ""
+		if valueMatch := yamlTypeErrorValue.FindStringSubmatch(match[2]); valueMatch != nil {
+			value = valueMatch[1]
+		}
+		parseErr.Column = findNodeColumn(root, parseErr.Line, value)
+	}
+	if extra := len(typeErr.Errors) - 1; extra > 0 {
+		parseErr.Message += fmt.Sprintf(" (and %d more)", extra)
+	}
+	return parseErr
+}
+
+func findNodeColumn(node *yaml.Node, line int, value string) int {
+	column := 0
+	var walk func(node *yaml.Node) bool
+	walk = func(node *yaml.Node) bool {
+		if node == nil {
+			return false
+		}
+		if node.Line == line {
+			if column == 0 {
+				column = node.Column
+			}
+			if value != "" && node.Value == value {
+				column = node.Column
+				return true
+			}
+		}
+		for _, child := range node.Content {
+			if walk(child) {
+				return true
+			}
+		}
+		return false
+	}
+	walk(node)
+	return column
+}
+
+// jsonToNode decodes JSON into a yaml.Node tree so both formats share one
+// decoding path, keeping source positions for error messages.
+func jsonToNode(content []byte) (*yaml.Node, error) {
+	content = bytes.TrimPrefix(content, utf8ByteOrderMarker)
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	positions := newJSONPositions(content)
+
+	node, err := decodeJSONValue(decoder, positions)
+	if err != nil {
+		return nil, jsonParseError(err, decoder, positions)
+	}
+	trailing := positions.tokenStart(decoder.InputOffset())
+	if _, err := decoder.Token(); err != io.EOF {
+		line, column := positions.at(trailing)
+		return nil, &ParseError{Format: FormatJSON, Line: line, Column: column, Message: "unexpected data after top-level value"}
+	}
+	return &yaml.Node{Kind: yaml.DocumentNode, Line: node.Line, Column: node.Column, Content: []*yaml.Node{node}}, nil
+}
+
+func decodeJSONValue(decoder *json.Decoder, positions *jsonPositions) (*yaml.Node, error) {
+	start := positions.tokenStart(decoder.InputOffset())
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	line, column := positions.at(start)
+	node := &yaml.Node{Line: line, Column: column}
+	switch value := token.(type) {
+	case json.Delim:
+		switch value {
+		case '{':
+			node.Kind = yaml.MappingNode
+			node.Tag = "!!map"
+			for decoder.More() {
+				key, err := decodeJSONValue(decoder, positions)
+				if err != nil {
+					return nil, err
+				}
+				item, err := decodeJSONValue(decoder, positions)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, key, item)
+			}
+		case '[':
+			node.Kind = yaml.SequenceNode
+			node.Tag = "!!seq"
+			for decoder.More() {
+				item, err := decodeJSONValue(decoder, positions)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, item)
+			}
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		node.Kind = yaml.ScalarNode
+		node.Tag = "!!str"
+		node.Value = value
+		node.Style = yaml.DoubleQuotedStyle
+	case json.Number:
+		node.Kind = yaml.ScalarNode
+		node.Tag = "!!float"
+		if _, err := value.Int64(); err == nil {
+			node.Tag = "!!int"
+		}
+		node.Value = value.String()
+	case bool:
+		node.Kind = yaml.ScalarNode
+		node.Tag = "!!bool"
+		node.Value = strconv.FormatBool(value)
+	case nil:
+		node.Kind = yaml.ScalarNode
+		node.Tag = "!!null"
+		node.Value = "null"
+	}
+	return node, nil
+}
+
+func jsonParseError(err error, decoder *json.Decoder, positions *jsonPositions) error {
+	offset := decoder.InputOffset()
+	message := err.Error()
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || message == "unexpected end of JSON input":
+		message = "unexpected end of JSON input"
+		offset = int64(len(positions.content))
+	case errors.As(err, &syntaxErr) && syntaxErr.Offset > 0:
+		// Offset counts the offending byte.
+		offset = syntaxErr.Offset - 1
+	}
+	line, column := positions.at(offset)
+	return &ParseError{Format: FormatJSON, Line: line, Column: column, Message: message}
+}
+
+type jsonPositions struct {
+	content    []byte
+	lineStarts []int
+}
+
+func newJSONPositions(content []byte) *jsonPositions {
+	starts := []int{0}
+	for i, b := range content {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return &jsonPositions{content: content, lineStarts: starts}
+}
+
+// tokenStart skips the whitespace and separators the decoder has not consumed
+// yet, returning the offset of the next token.
+func (p *jsonPositions) tokenStart(offset int64) int64 {
+	for offset < int64(len(p.content)) && strings.IndexByte(" \t\r\n,:", p.content[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+// at converts a byte offset to a 1-based line and column.
+func (p *jsonPositions) at(offset int64) (int, int) {
+	index := sort.Search(len(p.lineStarts), func(i int) bool {
+		return int64(p.lineStarts[i]) > offset
+	}) - 1
+	if index < 0 {
+		index = 0
+	}
+	return index + 1, int(offset) - p.lineStarts[index] + 1
+}
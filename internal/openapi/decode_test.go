@@ -0,0 +1,123 @@
+package openapi
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadJSONMatchesYAML(t *testing.T) {
+	fromYAML, err := Load(filepath.Join("testdata", "swagger_fragment.yaml"))
+	if err != nil {
+		t.Fatalf("Load(yaml) error = %v", err)
+	}
+	fromJSON, err := Load(filepath.Join("testdata", "swagger_fragment.json"))
+	if err != nil {
+		t.Fatalf("Load(json) error = %v", err)
+	}
+	if !reflect.DeepEqual(fromYAML, fromJSON) {
+		t.Fatalf("expected JSON and YAML fragments to decode identically\nyaml=%+v\njson=%+v", fromYAML, fromJSON)
+	}
+}
+
+func TestParseDetectsJSONContent(t *testing.T) {
+	doc, err := Parse([]byte("\xef\xbb\xbf{\"paths\": {\"/v1\\/bots\": {\"get\": {\"operationId\": \"ListBots\"}}},\n \"components\": {\"schemas\": {\"Mode\": {\"type\": \"integer\", \"enum\": [0, 1.5]}}}}"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if op, ok := doc.Operation("get", "/v1/bots"); !ok || op.OperationID != "ListBots" {
+		t.Fatalf("expected escaped JSON path to decode, got %+v", doc.Paths)
+	}
+	enum := doc.Components.Schemas["Mode"].Enum
+	if len(enum) != 2 || enum[0] != 0 || enum[1] != 1.5 {
+		t.Fatalf("expected JSON numbers to decode like YAML, got %#v", enum)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	cases := []struct {
+		path    string
+		content string
+		want    Format
+	}{
+		{path: "openapi.json", content: "paths: {}", want: FormatJSON},
+		{path: "openapi.YML", content: "{}", want: FormatYAML},
+		{path: "", content: "  \n[1]", want: FormatJSON},
+		{path: "spec", content: "openapi: 3.0.0", want: FormatYAML},
+	}
+	for _, tc := range cases {
+		if got := DetectFormat(tc.path, []byte(tc.content)); got != tc.want {
+			t.Fatalf("DetectFormat(%q, %q) = %s, want %s", tc.path, tc.content, got, tc.want)
+		}
+	}
+}
+
+func TestParseErrorsReportLineAndColumn(t *testing.T) {
+	cases := []struct {
+		name    string
+		format  Format
+		content string
+		want    string
+	}{
+		{
+			name:    "json syntax",
+			format:  FormatJSON,
+			content: "{\n  \"paths\": {\n    \"/v1\": [}\n}",
+			want:    "parse openapi json: line 3, column 13: invalid character '}' looking for beginning of value",
+		},
+		{
+			name:    "json truncated",
+			format:  FormatJSON,
+			content: "{\n  \"paths\": {",
+			want:    "parse openapi json: line 2, column 13: unexpected end of JSON input",
+		},
+		{
+			name:    "json trailing data",
+			format:  FormatJSON,
+			content: "{}\n  {}",
+			want:    "parse openapi json: line 2, column 3: unexpected data after top-level value",
+		},
+		{
+			name:    "json type",
+			format:  FormatJSON,
+			content: "{\n  \"paths\": {\"/v1\": {\"get\": {\n    \"deprecated\": \"soon\"}}}}",
+			want:    "parse openapi json: line 3, column 19: cannot unmarshal !!str `soon` into bool",
+		},
+		{
+			name:    "yaml syntax",
+			format:  FormatYAML,
+			content: "paths:\n  /v1: [\n",
+			want:    "parse openapi yaml: line 2: did not find expected node content",
+		},
+		{
+			name:    "yaml type",
+			format:  FormatYAML,
+			content: "paths:\n  /v1:\n    get:\n      deprecated: soon\n      tags: nope\n",
+			want:    "parse openapi yaml: line 4, column 19: cannot unmarshal !!str `soon` into bool (and 1 more)",
+		},
+	}
+	for _, tc := range cases {
+		_, err := ParseFormat([]byte(tc.content), tc.format)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("%s: expected ParseError, got %v", tc.name, err)
+		}
+		if err.Error() != tc.want {
+			t.Fatalf("%s: unexpected error\n got: %s\nwant: %s", tc.name, err.Error(), tc.want)
+		}
+	}
+}
+
+func TestLoadParseErrorIncludesPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.json")
+	if err := os.WriteFile(path, []byte("{\"paths\": ]"), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	_, err := Load(path)
+	if err == nil || !strings.Contains(err.Error(), "parse openapi json \""+path+"\": line 1, column 11: ") {
+		t.Fatalf("expected path and position in error, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type Discriminator struct {
+	PropertyName string            `yaml:"propertyName"`
+	Mapping      map[string]string `yaml:"mapping"`
+}
+
+// UnionVariant is one member of a discriminated oneOf/anyOf together with the
+// discriminator values that select it.
+type UnionVariant struct {
+	SchemaName string
+	Schema     *Schema
+	Tags       []string
+}
+
+// DiscriminatorTag is the discriminator property a variant schema carries and
+// the values it may hold.
+type DiscriminatorTag struct {
+	PropertyName string
+	Values       []string
+}
+
+// UnionVariants lists the members of a discriminated oneOf/anyOf. Tags come
+// from the discriminator mapping, then from a const or enum on the member's
+// discriminator property, then from the member's schema name (the implicit
+// mapping). It reports false unless schema has a discriminator and every
+// member is a named component.
+func (d *Document) UnionVariants(schema *Schema) ([]UnionVariant, bool) {
+	resolved := d.ResolveSchema(schema)
+	if resolved == nil || resolved.Discriminator == nil || strings.TrimSpace(resolved.Discriminator.PropertyName) == "" {
+		return nil, false
+	}
+	members := resolved.OneOf
+	if len(members) == 0 {
+		members = resolved.AnyOf
+	}
+	if len(members) == 0 {
+		return nil, false
+	}
+	property := strings.TrimSpace(resolved.Discriminator.PropertyName)
+
+	mapped := map[string][]string{}
+	keys := make([]string, 0, len(resolved.Discriminator.Mapping))
+	for key := range resolved.Discriminator.Mapping {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		target := strings.TrimSpace(resolved.Discriminator.Mapping[key])
+		name, ok := refName(target, "#/components/schemas/")
+		if !ok {
+			name = target
+		}
+		mapped[name] = append(mapped[name], key)
+	}
+
+	variants := make([]UnionVariant, 0, len(members))
+	for _, member := range members {
+		name, ok := d.SchemaName(member)
+		if !ok {
+			return nil, false
+		}
+		variant := UnionVariant{SchemaName: name, Schema: d.ResolveSchema(member), Tags: mapped[name]}
+		if len(variant.Tags) == 0 {
+			variant.Tags = schemaTagValues(d, variant.Schema, property)
+		}
+		if len(variant.Tags) == 0 {
+			variant.Tags = []string{name}
+		}
+		variants = append(variants, variant)
+	}
+	return variants, true
+}
+
+// DiscriminatorTags maps every component schema used as a discriminated
+// union member to the tag it carries.
+func (d *Document) DiscriminatorTags() map[string]DiscriminatorTag {
+	tags := map[string]DiscriminatorTag{}
+	if d == nil {
+		return tags
+	}
+	visited := map[*Schema]struct{}{}
+	var walk func(*Schema)
+	walk = func(schema *Schema) {
+		resolved := d.ResolveSchema(schema)
+		if resolved == nil {
+			return
+		}
+		if _, ok := visited[resolved]; ok {
+			return
+		}
+		visited[resolved] = struct{}{}
+		if variants, ok := d.UnionVariants(resolved); ok {
+			property := strings.TrimSpace(resolved.Discriminator.PropertyName)
+			for _, variant := range variants {
+				tag, exists := tags[variant.SchemaName]
+				if exists && tag.PropertyName != property {
+					continue
+				}
+				tag.PropertyName = property
+				for _, value := range variant.Tags {
+					if !containsString(tag.Values, value) {
+						tag.Values = append(tag.Values, value)
+					}
+				}
+				tags[variant.SchemaName] = tag
+			}
+		}
+		for _, name := range sortedKeys(resolved.Properties) {
+			walk(resolved.Properties[name])
+		}
+		walk(resolved.Items)
+		for _, group := range [][]*Schema{resolved.AllOf, resolved.OneOf, resolved.AnyOf} {
+			for _, item := range group {
+				walk(item)
+			}
+		}
+	}
+	for _, name := range sortedKeys(d.Components.Schemas) {
+		walk(d.Components.Schemas[name])
+	}
+	return tags
+}
+
+func schemaTagValues(d *Document, schema *Schema, property string) []string {
+	effective := d.EffectiveSchema(schema)
+	if effective == nil {
+		return nil
+	}
+	field := d.ResolveSchema(effective.Properties[property])
+	if field == nil {
+		return nil
+	}
+	values := make([]string, 0, len(field.Enum))
+	for _, value := range field.Enum {
+		values = append(values, fmt.Sprint(value))
+	}
+	return values
+}
@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnionVariantsResolveDiscriminatorTags(t *testing.T) {
+	doc, err := Parse([]byte(`components:
+  schemas:
+    WorkflowEvent:
+      anyOf:
+        - $ref: "#/components/schemas/MessageEvent"
+        - $ref: "#/components/schemas/ErrorEvent"
+        - $ref: "#/components/schemas/Interrupt"
+      discriminator:
+        propertyName: event
+        mapping:
+          Message: "#/components/schemas/MessageEvent"
+          Delta: MessageEvent
+    MessageEvent:
+      type: object
+      properties:
+        event:
+          type: string
+    ErrorEvent:
+      type: object
+      properties:
+        event:
+          type: string
+          enum: [Error]
+    Interrupt:
+      type: object
+    Plain:
+      oneOf:
+        - $ref: "#/components/schemas/MessageEvent"
+        - type: string
+      discriminator:
+        propertyName: event
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	variants, ok := doc.UnionVariants(doc.Components.Schemas["WorkflowEvent"])
+	if !ok {
+		t.Fatal("expected WorkflowEvent to be a discriminated union")
+	}
+	got := map[string][]string{}
+	for _, variant := range variants {
+		got[variant.SchemaName] = variant.Tags
+	}
+	want := map[string][]string{
+		"MessageEvent": {"Delta", "Message"},
+		"ErrorEvent":   {"Error"},
+		"Interrupt":    {"Interrupt"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("UnionVariants() tags = %v, want %v", got, want)
+	}
+	if _, ok := doc.UnionVariants(doc.Components.Schemas["Plain"]); ok {
+		t.Fatal("expected union with an inline member to be rejected")
+	}
+	if _, ok := doc.UnionVariants(doc.Components.Schemas["MessageEvent"]); ok {
+		t.Fatal("expected plain object not to be a union")
+	}
+
+	tags := doc.DiscriminatorTags()
+	if tag := tags["ErrorEvent"]; tag.PropertyName != "event" || !reflect.DeepEqual(tag.Values, []string{"Error"}) {
+		t.Fatalf("DiscriminatorTags()[ErrorEvent] = %+v", tag)
+	}
+}
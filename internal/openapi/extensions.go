@@ -0,0 +1,111 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Extensions holds the `x-` keys of a spec object, decoded as plain values
+// (strings, bools, numbers, []interface{} and map[string]interface{}).
+type Extensions map[string]interface{}
+
+func (o *Operation) UnmarshalYAML(node *yaml.Node) error {
+	type plainOperation Operation
+	return decodeExtensible(node, (*plainOperation)(o), &o.Extensions)
+}
+
+func (p *Parameter) UnmarshalYAML(node *yaml.Node) error {
+	type plainParameter Parameter
+	return decodeExtensible(node, (*plainParameter)(p), &p.Extensions)
+}
+
+func (o *swaggerOperation) UnmarshalYAML(node *yaml.Node) error {
+	type plainOperation swaggerOperation
+	return decodeExtensible(node, (*plainOperation)(o), &o.Extensions)
+}
+
+func (p *swaggerParameter) UnmarshalYAML(node *yaml.Node) error {
+	type plainParameter swaggerParameter
+	return decodeExtensible(node, (*plainParameter)(p), &p.Extensions)
+}
+
+func decodeExtensible(node *yaml.Node, out interface{}, extensions *Extensions) error {
+	if err := node.Decode(out); err != nil {
+		return err
+	}
+	decoded, err := decodeExtensions(node)
+	if err != nil {
+		return err
+	}
+	*extensions = decoded
+	return nil
+}
+
+func decodeExtensions(node *yaml.Node) (Extensions, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	var extensions Extensions
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		var value interface{}
+		if err := node.Content[i+1].Decode(&value); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", key, err)
+		}
+		if extensions == nil {
+			extensions = Extensions{}
+		}
+		extensions[key] = value
+	}
+	return extensions, nil
+}
+
+// String returns a string extension; other value types report false.
+func (e Extensions) String(name string) (string, bool) {
+	value, ok := e[name].(string)
+	if !ok || strings.TrimSpace(value) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(value), true
+}
+
+func (e Extensions) Bool(name string) (bool, bool) {
+	value, ok := e[name].(bool)
+	return value, ok
+}
+
+// Strings accepts a single string or a list of strings.
+func (e Extensions) Strings(name string) ([]string, bool) {
+	switch value := e[name].(type) {
+	case string:
+		if strings.TrimSpace(value) == "" {
+			return nil, false
+		}
+		return []string{strings.TrimSpace(value)}, true
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			text, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			if text = strings.TrimSpace(text); text != "" {
+				values = append(values, text)
+			}
+		}
+		return values, len(values) > 0
+	default:
+		return nil, false
+	}
+}
+
+// Map returns an object extension.
+func (e Extensions) Map(name string) (map[string]interface{}, bool) {
+	value, ok := e[name].(map[string]interface{})
+	return value, ok
+}
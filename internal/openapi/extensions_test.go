@@ -0,0 +1,69 @@
+package openapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCapturesExtensions(t *testing.T) {
+	yamlDoc := `openapi: 3.0.0
+paths:
+  /v1/bots:
+    get:
+      x-coze-sdk-method: [bots.list, bots.list_all]
+      x-coze-pagination:
+        mode: number
+      parameters:
+        - in: query
+          name: space_id
+          x-coze-param-alias: workspace_id
+          schema:
+            type: string
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    Bot:
+      type: object
+      x-internal: true
+      properties:
+        bot_id:
+          type: string
+`
+	jsonDoc := `{"swagger": "2.0", "paths": {"/v1/bots": {"get": {
+  "x-coze-sdk-method": ["bots.list", "bots.list_all"],
+  "x-coze-pagination": {"mode": "number"},
+  "parameters": [{"in": "query", "name": "space_id", "type": "string", "x-coze-param-alias": "workspace_id"}],
+  "responses": {"200": {"description": "ok"}}}}},
+  "definitions": {"Bot": {"type": "object", "x-internal": true, "properties": {"bot_id": {"type": "string"}}}}}`
+
+	for name, content := range map[string]string{"openapi3 yaml": yamlDoc, "swagger2 json": jsonDoc} {
+		t.Run(name, func(t *testing.T) {
+			doc, err := Parse([]byte(content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			details, ok := doc.OperationDetails("/v1/bots", "get")
+			if !ok {
+				t.Fatal("expected operation")
+			}
+			ext := details.Operation.Extensions
+			if methods, ok := ext.Strings("x-coze-sdk-method"); !ok || !reflect.DeepEqual(methods, []string{"bots.list", "bots.list_all"}) {
+				t.Fatalf("unexpected x-coze-sdk-method: %v", ext)
+			}
+			if pagination, ok := ext.Map("x-coze-pagination"); !ok || pagination["mode"] != "number" {
+				t.Fatalf("unexpected x-coze-pagination: %v", ext)
+			}
+			if alias, ok := details.Parameters[0].Extensions.String("x-coze-param-alias"); !ok || alias != "workspace_id" {
+				t.Fatalf("unexpected parameter extensions: %v", details.Parameters[0].Extensions)
+			}
+			if internal, ok := doc.Components.Schemas["Bot"].Extensions.Bool("x-internal"); !ok || !internal {
+				t.Fatalf("unexpected schema extensions: %v", doc.Components.Schemas["Bot"].Extensions)
+			}
+			if doc.Components.Schemas["Bot"].Properties["bot_id"].Extensions != nil {
+				t.Fatal("expected schemas without x- keys to have no extensions")
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package openapi
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -11,17 +13,20 @@ import (
 )
 
 type Document struct {
-	OpenAPI    string              `yaml:"openapi"`
-	Swagger    string              `yaml:"swagger"`
-	Paths      map[string]PathItem `yaml:"paths"`
-	Components Components          `yaml:"components"`
+	OpenAPI    string                `yaml:"openapi"`
+	Swagger    string                `yaml:"swagger"`
+	Servers    []Server              `yaml:"servers"`
+	Security   []SecurityRequirement `yaml:"security"`
+	Paths      map[string]PathItem   `yaml:"paths"`
+	Components Components            `yaml:"components"`
 }
 
 type Components struct {
-	Schemas       map[string]*Schema      `yaml:"schemas"`
-	Parameters    map[string]*Parameter   `yaml:"parameters"`
-	Responses     map[string]*Response    `yaml:"responses"`
-	RequestBodies map[string]*RequestBody `yaml:"requestBodies"`
+	Schemas         map[string]*Schema         `yaml:"schemas"`
+	Parameters      map[string]*Parameter      `yaml:"parameters"`
+	Responses       map[string]*Response       `yaml:"responses"`
+	RequestBodies   map[string]*RequestBody    `yaml:"requestBodies"`
+	SecuritySchemes map[string]*SecurityScheme `yaml:"securitySchemes"`
 }
 
 type PathItem struct {
@@ -45,15 +50,19 @@ type Operation struct {
 	Parameters  []*Parameter         `yaml:"parameters"`
 	RequestBody *RequestBody         `yaml:"requestBody"`
 	Responses   map[string]*Response `yaml:"responses"`
+	// Security is nil when the operation inherits the document security.
+	Security   []SecurityRequirement `yaml:"security"`
+	Extensions Extensions            `yaml:"-"`
 }
 
 type Parameter struct {
-	Ref         string  `yaml:"$ref"`
-	Name        string  `yaml:"name"`
-	In          string  `yaml:"in"`
-	Description string  `yaml:"description"`
-	Required    bool    `yaml:"required"`
-	Schema      *Schema `yaml:"schema"`
+	Ref         string     `yaml:"$ref"`
+	Name        string     `yaml:"name"`
+	In          string     `yaml:"in"`
+	Description string     `yaml:"description"`
+	Required    bool       `yaml:"required"`
+	Schema      *Schema    `yaml:"schema"`
+	Extensions  Extensions `yaml:"-"`
 }
 
 type RequestBody struct {
@@ -88,6 +97,16 @@ type Schema struct {
 	AllOf                []*Schema          `yaml:"allOf"`
 	OneOf                []*Schema          `yaml:"oneOf"`
 	AnyOf                []*Schema          `yaml:"anyOf"`
+	Const                interface{}        `yaml:"const"`
+	Example              interface{}        `yaml:"example"`
+	Examples             []interface{}      `yaml:"examples"`
+	PrefixItems          []*Schema          `yaml:"prefixItems"`
+	Discriminator        *Discriminator     `yaml:"discriminator"`
+	Extensions           Extensions         `yaml:"-"`
+
+	// nullType records an explicit `type: "null"` (or a type list of only
+	// "null"); decoding folds it into Nullable with an empty Type.
+	nullType bool
 }
 
 type OperationRef struct {
@@ -101,6 +120,7 @@ type ParameterSpec struct {
 	Description string
 	Required    bool
 	Schema      *Schema
+	Extensions  Extensions
 }
 
 type OperationDetails struct {
@@ -121,20 +141,72 @@ type OperationDetails struct {
 	Response               *Response
 	ResponseSchema         *Schema
 	ResponseContentType    string
+	// Security is the effective requirement list; nil when neither the
+	// operation nor the document declares one.
+	Security []SecurityRequirement
 }
 
+// Load reads a YAML or JSON document; see DetectFormat. Relative-file $refs
+// are resolved against the directory of path.
 func Load(path string) (*Document, error) {
-	content, err := os.ReadFile(path)
+	doc, root, err := readDocument(path)
 	if err != nil {
-		return nil, fmt.Errorf("read openapi file %q: %w", path, err)
+		return nil, err
+	}
+	if err := resolveRefs(doc, root, path); err != nil {
+		return nil, err
 	}
-	return Parse(content)
+	return doc, nil
 }
 
 func Parse(content []byte) (*Document, error) {
+	return ParseFormat(content, DetectFormat("", content))
+}
+
+func ParseFormat(content []byte, format Format) (*Document, error) {
+	doc, root, err := parseDocument(content, format)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveRefs(doc, root, ""); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func readDocument(path string) (*Document, *yaml.Node, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read openapi file %q: %w", path, err)
+	}
+	doc, root, err := parseDocument(content, DetectFormat(path, content))
+	if err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Path = path
+		}
+		return nil, nil, err
+	}
+	return doc, root, nil
+}
+
+// parseDocument decodes content without resolving $refs and also returns the
+// node tree, which JSON-pointer refs are looked up in.
+func parseDocument(content []byte, format Format) (*Document, *yaml.Node, error) {
+	root, err := decodeNode(content, format)
+	if err != nil {
+		return nil, nil, err
+	}
 	var doc Document
-	if err := yaml.Unmarshal(content, &doc); err != nil {
-		return nil, fmt.Errorf("parse openapi yaml: %w", err)
+	if err := decodeInto(root, format, &doc); err != nil {
+		return nil, nil, err
+	}
+	if isSwagger2(doc.Swagger) {
+		var legacy swaggerDocument
+		if err := decodeInto(root, format, &legacy); err != nil {
+			return nil, nil, err
+		}
+		doc = legacy.convert()
 	}
 	if doc.Paths == nil {
 		doc.Paths = map[string]PathItem{}
@@ -151,7 +223,7 @@ func Parse(content []byte) (*Document, error) {
 	if doc.Components.RequestBodies == nil {
 		doc.Components.RequestBodies = map[string]*RequestBody{}
 	}
-	return &doc, nil
+	return &doc, root, nil
 }
 
 func (d *Document) HasOperation(method string, path string) bool {
@@ -294,6 +366,10 @@ func (d *Document) OperationDetails(path string, method string) (*OperationDetai
 		Summary:     operation.Summary,
 		Description: operation.Description,
 		Tags:        append([]string(nil), operation.Tags...),
+		Security:    d.Security,
+	}
+	if operation.Security != nil {
+		details.Security = operation.Security
 	}
 
 	paramMap := map[string]ParameterSpec{}
@@ -309,6 +385,7 @@ func (d *Document) OperationDetails(path string, method string) (*OperationDetai
 			Description: resolved.Description,
 			Required:    resolved.Required,
 			Schema:      d.ResolveSchema(resolved.Schema),
+			Extensions:  resolved.Extensions,
 		}
 	}
 	for _, parameter := range operation.Parameters {
@@ -323,6 +400,7 @@ func (d *Document) OperationDetails(path string, method string) (*OperationDetai
 			Description: resolved.Description,
 			Required:    resolved.Required,
 			Schema:      d.ResolveSchema(resolved.Schema),
+			Extensions:  resolved.Extensions,
 		}
 	}
 
@@ -609,6 +687,14 @@ func refName(ref string, prefix string) (string, bool) {
 	return name, true
 }
 
+var colonPathParamPattern = regexp.MustCompile(`/:([A-Za-z0-9_]+)`)
+
+// NormalizePathTemplate rewrites ":param" path segments, as written by the
+// API docs, to OpenAPI "{param}" templates.
+func NormalizePathTemplate(path string) string {
+	return colonPathParamPattern.ReplaceAllString(path, "/{$1}")
+}
+
 func normalizeMethod(method string) string {
 	return strings.ToLower(strings.TrimSpace(method))
 }
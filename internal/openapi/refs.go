@@ -0,0 +1,507 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	errRefNotFound = errors.New("target not found")
+	errRefCircular = errors.New("circular reference")
+)
+
+var pointerTokenReplacer = strings.NewReplacer("~1", "/", "~0", "~")
+
+// RefError reports a $ref that could not be resolved. Chain lists the refs
+// followed to reach it, ending with the failing one; refs into other files
+// are shown relative to the root document.
+type RefError struct {
+	Chain []string
+	Err   error
+}
+
+func (e *RefError) Error() string {
+	quoted := make([]string, 0, len(e.Chain))
+	for _, ref := range e.Chain {
+		quoted = append(quoted, strconv.Quote(ref))
+	}
+	return fmt.Sprintf("resolve $ref %s: %v", strings.Join(quoted, " -> "), e.Err)
+}
+
+func (e *RefError) Unwrap() error {
+	return e.Err
+}
+
+type refFile struct {
+	doc  *Document
+	root *yaml.Node
+}
+
+// refTarget is where a ref points. pointer is the OpenAPI 3 form used to
+// recognize components; lookup is the pointer as written in the file's node
+// tree, which differs for Swagger 2.0 documents (/definitions/...).
+type refTarget struct {
+	file    string
+	pointer string
+	lookup  string
+	display string
+}
+
+// refResolver rewrites a document so every $ref is local: components from
+// other files are imported into the root components under their own name
+// (suffixed on conflict), and JSON-pointer refs to anything other than a
+// component are inlined.
+type refResolver struct {
+	root     *Document
+	rootFile string
+	files    map[string]*refFile
+	imported map[string]string
+	visited  map[interface{}]struct{}
+}
+
+func resolveRefs(doc *Document, root *yaml.Node, path string) error {
+	rootFile := ""
+	if path != "" {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("resolve openapi path %q: %w", path, err)
+		}
+		rootFile = abs
+	}
+	r := &refResolver{
+		root:     doc,
+		rootFile: rootFile,
+		files:    map[string]*refFile{rootFile: {doc: doc, root: root}},
+		imported: map[string]string{},
+		visited:  map[interface{}]struct{}{},
+	}
+	return r.document()
+}
+
+func (r *refResolver) document() error {
+	components := r.root.Components
+	for _, name := range sortedKeys(components.Schemas) {
+		if err := r.schema(components.Schemas[name], r.rootFile, nil); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(components.Parameters) {
+		if err := r.parameter(components.Parameters[name], r.rootFile, nil); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(components.RequestBodies) {
+		if err := r.requestBody(components.RequestBodies[name], r.rootFile, nil); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		if err := r.response(components.Responses[name], r.rootFile, nil); err != nil {
+			return err
+		}
+	}
+	for _, path := range sortedKeys(r.root.Paths) {
+		item := r.root.Paths[path]
+		for _, param := range item.Parameters {
+			if err := r.parameter(param, r.rootFile, nil); err != nil {
+				return err
+			}
+		}
+		for _, op := range []*Operation{item.Get, item.Put, item.Post, item.Delete, item.Patch, item.Options, item.Head, item.Trace} {
+			if err := r.operation(op); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *refResolver) operation(op *Operation) error {
+	if op == nil {
+		return nil
+	}
+	for _, param := range op.Parameters {
+		if err := r.parameter(param, r.rootFile, nil); err != nil {
+			return err
+		}
+	}
+	if err := r.requestBody(op.RequestBody, r.rootFile, nil); err != nil {
+		return err
+	}
+	for _, status := range sortedKeys(op.Responses) {
+		if err := r.response(op.Responses[status], r.rootFile, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *refResolver) schema(schema *Schema, file string, chain []string) error {
+	if !r.markVisited(schema) {
+		return nil
+	}
+	if schema.Ref != "" {
+		local, inline, err := resolveRef(r, schema.Ref, file, chain, "schemas", schemaTable, r.schema)
+		if err != nil {
+			return err
+		}
+		if inline != nil {
+			*schema = *inline
+		} else {
+			schema.Ref = local
+		}
+		return nil
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		if err := r.schema(schema.Properties[name], file, chain); err != nil {
+			return err
+		}
+	}
+	if err := r.schema(schema.Items, file, chain); err != nil {
+		return err
+	}
+	for _, group := range [][]*Schema{schema.AllOf, schema.AnyOf, schema.OneOf, schema.PrefixItems} {
+		for _, item := range group {
+			if err := r.schema(item, file, chain); err != nil {
+				return err
+			}
+		}
+	}
+	if raw, ok := schema.AdditionalProperties.(map[string]interface{}); ok {
+		if ref, ok := raw["$ref"].(string); ok {
+			local, inline, err := resolveRef(r, ref, file, chain, "schemas", schemaTable, r.schema)
+			if err != nil {
+				return err
+			}
+			if inline != nil {
+				return &RefError{Chain: append(chain, r.writtenRef(ref, file)), Err: errors.New("only component refs are supported in additionalProperties")}
+			}
+			raw["$ref"] = local
+		}
+	}
+	return nil
+}
+
+func (r *refResolver) parameter(param *Parameter, file string, chain []string) error {
+	if !r.markVisited(param) {
+		return nil
+	}
+	if param.Ref != "" {
+		local, inline, err := resolveRef(r, param.Ref, file, chain, "parameters", parameterTable, r.parameter)
+		if err != nil {
+			return err
+		}
+		if inline != nil {
+			*param = *inline
+		} else {
+			param.Ref = local
+		}
+		return nil
+	}
+	return r.schema(param.Schema, file, chain)
+}
+
+func (r *refResolver) requestBody(body *RequestBody, file string, chain []string) error {
+	if !r.markVisited(body) {
+		return nil
+	}
+	if body.Ref != "" {
+		local, inline, err := resolveRef(r, body.Ref, file, chain, "requestBodies", requestBodyTable, r.requestBody)
+		if err != nil {
+			return err
+		}
+		if inline != nil {
+			*body = *inline
+		} else {
+			body.Ref = local
+		}
+		return nil
+	}
+	return r.content(body.Content, file, chain)
+}
+
+func (r *refResolver) response(response *Response, file string, chain []string) error {
+	if !r.markVisited(response) {
+		return nil
+	}
+	if response.Ref != "" {
+		local, inline, err := resolveRef(r, response.Ref, file, chain, "responses", responseTable, r.response)
+		if err != nil {
+			return err
+		}
+		if inline != nil {
+			*response = *inline
+		} else {
+			response.Ref = local
+		}
+		return nil
+	}
+	return r.content(response.Content, file, chain)
+}
+
+func (r *refResolver) content(content map[string]*MediaType, file string, chain []string) error {
+	for _, contentType := range sortedKeys(content) {
+		if mediaType := content[contentType]; mediaType != nil {
+			if err := r.schema(mediaType.Schema, file, chain); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// markVisited reports whether value still needs walking.
+func (r *refResolver) markVisited(value interface{}) bool {
+	switch typed := value.(type) {
+	case *Schema:
+		if typed == nil {
+			return false
+		}
+	case *Parameter:
+		if typed == nil {
+			return false
+		}
+	case *RequestBody:
+		if typed == nil {
+			return false
+		}
+	case *Response:
+		if typed == nil {
+			return false
+		}
+	}
+	if _, ok := r.visited[value]; ok {
+		return false
+	}
+	r.visited[value] = struct{}{}
+	return true
+}
+
+// resolveRef returns either the local ref that now points at the target or,
+// for non-component pointers, a decoded copy of the target to inline. The
+// target's own refs are resolved relative to the file it lives in.
+func resolveRef[T any](
+	r *refResolver,
+	ref string,
+	file string,
+	chain []string,
+	kind string,
+	table func(*Document) map[string]*T,
+	visit func(*T, string, []string) error,
+) (string, *T, error) {
+	target, err := r.locate(ref, file)
+	if err != nil {
+		return "", nil, &RefError{Chain: append(chain, r.writtenRef(ref, file)), Err: err}
+	}
+	next := append(append([]string(nil), chain...), target.display)
+
+	if name, ok := componentName(target.pointer, kind); ok {
+		if target.file == r.rootFile {
+			// Missing local components keep their ref, matching ResolveSchema's
+			// fallback; only refs from other files must resolve.
+			if _, exists := table(r.root)[name]; exists || file == r.rootFile {
+				return "#" + target.pointer, nil, nil
+			}
+			return "", nil, &RefError{Chain: next, Err: errRefNotFound}
+		}
+		key := target.file + "#" + target.pointer
+		if local, ok := r.imported[key]; ok {
+			return local, nil, nil
+		}
+		source, err := r.load(target.file)
+		if err != nil {
+			return "", nil, &RefError{Chain: next, Err: err}
+		}
+		value := table(source.doc)[name]
+		if value == nil {
+			return "", nil, &RefError{Chain: next, Err: errRefNotFound}
+		}
+		localName := uniqueComponentName(table(r.root), name)
+		local := "#/components/" + kind + "/" + localName
+		r.imported[key] = local
+		table(r.root)[localName] = value
+		if err := visit(value, target.file, next); err != nil {
+			return "", nil, err
+		}
+		return local, nil, nil
+	}
+
+	for _, seen := range chain {
+		if seen == target.display {
+			return "", nil, &RefError{Chain: next, Err: errRefCircular}
+		}
+	}
+	source, err := r.load(target.file)
+	if err != nil {
+		return "", nil, &RefError{Chain: next, Err: err}
+	}
+	node, ok := lookupPointer(source.root, target.lookup)
+	if !ok {
+		return "", nil, &RefError{Chain: next, Err: errRefNotFound}
+	}
+	value := new(T)
+	if err := node.Decode(value); err != nil {
+		return "", nil, &RefError{Chain: next, Err: err}
+	}
+	if schema, ok := any(value).(*Schema); ok && isSwagger2(source.doc.Swagger) {
+		rewriteSchemaRefs(schema, map[*Schema]struct{}{})
+	}
+	if err := visit(value, target.file, next); err != nil {
+		return "", nil, err
+	}
+	return "", value, nil
+}
+
+func (r *refResolver) locate(ref string, file string) (refTarget, error) {
+	location, fragment, _ := strings.Cut(ref, "#")
+	target := refTarget{file: file}
+	if location != "" {
+		if strings.Contains(location, "://") {
+			return refTarget{}, errors.New("remote refs are not supported")
+		}
+		if r.rootFile == "" {
+			return refTarget{}, errors.New("file refs need a document path; use Load")
+		}
+		location = filepath.FromSlash(location)
+		if !filepath.IsAbs(location) {
+			location = filepath.Join(filepath.Dir(file), location)
+		}
+		target.file = filepath.Clean(location)
+	}
+	pointer, err := url.PathUnescape(fragment)
+	if err != nil {
+		return refTarget{}, fmt.Errorf("invalid JSON pointer: %w", err)
+	}
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return refTarget{}, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	target.pointer = pointer
+	target.lookup = pointer
+	if location == "" {
+		target.lookup = strings.TrimPrefix(r.writtenRef("#"+pointer, file), "#")
+	}
+	target.display = "#" + target.lookup
+	if target.file != r.rootFile {
+		display, err := filepath.Rel(filepath.Dir(r.rootFile), target.file)
+		if err != nil {
+			display = target.file
+		}
+		target.display = filepath.ToSlash(display) + target.display
+	}
+	return target, nil
+}
+
+// writtenRef returns a local ref as it appears in file: Swagger 2.0 refs were
+// rewritten to #/components/... on conversion.
+func (r *refResolver) writtenRef(ref string, file string) string {
+	if loaded, ok := r.files[file]; ok && strings.HasPrefix(ref, "#") && isSwagger2(loaded.doc.Swagger) {
+		return originalSwaggerRef(ref)
+	}
+	return ref
+}
+
+func (r *refResolver) load(file string) (*refFile, error) {
+	if cached, ok := r.files[file]; ok {
+		return cached, nil
+	}
+	doc, root, err := readDocument(file)
+	if err != nil {
+		return nil, err
+	}
+	loaded := &refFile{doc: doc, root: root}
+	r.files[file] = loaded
+	return loaded, nil
+}
+
+// componentName returns the component name when pointer addresses a whole
+// component of the given kind, like /components/schemas/Name.
+func componentName(pointer string, kind string) (string, bool) {
+	name, ok := strings.CutPrefix(pointer, "/components/"+kind+"/")
+	if !ok || name == "" || strings.Contains(name, "/") {
+		return "", false
+	}
+	return pointerTokenReplacer.Replace(name), true
+}
+
+func lookupPointer(root *yaml.Node, pointer string) (*yaml.Node, bool) {
+	node := root
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if pointer == "" {
+		return node, node != nil
+	}
+	for _, token := range strings.Split(pointer[1:], "/") {
+		for node != nil && node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		if node == nil {
+			return nil, false
+		}
+		token = pointerTokenReplacer.Replace(token)
+		switch node.Kind {
+		case yaml.MappingNode:
+			var next *yaml.Node
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					next = node.Content[i+1]
+					break
+				}
+			}
+			node = next
+		case yaml.SequenceNode:
+			index, err := strconv.Atoi(token)
+			if err != nil || index < 0 || index >= len(node.Content) {
+				return nil, false
+			}
+			node = node.Content[index]
+		default:
+			return nil, false
+		}
+	}
+	return node, node != nil
+}
+
+func uniqueComponentName[T any](table map[string]*T, name string) string {
+	if _, exists := table[name]; !exists {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := name + strconv.Itoa(i)
+		if _, exists := table[candidate]; !exists {
+			return candidate
+		}
+	}
+}
+
+func schemaTable(doc *Document) map[string]*Schema {
+	return doc.Components.Schemas
+}
+
+func parameterTable(doc *Document) map[string]*Parameter {
+	return doc.Components.Parameters
+}
+
+func requestBodyTable(doc *Document) map[string]*RequestBody {
+	return doc.Components.RequestBodies
+}
+
+func responseTable(doc *Document) map[string]*Response {
+	return doc.Components.Responses
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadResolvesFileRefs(t *testing.T) {
+	doc, err := Load(filepath.Join("testdata", "refs", "main.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	get, ok := doc.OperationDetails("/v1/users/{user_id}", "get")
+	if !ok {
+		t.Fatal("expected GetUser operation")
+	}
+	if len(get.PathParameters) != 1 || get.PathParameters[0].Name != "user_id" || !get.PathParameters[0].Required {
+		t.Fatalf("expected imported path parameter, got %+v", get.PathParameters)
+	}
+	if len(get.QueryParameters) != 1 {
+		t.Fatalf("expected query parameter, got %+v", get.QueryParameters)
+	}
+	if fields := get.QueryParameters[0].Schema; fields.Ref != "" || fields.Type != "array" || fields.Items.Type != "string" {
+		t.Fatalf("expected nested pointer to be inlined, got %+v", fields)
+	}
+	if name, ok := doc.SchemaName(get.ResponseSchema); !ok || name != "User" {
+		t.Fatalf("expected imported response schema User, got %q %v", name, ok)
+	}
+
+	user := doc.Components.Schemas["User"]
+	if user == nil {
+		t.Fatalf("expected User to be imported, got %v", sortedKeys(doc.Components.Schemas))
+	}
+	if got := user.Properties["team"].Ref; got != "#/components/schemas/Team2" {
+		t.Fatalf("expected shared Team to be imported under a free name, got %q", got)
+	}
+	if got := doc.Components.Schemas["Team2"].Properties["members"].Items.Ref; got != "#/components/schemas/User" {
+		t.Fatalf("expected cyclic component refs to stay by name, got %q", got)
+	}
+
+	post, ok := doc.OperationDetails("/v1/users/{user_id}", "post")
+	if !ok {
+		t.Fatal("expected UpdateUser operation")
+	}
+	body := post.RequestBodySchema.Properties
+	if body["user"].Ref != "#/components/schemas/User" || body["team"].Ref != "#/components/schemas/Team" {
+		t.Fatalf("unexpected body refs: user=%q team=%q", body["user"].Ref, body["team"].Ref)
+	}
+	if name := body["name"]; name.Ref != "" || name.Type != "string" || name.Description != "Local team name." {
+		t.Fatalf("expected local nested pointer to be inlined, got %+v", name)
+	}
+}
+
+func TestLoadReportsUnresolvedRefChain(t *testing.T) {
+	_, err := Load(filepath.Join("testdata", "refs", "broken.yaml"))
+	var refErr *RefError
+	if !errors.As(err, &refErr) || !errors.Is(err, errRefNotFound) {
+		t.Fatalf("expected RefError, got %v", err)
+	}
+	want := `resolve $ref "shared/missing_ref.yaml#/components/schemas/Owner" -> "shared/missing_ref.yaml#/components/schemas/Profile": target not found`
+	if err.Error() != want {
+		t.Fatalf("unexpected error\n got: %s\nwant: %s", err, want)
+	}
+}
+
+func TestParseDetectsCircularPointerRefs(t *testing.T) {
+	_, err := Parse([]byte(`components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        next:
+          $ref: "#/components/schemas/Node/properties/next"
+`))
+	want := `resolve $ref "#/components/schemas/Node/properties/next" -> "#/components/schemas/Node/properties/next": circular reference`
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error\n got: %v\nwant: %s", err, want)
+	}
+}
+
+func TestParseRejectsFileRefs(t *testing.T) {
+	_, err := Parse([]byte(`components:
+  schemas:
+    Bot:
+      $ref: "common.yaml#/components/schemas/Bot"
+`))
+	if err == nil || !strings.Contains(err.Error(), "file refs need a document path") {
+		t.Fatalf("expected file ref error, got %v", err)
+	}
+	if _, err := Parse([]byte("components:\n  schemas:\n    Bot:\n      $ref: '#/components/schemas/Missing'\n")); err != nil {
+		t.Fatalf("expected missing local refs to stay tolerated, got %v", err)
+	}
+}
+
+func TestParseResolvesSwagger2NestedPointers(t *testing.T) {
+	doc, err := Parse([]byte(`swagger: "2.0"
+paths: {}
+definitions:
+  Foo:
+    type: object
+    properties:
+      bar:
+        type: string
+        description: Bar.
+      owner:
+        $ref: "#/definitions/Owner"
+  Owner:
+    type: object
+  Baz:
+    type: object
+    properties:
+      bar:
+        $ref: "#/definitions/Foo/properties/bar"
+      owner:
+        $ref: "#/definitions/Foo/properties/owner"
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	baz := doc.Components.Schemas["Baz"]
+	if bar := baz.Properties["bar"]; bar.Ref != "" || bar.Type != "string" || bar.Description != "Bar." {
+		t.Fatalf("expected nested pointer to be inlined, got %+v", bar)
+	}
+	if got := baz.Properties["owner"].Ref; got != "#/components/schemas/Owner" {
+		t.Fatalf("expected refs inside the inlined schema to be converted, got %q", got)
+	}
+
+	_, err = Parse([]byte(`swagger: "2.0"
+paths: {}
+definitions:
+  Foo:
+    type: object
+    properties:
+      bar:
+        $ref: "#/definitions/Foo/properties/missing"
+`))
+	want := `resolve $ref "#/definitions/Foo/properties/missing": target not found`
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error\n got: %v\nwant: %s", err, want)
+	}
+}
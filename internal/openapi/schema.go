@@ -0,0 +1,304 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UnmarshalYAML accepts OpenAPI 3.0 and 3.1 schemas and normalizes the 3.1
+// spellings (type arrays, "null" variants, const, examples, prefixItems) onto
+// the 3.0 fields the generators read.
+func (s *Schema) UnmarshalYAML(node *yaml.Node) error {
+	var types []string
+	hasTypeList := false
+	if node.Kind == yaml.MappingNode {
+		content := make([]*yaml.Node, 0, len(node.Content))
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			switch {
+			case key.Value == "type" && value.Kind == yaml.SequenceNode:
+				for _, item := range value.Content {
+					if item.Kind != yaml.ScalarNode {
+						return &yaml.TypeError{Errors: []string{
+							fmt.Sprintf("line %d: cannot unmarshal %s into string", item.Line, item.ShortTag()),
+						}}
+					}
+					if item.ShortTag() == "!!null" {
+						types = append(types, "null")
+						continue
+					}
+					types = append(types, item.Value)
+				}
+				hasTypeList = true
+				continue
+			case key.Value == "items" && value.Kind == yaml.ScalarNode && value.Tag == "!!bool":
+				// items: false closes a 3.1 tuple; it carries no schema.
+				continue
+			}
+			content = append(content, key, value)
+		}
+		trimmed := *node
+		trimmed.Content = content
+		node = &trimmed
+	}
+
+	type plainSchema Schema
+	if err := decodeExtensible(node, (*plainSchema)(s), &s.Extensions); err != nil {
+		return err
+	}
+	if hasTypeList {
+		s.applyTypeList(types)
+	} else if s.Type == "null" {
+		s.Type = ""
+		s.Nullable = true
+		s.nullType = true
+	}
+	s.normalizeNullVariants()
+	s.normalizeValues()
+	s.normalizePrefixItems()
+	return nil
+}
+
+// applyTypeList maps `type: [T, "null"]` to Type T plus Nullable. Several
+// non-null types become anyOf variants.
+func (s *Schema) applyTypeList(types []string) {
+	nonNull := make([]string, 0, len(types))
+	for _, typ := range types {
+		if typ == "null" {
+			s.Nullable = true
+			continue
+		}
+		nonNull = append(nonNull, typ)
+	}
+	switch len(nonNull) {
+	case 0:
+		s.Type = ""
+		s.nullType = s.Nullable
+	case 1:
+		s.Type = nonNull[0]
+	default:
+		s.Type = ""
+		for _, typ := range nonNull {
+			variant := &Schema{Type: typ, Format: s.Format}
+			switch typ {
+			case "array":
+				variant.Items = s.Items
+			case "object":
+				variant.Properties = s.Properties
+				variant.Required = s.Required
+				variant.AdditionalProperties = s.AdditionalProperties
+			}
+			s.AnyOf = append(s.AnyOf, variant)
+		}
+	}
+}
+
+// normalizeNullVariants folds `anyOf: [X, {type: "null"}]` into X with
+// Nullable, which is how 3.0 documents spell an optional reference.
+func (s *Schema) normalizeNullVariants() {
+	for _, variants := range []*[]*Schema{&s.AnyOf, &s.OneOf} {
+		kept := make([]*Schema, 0, len(*variants))
+		hasNull := false
+		for _, variant := range *variants {
+			if isNullSchema(variant) {
+				hasNull = true
+				continue
+			}
+			kept = append(kept, variant)
+		}
+		if !hasNull {
+			continue
+		}
+		s.Nullable = true
+		*variants = kept
+		if len(kept) != 1 || s.Type != "" || len(s.Properties) > 0 || s.Items != nil {
+			continue
+		}
+		*variants = nil
+		s.mergeVariant(kept[0])
+	}
+}
+
+func (s *Schema) mergeVariant(variant *Schema) {
+	if variant.Ref != "" {
+		s.Ref = variant.Ref
+		return
+	}
+	description, title := s.Description, s.Title
+	nullable := s.Nullable
+	*s = *variant
+	s.Nullable = s.Nullable || nullable
+	if description != "" {
+		s.Description = description
+	}
+	if title != "" {
+		s.Title = title
+	}
+}
+
+func (s *Schema) normalizeValues() {
+	if s.Const != nil && len(s.Enum) == 0 {
+		s.Enum = []interface{}{s.Const}
+	}
+	if s.Example != nil && len(s.Examples) == 0 {
+		s.Examples = []interface{}{s.Example}
+	}
+}
+
+// normalizePrefixItems gives tuple schemas an items schema: the shared item
+// type when every position agrees, otherwise an anyOf of the positions.
+func (s *Schema) normalizePrefixItems() {
+	if len(s.PrefixItems) == 0 {
+		return
+	}
+	if s.Type == "" && len(s.AnyOf) == 0 {
+		s.Type = "array"
+	}
+	if s.Items != nil {
+		return
+	}
+	first := s.PrefixItems[0]
+	for _, item := range s.PrefixItems[1:] {
+		if !reflect.DeepEqual(item, first) {
+			s.Items = &Schema{AnyOf: append([]*Schema(nil), s.PrefixItems...)}
+			return
+		}
+	}
+	s.Items = first
+}
+
+// isNullSchema matches only an explicit `type: "null"` variant; a bare
+// `nullable: true` schema without a type stays a variant of its own.
+func isNullSchema(schema *Schema) bool {
+	if schema == nil || schema.Ref != "" || !schema.nullType {
+		return false
+	}
+	return len(schema.Properties) == 0 && schema.Items == nil && len(schema.AllOf) == 0 &&
+		len(schema.AnyOf) == 0 && len(schema.OneOf) == 0 && len(schema.Enum) == 0
+}
+
+// IsEmptyObject reports whether the schema is an object that declares nothing:
+// no properties, composition, items, enum or open additionalProperties.
+func (s *Schema) IsEmptyObject() bool {
+	if s == nil {
+		return false
+	}
+	if len(s.Properties) > 0 || len(s.Required) > 0 {
+		return false
+	}
+	if s.Items != nil || len(s.AllOf) > 0 || len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		return false
+	}
+	if len(s.Enum) > 0 {
+		return false
+	}
+	if s.AdditionalProperties != nil {
+		if allow, ok := s.AdditionalProperties.(bool); !ok || allow {
+			return false
+		}
+	}
+	schemaType := strings.ToLower(strings.TrimSpace(s.Type))
+	return schemaType == "" || schemaType == "object"
+}
+
+// EffectiveSchema resolves schema and merges its allOf members, so composed
+// schemas expose every inherited property. Members merge in order and the
+// schema's own fields win; required lists are unioned. Schemas without allOf
+// are returned as resolved, keeping their identity for SchemaName.
+func (d *Document) EffectiveSchema(schema *Schema) *Schema {
+	return d.effectiveSchema(schema, map[*Schema]struct{}{})
+}
+
+func (d *Document) effectiveSchema(schema *Schema, visiting map[*Schema]struct{}) *Schema {
+	resolved := d.ResolveSchema(schema)
+	if resolved == nil || len(resolved.AllOf) == 0 {
+		return resolved
+	}
+	own := *resolved
+	own.AllOf = nil
+	if _, ok := visiting[resolved]; ok {
+		return &own
+	}
+	visiting[resolved] = struct{}{}
+	defer delete(visiting, resolved)
+
+	merged := &Schema{}
+	for _, member := range resolved.AllOf {
+		mergeSchema(merged, d.effectiveSchema(member, visiting))
+	}
+	mergeSchema(merged, &own)
+	if merged.Type == "" && len(merged.Properties) > 0 {
+		merged.Type = "object"
+	}
+	return merged
+}
+
+func mergeSchema(dst *Schema, src *Schema) {
+	if src == nil {
+		return
+	}
+	if src.Type != "" {
+		dst.Type = src.Type
+	}
+	if src.Format != "" {
+		dst.Format = src.Format
+	}
+	if src.Title != "" {
+		dst.Title = src.Title
+	}
+	if src.Description != "" {
+		dst.Description = src.Description
+	}
+	dst.Nullable = dst.Nullable || src.Nullable
+	if len(src.Enum) > 0 {
+		dst.Enum = src.Enum
+	}
+	for _, name := range src.Required {
+		if !containsString(dst.Required, name) {
+			dst.Required = append(dst.Required, name)
+		}
+	}
+	if len(src.Properties) > 0 {
+		if dst.Properties == nil {
+			dst.Properties = make(map[string]*Schema, len(src.Properties))
+		}
+		for name, property := range src.Properties {
+			dst.Properties[name] = property
+		}
+	}
+	if src.Items != nil {
+		dst.Items = src.Items
+	}
+	if src.AdditionalProperties != nil {
+		dst.AdditionalProperties = src.AdditionalProperties
+	}
+	dst.OneOf = append(dst.OneOf, src.OneOf...)
+	dst.AnyOf = append(dst.AnyOf, src.AnyOf...)
+	if src.Const != nil {
+		dst.Const = src.Const
+	}
+	if src.Example != nil {
+		dst.Example = src.Example
+	}
+	if len(src.Examples) > 0 {
+		dst.Examples = src.Examples
+	}
+	if len(src.PrefixItems) > 0 {
+		dst.PrefixItems = src.PrefixItems
+	}
+	if src.Discriminator != nil {
+		dst.Discriminator = src.Discriminator
+	}
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
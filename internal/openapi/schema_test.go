@@ -0,0 +1,176 @@
+package openapi
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadOpenAPI31NormalizesSchemas(t *testing.T) {
+	doc, err := Load(filepath.Join("testdata", "openapi31_fragment.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	bot := doc.Components.Schemas["Bot"]
+	if bot == nil {
+		t.Fatal("expected Bot schema")
+	}
+	props := bot.Properties
+
+	if got := props["description"]; got.Type != "string" || !got.Nullable {
+		t.Fatalf("expected nullable string, got %+v", got)
+	}
+	if got := props["version"]; got.Type != "integer" || got.Format != "int64" || !got.Nullable {
+		t.Fatalf("expected nullable int64, got %+v", got)
+	}
+	if got := props["kind"]; got.Const != "bot" || !reflect.DeepEqual(got.Enum, []interface{}{"bot"}) {
+		t.Fatalf("expected const to become a single-value enum, got %+v", got)
+	}
+	if got := props["bot_id"].Examples; !reflect.DeepEqual(got, []interface{}{"7351"}) {
+		t.Fatalf("unexpected examples: %#v", got)
+	}
+	if got := doc.Components.Schemas["User"].Properties["name"].Examples; !reflect.DeepEqual(got, []interface{}{"coze"}) {
+		t.Fatalf("expected 3.0 example to populate examples, got %#v", got)
+	}
+
+	owner := props["owner"]
+	if owner.Ref != "#/components/schemas/User" || !owner.Nullable || len(owner.AnyOf) != 0 || owner.Description != "Bot owner." {
+		t.Fatalf("expected nullable anyOf to fold into a ref, got %+v", owner)
+	}
+	if name, ok := doc.SchemaName(doc.ResolveSchema(owner)); !ok || name != "User" {
+		t.Fatalf("expected owner to resolve to User, got %q %v", name, ok)
+	}
+
+	score := props["score"]
+	if score.Type != "" || len(score.AnyOf) != 2 || score.AnyOf[0].Type != "string" || score.AnyOf[1].Type != "number" {
+		t.Fatalf("expected multi-type schema to become anyOf, got %+v", score)
+	}
+
+	coordinates := props["coordinates"]
+	if coordinates.Type != "array" || coordinates.Items == nil || coordinates.Items.Type != "number" {
+		t.Fatalf("expected homogeneous tuple to expose items, got %+v", coordinates)
+	}
+	entry := props["entry"]
+	if entry.Type != "array" || entry.Items == nil || len(entry.Items.AnyOf) != 2 {
+		t.Fatalf("expected mixed tuple to expose anyOf items, got %+v", entry)
+	}
+}
+
+func TestOpenAPI31JSONMatchesYAML(t *testing.T) {
+	fromYAML, err := Parse([]byte("components:\n  schemas:\n    Name:\n      type: [string, 'null']\n      const: coze\n"))
+	if err != nil {
+		t.Fatalf("Parse(yaml) error = %v", err)
+	}
+	fromJSON, err := Parse([]byte(`{"components": {"schemas": {"Name": {"type": ["string", null], "const": "coze"}}}}`))
+	if err != nil {
+		t.Fatalf("Parse(json) error = %v", err)
+	}
+	if !reflect.DeepEqual(fromYAML.Components.Schemas, fromJSON.Components.Schemas) {
+		t.Fatalf("expected identical schemas\nyaml=%+v\njson=%+v", fromYAML.Components.Schemas["Name"], fromJSON.Components.Schemas["Name"])
+	}
+	name := fromJSON.Components.Schemas["Name"]
+	if name.Type != "string" || !name.Nullable || len(name.Enum) != 1 {
+		t.Fatalf("unexpected normalized schema: %+v", name)
+	}
+}
+
+func TestSchemaTypeListErrorsKeepPosition(t *testing.T) {
+	_, err := ParseFormat([]byte("components:\n  schemas:\n    Bad:\n      type: [string, {a: 1}]\n"), FormatYAML)
+	if err == nil || err.Error() != "parse openapi yaml: line 4, column 7: cannot unmarshal !!map into string" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestEffectiveSchemaMergesAllOf(t *testing.T) {
+	doc, err := Parse([]byte(`components:
+  schemas:
+    Entity:
+      type: object
+      description: Base entity.
+      required: [id]
+      properties:
+        id:
+          type: string
+        created_at:
+          type: integer
+    Named:
+      allOf:
+        - $ref: "#/components/schemas/Entity"
+        - type: object
+          required: [name, id]
+          properties:
+            name:
+              type: string
+    Bot:
+      description: A bot.
+      allOf:
+        - $ref: "#/components/schemas/Named"
+      properties:
+        created_at:
+          type: string
+          format: date-time
+    Loop:
+      allOf:
+        - $ref: "#/components/schemas/Loop"
+      properties:
+        next:
+          type: string
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	bot := doc.EffectiveSchema(&Schema{Ref: "#/components/schemas/Bot"})
+	if bot.Type != "object" || bot.Description != "A bot." || len(bot.AllOf) != 0 {
+		t.Fatalf("unexpected merged schema: %+v", bot)
+	}
+	if !reflect.DeepEqual(sortedKeys(bot.Properties), []string{"created_at", "id", "name"}) {
+		t.Fatalf("unexpected merged properties: %v", sortedKeys(bot.Properties))
+	}
+	if got := bot.Properties["created_at"].Format; got != "date-time" {
+		t.Fatalf("expected own property to override inherited one, got format %q", got)
+	}
+	if !reflect.DeepEqual(bot.Required, []string{"id", "name"}) {
+		t.Fatalf("unexpected merged required: %v", bot.Required)
+	}
+
+	entity := doc.Components.Schemas["Entity"]
+	if doc.EffectiveSchema(entity) != entity {
+		t.Fatal("expected schemas without allOf to keep their identity")
+	}
+	if loop := doc.EffectiveSchema(doc.Components.Schemas["Loop"]); len(loop.Properties) != 1 {
+		t.Fatalf("expected self-referencing allOf to terminate, got %+v", loop)
+	}
+}
+
+func TestNullVariantsRequireExplicitNullType(t *testing.T) {
+	doc, err := Parse([]byte(`components:
+  schemas:
+    Folded:
+      anyOf:
+        - $ref: '#/components/schemas/User'
+        - type: 'null'
+    Listed:
+      oneOf:
+        - type: string
+        - type: ['null']
+    Kept:
+      anyOf:
+        - $ref: '#/components/schemas/User'
+        - nullable: true
+    User:
+      type: object
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	schemas := doc.Components.Schemas
+	if got := schemas["Folded"]; got.Ref != "#/components/schemas/User" || !got.Nullable || len(got.AnyOf) != 0 {
+		t.Fatalf("expected type null variant to fold, got %+v", got)
+	}
+	if got := schemas["Listed"]; got.Type != "string" || !got.Nullable || len(got.OneOf) != 0 {
+		t.Fatalf("expected null-only type list variant to fold, got %+v", got)
+	}
+	if got := schemas["Kept"]; got.Nullable || len(got.AnyOf) != 2 || !got.AnyOf[1].Nullable {
+		t.Fatalf("expected untyped nullable variant to be kept, got %+v", got)
+	}
+}
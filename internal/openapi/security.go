@@ -0,0 +1,53 @@
+package openapi
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Server struct {
+	URL         string     `yaml:"url"`
+	Description string     `yaml:"description"`
+	Extensions  Extensions `yaml:"-"`
+}
+
+// SecurityRequirement maps security scheme names to required scopes. An empty
+// requirement allows anonymous access.
+type SecurityRequirement map[string][]string
+
+type SecurityScheme struct {
+	Type         string `yaml:"type"`
+	Description  string `yaml:"description"`
+	Name         string `yaml:"name"`
+	In           string `yaml:"in"`
+	Scheme       string `yaml:"scheme"`
+	BearerFormat string `yaml:"bearerFormat"`
+}
+
+func (s *Server) UnmarshalYAML(node *yaml.Node) error {
+	type plainServer Server
+	return decodeExtensible(node, (*plainServer)(s), &s.Extensions)
+}
+
+// Region returns the x-coze-region extension of the server, or "" when the
+// server does not declare one.
+func (s Server) Region() string {
+	region, _ := s.Extensions.String("x-coze-region")
+	return strings.TrimSpace(region)
+}
+
+// RequiresAuth reports whether the operation needs credentials. Operations
+// inherit the document security; `security: []` or only empty requirements
+// mean anonymous access. Undeclared security is assumed to need auth.
+func (d OperationDetails) RequiresAuth() bool {
+	if d.Security == nil {
+		return true
+	}
+	for _, requirement := range d.Security {
+		if len(requirement) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"testing"
+)
+
+func TestParseServersAndSecurity(t *testing.T) {
+	yamlDoc := `openapi: 3.0.0
+servers:
+  - url: https://api.coze.com
+  - url: https://api.coze.cn
+    x-coze-region: china
+security:
+  - bearer: []
+paths:
+  /v1/bots:
+    get:
+      responses:
+        '200':
+          description: ok
+  /api/permission/oauth2/token:
+    post:
+      security: []
+      responses:
+        '200':
+          description: ok
+components:
+  securitySchemes:
+    bearer:
+      type: http
+      scheme: bearer
+`
+	jsonDoc := `{"swagger": "2.0", "host": "api.coze.com", "basePath": "/", "schemes": ["https"],
+  "security": [{"bearer": []}],
+  "securityDefinitions": {"bearer": {"type": "apiKey", "name": "Authorization", "in": "header"}},
+  "paths": {
+    "/v1/bots": {"get": {"responses": {"200": {"description": "ok"}}}},
+    "/api/permission/oauth2/token": {"post": {"security": [], "responses": {"200": {"description": "ok"}}}}}}`
+
+	for name, content := range map[string]string{"openapi3 yaml": yamlDoc, "swagger2 json": jsonDoc} {
+		t.Run(name, func(t *testing.T) {
+			doc, err := Parse([]byte(content))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if len(doc.Servers) == 0 || doc.Servers[0].URL != "https://api.coze.com" {
+				t.Fatalf("unexpected servers: %+v", doc.Servers)
+			}
+			if doc.Components.SecuritySchemes["bearer"] == nil {
+				t.Fatalf("expected bearer security scheme, got %+v", doc.Components.SecuritySchemes)
+			}
+
+			bots, ok := doc.OperationDetails("/v1/bots", "get")
+			if !ok {
+				t.Fatal("expected bots operation")
+			}
+			if !bots.RequiresAuth() {
+				t.Fatalf("expected document security to apply, got %+v", bots.Security)
+			}
+			token, ok := doc.OperationDetails("/api/permission/oauth2/token", "post")
+			if !ok {
+				t.Fatal("expected token operation")
+			}
+			if token.RequiresAuth() {
+				t.Fatalf("expected `security: []` to allow anonymous access, got %+v", token.Security)
+			}
+		})
+	}
+}
+
+func TestServerRegion(t *testing.T) {
+	cases := map[string]Server{
+		"":      {URL: "https://api.coze.cn/"},
+		"china": {URL: "https://api.coze.cn", Extensions: Extensions{"x-coze-region": " china "}},
+	}
+	for want, server := range cases {
+		if got := server.Region(); got != want {
+			t.Fatalf("Region(%q) = %q, want %q", server.URL, got, want)
+		}
+	}
+}
+
+func TestRequiresAuthWithoutSecurity(t *testing.T) {
+	if !(OperationDetails{}).RequiresAuth() {
+		t.Fatal("expected undeclared security to require auth")
+	}
+	anonymous := OperationDetails{Security: []SecurityRequirement{{}}}
+	if anonymous.RequiresAuth() {
+		t.Fatal("expected an empty requirement to allow anonymous access")
+	}
+}
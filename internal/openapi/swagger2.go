@@ -0,0 +1,437 @@
+package openapi
+
+import (
+	"strings"
+)
+
+const (
+	contentTypeJSON      = "application/json"
+	contentTypeMultipart = "multipart/form-data"
+	contentTypeForm      = "application/x-www-form-urlencoded"
+)
+
+var swaggerRefPrefixes = [][2]string{
+	{"#/definitions/", "#/components/schemas/"},
+	{"#/parameters/", "#/components/parameters/"},
+	{"#/responses/", "#/components/responses/"},
+}
+
+// swaggerDocument holds the Swagger 2.0 fields that have no direct OpenAPI 3
+// counterpart; convert maps them onto Document.
+type swaggerDocument struct {
+	Swagger             string                            `yaml:"swagger"`
+	Host                string                            `yaml:"host"`
+	BasePath            string                            `yaml:"basePath"`
+	Schemes             []string                          `yaml:"schemes"`
+	Consumes            []string                          `yaml:"consumes"`
+	Produces            []string                          `yaml:"produces"`
+	Security            []SecurityRequirement             `yaml:"security"`
+	SecurityDefinitions map[string]*swaggerSecurityScheme `yaml:"securityDefinitions"`
+	Paths               map[string]swaggerPathItem        `yaml:"paths"`
+	Definitions         map[string]*Schema                `yaml:"definitions"`
+	Parameters          map[string]*swaggerParameter      `yaml:"parameters"`
+	Responses           map[string]*swaggerResponse       `yaml:"responses"`
+}
+
+type swaggerPathItem struct {
+	Parameters []*swaggerParameter `yaml:"parameters"`
+	Get        *swaggerOperation   `yaml:"get"`
+	Put        *swaggerOperation   `yaml:"put"`
+	Post       *swaggerOperation   `yaml:"post"`
+	Delete     *swaggerOperation   `yaml:"delete"`
+	Patch      *swaggerOperation   `yaml:"patch"`
+	Options    *swaggerOperation   `yaml:"options"`
+	Head       *swaggerOperation   `yaml:"head"`
+}
+
+type swaggerOperation struct {
+	OperationID string                      `yaml:"operationId"`
+	Summary     string                      `yaml:"summary"`
+	Description string                      `yaml:"description"`
+	Tags        []string                    `yaml:"tags"`
+	Deprecated  bool                        `yaml:"deprecated"`
+	Consumes    []string                    `yaml:"consumes"`
+	Produces    []string                    `yaml:"produces"`
+	Parameters  []*swaggerParameter         `yaml:"parameters"`
+	Responses   map[string]*swaggerResponse `yaml:"responses"`
+	Security    []SecurityRequirement       `yaml:"security"`
+	Extensions  Extensions                  `yaml:"-"`
+}
+
+type swaggerParameter struct {
+	Ref         string        `yaml:"$ref"`
+	Name        string        `yaml:"name"`
+	In          string        `yaml:"in"`
+	Description string        `yaml:"description"`
+	Required    bool          `yaml:"required"`
+	Schema      *Schema       `yaml:"schema"`
+	Type        string        `yaml:"type"`
+	Format      string        `yaml:"format"`
+	Items       *Schema       `yaml:"items"`
+	Enum        []interface{} `yaml:"enum"`
+	Extensions  Extensions    `yaml:"-"`
+}
+
+type swaggerSecurityScheme struct {
+	Type        string `yaml:"type"`
+	Description string `yaml:"description"`
+	Name        string `yaml:"name"`
+	In          string `yaml:"in"`
+}
+
+type swaggerResponse struct {
+	Ref         string  `yaml:"$ref"`
+	Description string  `yaml:"description"`
+	Schema      *Schema `yaml:"schema"`
+}
+
+func isSwagger2(version string) bool {
+	return strings.HasPrefix(strings.TrimSpace(version), "2")
+}
+
+func (s *swaggerDocument) convert() Document {
+	doc := Document{
+		Swagger:  s.Swagger,
+		Servers:  s.servers(),
+		Security: s.Security,
+		Paths:    map[string]PathItem{},
+		Components: Components{
+			Schemas:         map[string]*Schema{},
+			Parameters:      map[string]*Parameter{},
+			Responses:       map[string]*Response{},
+			SecuritySchemes: map[string]*SecurityScheme{},
+		},
+	}
+	for name, scheme := range s.SecurityDefinitions {
+		if scheme != nil {
+			doc.Components.SecuritySchemes[name] = scheme.convert()
+		}
+	}
+	seen := map[*Schema]struct{}{}
+	for name, schema := range s.Definitions {
+		rewriteSchemaRefs(schema, seen)
+		doc.Components.Schemas[name] = schema
+	}
+	for name, param := range s.Parameters {
+		if param == nil || isSwaggerBodyParameter(param) {
+			// Body and formData parameters are inlined into request bodies.
+			continue
+		}
+		doc.Components.Parameters[name] = s.convertParameter(param, seen)
+	}
+	for name, response := range s.Responses {
+		doc.Components.Responses[name] = s.convertResponse(response, s.Produces, seen)
+	}
+
+	for path, item := range s.Paths {
+		converted := PathItem{}
+		for _, param := range item.Parameters {
+			if resolved := s.resolveParameter(param); resolved != nil && !isSwaggerBodyParameter(resolved) {
+				converted.Parameters = append(converted.Parameters, s.convertParameter(param, seen))
+			}
+		}
+		convertOp := func(op *swaggerOperation) *Operation {
+			if op == nil {
+				return nil
+			}
+			return s.convertOperation(op, item.Parameters, seen)
+		}
+		converted.Get = convertOp(item.Get)
+		converted.Put = convertOp(item.Put)
+		converted.Post = convertOp(item.Post)
+		converted.Delete = convertOp(item.Delete)
+		converted.Patch = convertOp(item.Patch)
+		converted.Options = convertOp(item.Options)
+		converted.Head = convertOp(item.Head)
+		doc.Paths[path] = converted
+	}
+	return doc
+}
+
+func (s *swaggerDocument) convertOperation(op *swaggerOperation, pathParams []*swaggerParameter, seen map[*Schema]struct{}) *Operation {
+	converted := &Operation{
+		OperationID: op.OperationID,
+		Summary:     op.Summary,
+		Description: op.Description,
+		Tags:        op.Tags,
+		Deprecated:  op.Deprecated,
+		Security:    op.Security,
+		Extensions:  op.Extensions,
+	}
+
+	// Operation parameters override path parameters with the same location
+	// and name.
+	overridden := map[string]struct{}{}
+	for _, param := range op.Parameters {
+		if resolved := s.resolveParameter(param); resolved != nil {
+			overridden[resolved.In+":"+resolved.Name] = struct{}{}
+		}
+	}
+	var body *swaggerParameter
+	var formData []*swaggerParameter
+	collect := func(param *swaggerParameter, inherited bool) {
+		resolved := s.resolveParameter(param)
+		if resolved == nil {
+			return
+		}
+		if inherited {
+			if _, ok := overridden[resolved.In+":"+resolved.Name]; ok {
+				return
+			}
+		}
+		switch resolved.In {
+		case "body":
+			body = resolved
+		case "formData":
+			formData = append(formData, resolved)
+		default:
+			if !inherited {
+				converted.Parameters = append(converted.Parameters, s.convertParameter(param, seen))
+			}
+		}
+	}
+	for _, param := range pathParams {
+		collect(param, true)
+	}
+	for _, param := range op.Parameters {
+		collect(param, false)
+	}
+
+	consumes := firstNonEmpty(op.Consumes, s.Consumes)
+	switch {
+	case body != nil:
+		rewriteSchemaRefs(body.Schema, seen)
+		converted.RequestBody = &RequestBody{
+			Description: body.Description,
+			Required:    body.Required,
+			Content:     mediaTypes(bodyContentTypes(consumes), body.Schema),
+		}
+	case len(formData) > 0:
+		converted.RequestBody = s.convertFormData(formData, consumes)
+	}
+
+	produces := firstNonEmpty(op.Produces, s.Produces)
+	if len(op.Responses) > 0 {
+		converted.Responses = make(map[string]*Response, len(op.Responses))
+		for status, response := range op.Responses {
+			converted.Responses[status] = s.convertResponse(response, produces, seen)
+		}
+	}
+	return converted
+}
+
+func (s *swaggerDocument) convertFormData(params []*swaggerParameter, consumes []string) *RequestBody {
+	contentType := ""
+	for _, candidate := range consumes {
+		if candidate == contentTypeMultipart || candidate == contentTypeForm {
+			contentType = candidate
+			break
+		}
+	}
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	required := false
+	for _, param := range params {
+		property := swaggerParameterSchema(param)
+		if param.Type == "file" {
+			property = &Schema{Type: "string", Format: "binary", Description: param.Description}
+			if contentType == "" {
+				contentType = contentTypeMultipart
+			}
+		}
+		schema.Properties[param.Name] = property
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+			required = true
+		}
+	}
+	if contentType == "" {
+		contentType = contentTypeForm
+	}
+	return &RequestBody{Required: required, Content: mediaTypes([]string{contentType}, schema)}
+}
+
+func (s *swaggerDocument) convertParameter(param *swaggerParameter, seen map[*Schema]struct{}) *Parameter {
+	if param.Ref != "" {
+		return &Parameter{Ref: convertSwaggerRef(param.Ref)}
+	}
+	schema := param.Schema
+	if schema == nil {
+		schema = swaggerParameterSchema(param)
+	}
+	rewriteSchemaRefs(schema, seen)
+	return &Parameter{
+		Name:        param.Name,
+		In:          param.In,
+		Description: param.Description,
+		Required:    param.Required,
+		Schema:      schema,
+		Extensions:  param.Extensions,
+	}
+}
+
+func (s *swaggerDocument) convertResponse(response *swaggerResponse, produces []string, seen map[*Schema]struct{}) *Response {
+	if response == nil {
+		return nil
+	}
+	if response.Ref != "" {
+		return &Response{Ref: convertSwaggerRef(response.Ref)}
+	}
+	converted := &Response{Description: response.Description}
+	if response.Schema != nil {
+		rewriteSchemaRefs(response.Schema, seen)
+		if len(produces) == 0 {
+			produces = []string{contentTypeJSON}
+		}
+		converted.Content = mediaTypes(produces, response.Schema)
+	}
+	return converted
+}
+
+// resolveParameter follows a #/parameters/ reference so callers can see
+// where the parameter lives.
+func (s *swaggerDocument) resolveParameter(param *swaggerParameter) *swaggerParameter {
+	if param == nil || param.Ref == "" {
+		return param
+	}
+	name, ok := refName(param.Ref, "#/parameters/")
+	if !ok {
+		return param
+	}
+	resolved, ok := s.Parameters[name]
+	if !ok || resolved == nil {
+		return nil
+	}
+	return resolved
+}
+
+// servers joins host and basePath for every scheme; https is assumed when
+// none is listed.
+func (s *swaggerDocument) servers() []Server {
+	host := strings.TrimSpace(s.Host)
+	if host == "" {
+		return nil
+	}
+	schemes := s.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+	basePath := strings.TrimRight(strings.TrimSpace(s.BasePath), "/")
+	servers := make([]Server, 0, len(schemes))
+	for _, scheme := range schemes {
+		servers = append(servers, Server{URL: scheme + "://" + host + basePath})
+	}
+	return servers
+}
+
+func (s *swaggerSecurityScheme) convert() *SecurityScheme {
+	converted := &SecurityScheme{Type: s.Type, Description: s.Description, Name: s.Name, In: s.In}
+	if s.Type == "basic" {
+		converted.Type = "http"
+		converted.Scheme = "basic"
+	}
+	return converted
+}
+
+func isSwaggerBodyParameter(param *swaggerParameter) bool {
+	return param.In == "body" || param.In == "formData"
+}
+
+func swaggerParameterSchema(param *swaggerParameter) *Schema {
+	return &Schema{
+		Type:        param.Type,
+		Format:      param.Format,
+		Description: param.Description,
+		Items:       param.Items,
+		Enum:        param.Enum,
+	}
+}
+
+func bodyContentTypes(consumes []string) []string {
+	types := make([]string, 0, len(consumes))
+	for _, contentType := range consumes {
+		if contentType == contentTypeMultipart || contentType == contentTypeForm {
+			continue
+		}
+		types = append(types, contentType)
+	}
+	if len(types) == 0 {
+		return []string{contentTypeJSON}
+	}
+	return types
+}
+
+func mediaTypes(contentTypes []string, schema *Schema) map[string]*MediaType {
+	content := make(map[string]*MediaType, len(contentTypes))
+	for _, contentType := range contentTypes {
+		content[contentType] = &MediaType{Schema: schema}
+	}
+	return content
+}
+
+func firstNonEmpty(values ...[]string) []string {
+	for _, value := range values {
+		if len(value) > 0 {
+			return value
+		}
+	}
+	return nil
+}
+
+func convertSwaggerRef(ref string) string {
+	for _, prefix := range swaggerRefPrefixes {
+		if strings.HasPrefix(ref, prefix[0]) {
+			return prefix[1] + strings.TrimPrefix(ref, prefix[0])
+		}
+	}
+	return ref
+}
+
+// originalSwaggerRef undoes convertSwaggerRef.
+func originalSwaggerRef(ref string) string {
+	for _, prefix := range swaggerRefPrefixes {
+		if strings.HasPrefix(ref, prefix[1]) {
+			return prefix[0] + strings.TrimPrefix(ref, prefix[1])
+		}
+	}
+	return ref
+}
+
+func rewriteSchemaRefs(schema *Schema, seen map[*Schema]struct{}) {
+	if schema == nil {
+		return
+	}
+	if _, ok := seen[schema]; ok {
+		return
+	}
+	seen[schema] = struct{}{}
+	schema.Ref = convertSwaggerRef(schema.Ref)
+	for _, property := range schema.Properties {
+		rewriteSchemaRefs(property, seen)
+	}
+	rewriteSchemaRefs(schema.Items, seen)
+	for _, group := range [][]*Schema{schema.AllOf, schema.AnyOf, schema.OneOf, schema.PrefixItems} {
+		for _, item := range group {
+			rewriteSchemaRefs(item, seen)
+		}
+	}
+	rewriteRawRefs(schema.AdditionalProperties)
+}
+
+// rewriteRawRefs updates $ref values inside schemas kept as raw maps, such as
+// additionalProperties.
+func rewriteRawRefs(value interface{}) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				typed[key] = convertSwaggerRef(ref)
+				continue
+			}
+			rewriteRawRefs(item)
+		}
+	case []interface{}:
+		for _, item := range typed {
+			rewriteRawRefs(item)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package openapi
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func loadSwagger2Fixture(t *testing.T) *Document {
+	t.Helper()
+	doc, err := Load(filepath.Join("testdata", "swagger2_fragment.yaml"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return doc
+}
+
+func TestSwagger2ConvertsDefinitionsAndBodies(t *testing.T) {
+	doc := loadSwagger2Fixture(t)
+	if doc.Swagger != "2.0" {
+		t.Fatalf("expected swagger version to be kept, got %q", doc.Swagger)
+	}
+	if _, ok := doc.Components.Schemas["Bot"]; !ok {
+		t.Fatalf("expected definitions to become component schemas, got %#v", doc.Components.Schemas)
+	}
+	if got := doc.Components.Schemas["Bot"].Properties["owner"].Ref; got != "#/components/schemas/User" {
+		t.Fatalf("expected definition refs to be rewritten, got %q", got)
+	}
+	labels := doc.Components.Schemas["Bot"].Properties["labels"].AdditionalProperties
+	if got := labels.(map[string]interface{})["$ref"]; got != "#/components/schemas/User" {
+		t.Fatalf("expected additionalProperties refs to be rewritten, got %#v", got)
+	}
+
+	update, ok := doc.OperationDetails("/v1/bots/{bot_id}", "post")
+	if !ok {
+		t.Fatal("expected UpdateBot operation")
+	}
+	if update.RequestBodyContentType != "application/json" || update.RequestBody == nil || !update.RequestBody.Required {
+		t.Fatalf("expected required JSON request body, got %+v", update.RequestBody)
+	}
+	if name, ok := doc.SchemaName(update.RequestBodySchema); !ok || name != "UpdateBotReq" {
+		t.Fatalf("expected body schema UpdateBotReq, got %q %v", name, ok)
+	}
+	if name, ok := doc.SchemaName(update.ResponseSchema); !ok || name != "Bot" {
+		t.Fatalf("expected response schema Bot, got %q %v", name, ok)
+	}
+	if len(update.PathParameters) != 1 || update.PathParameters[0].Name != "bot_id" || update.PathParameters[0].Schema.Type != "string" {
+		t.Fatalf("expected path parameter from #/parameters ref, got %+v", update.PathParameters)
+	}
+	if got := doc.CollectSchemaRefsFromOperation(*update); !reflect.DeepEqual(got, []string{"Bot", "UpdateBotReq"}) {
+		t.Fatalf("unexpected schema refs: %#v", got)
+	}
+
+	get, ok := doc.OperationDetails("/v1/bots/{bot_id}", "get")
+	if !ok {
+		t.Fatal("expected GetBot operation")
+	}
+	if get.RequestBody != nil {
+		t.Fatalf("expected no request body for GetBot, got %+v", get.RequestBody)
+	}
+	if len(get.QueryParameters) != 1 || get.QueryParameters[0].Schema.Type != "boolean" {
+		t.Fatalf("expected typed query parameter, got %+v", get.QueryParameters)
+	}
+	if name, ok := doc.SchemaName(get.ResponseSchema); !ok || name != "Bot" {
+		t.Fatalf("expected #/responses ref to resolve to Bot, got %q %v", name, ok)
+	}
+}
+
+func TestSwagger2ConvertsFormDataAndProduces(t *testing.T) {
+	doc := loadSwagger2Fixture(t)
+
+	upload, ok := doc.OperationDetails("/v1/files/upload", "post")
+	if !ok {
+		t.Fatal("expected UploadFile operation")
+	}
+	if upload.RequestBodyContentType != "multipart/form-data" {
+		t.Fatalf("expected multipart body, got %q", upload.RequestBodyContentType)
+	}
+	body := upload.RequestBodySchema
+	if body.Type != "object" || !reflect.DeepEqual(body.Required, []string{"file"}) {
+		t.Fatalf("unexpected form schema: %+v", body)
+	}
+	if file := body.Properties["file"]; file.Type != "string" || file.Format != "binary" {
+		t.Fatalf("expected file field to become binary string, got %+v", file)
+	}
+	if purpose := body.Properties["purpose"]; purpose.Type != "string" {
+		t.Fatalf("unexpected purpose field: %+v", purpose)
+	}
+
+	tags, ok := doc.OperationDetails("/v1/tags", "get")
+	if !ok {
+		t.Fatal("expected ListTags operation")
+	}
+	if tags.ResponseContentType != "text/event-stream" {
+		t.Fatalf("expected operation produces to win, got %q", tags.ResponseContentType)
+	}
+	if ids := tags.QueryParameters[0].Schema; ids.Type != "array" || ids.Items == nil || ids.Items.Type != "string" {
+		t.Fatalf("expected array query parameter, got %+v", ids)
+	}
+}
+
+func TestSwagger2FormDataDefaultsToURLEncoded(t *testing.T) {
+	doc, err := Parse([]byte(`swagger: "2.0"
+paths:
+  /v1/login:
+    post:
+      parameters:
+        - {name: user, in: formData, type: string}
+      responses:
+        "200": {description: ok}
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	details, ok := doc.OperationDetails("/v1/login", "post")
+	if !ok || details.RequestBodyContentType != "application/x-www-form-urlencoded" || details.RequestBody.Required {
+		t.Fatalf("unexpected form request body: %+v", details)
+	}
+}
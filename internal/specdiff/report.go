@@ -0,0 +1,50 @@
+package specdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Report struct {
+	Breaking    int      `json:"breaking"`
+	NonBreaking int      `json:"non_breaking"`
+	Changes     []Change `json:"changes"`
+}
+
+func NewReport(changes []Change) Report {
+	report := Report{Changes: changes}
+	if report.Changes == nil {
+		report.Changes = []Change{}
+	}
+	for _, change := range changes {
+		if change.Breaking {
+			report.Breaking++
+		} else {
+			report.NonBreaking++
+		}
+	}
+	return report
+}
+
+func (r Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("encode spec diff report: %w", err)
+	}
+	return nil
+}
+
+// WriteText prints one line per change followed by the totals.
+func (r Report) WriteText(w io.Writer) error {
+	var buf strings.Builder
+	for _, change := range r.Changes {
+		buf.WriteString(change.String())
+		buf.WriteString("\n")
+	}
+	buf.WriteString(fmt.Sprintf("breaking=%d non_breaking=%d\n", r.Breaking, r.NonBreaking))
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
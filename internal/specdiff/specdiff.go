@@ -0,0 +1,342 @@
+// Package specdiff compares two versions of an OpenAPI document and classifies
+// the changes that reach generated sdk methods.
+package specdiff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+type ChangeKind string
+
+const (
+	OperationRemoved   ChangeKind = "operation-removed"
+	OperationAdded     ChangeKind = "operation-added"
+	FieldRemoved       ChangeKind = "field-removed"
+	FieldRenamed       ChangeKind = "field-renamed"
+	FieldAdded         ChangeKind = "field-added"
+	FieldRequired      ChangeKind = "field-required"
+	TypeChanged        ChangeKind = "type-changed"
+	ContentTypeChanged ChangeKind = "content-type-changed"
+	EnumValueRemoved   ChangeKind = "enum-removed"
+	EnumValueAdded     ChangeKind = "enum-added"
+)
+
+// Change is one difference of an operation. Field is a dotted path rooted at
+// "path", "query", "header", "body" or "response"; "[]" steps into array
+// items.
+type Change struct {
+	Operation  string     `json:"operation"`
+	SDKMethods []string   `json:"sdk_methods,omitempty"`
+	Kind       ChangeKind `json:"kind"`
+	Field      string     `json:"field,omitempty"`
+	Breaking   bool       `json:"breaking"`
+	Message    string     `json:"message"`
+}
+
+func (c Change) String() string {
+	impact := "non-breaking"
+	if c.Breaking {
+		impact = "breaking"
+	}
+	location := c.Operation
+	if c.Field != "" {
+		location += " " + c.Field
+	}
+	text := fmt.Sprintf("%s %s %s: %s", impact, c.Kind, location, c.Message)
+	if len(c.SDKMethods) > 0 {
+		text += " (" + strings.Join(c.SDKMethods, ", ") + ")"
+	}
+	return text
+}
+
+// side tells whether a schema is sent or received, which decides whether
+// stricter requirements break callers.
+type side int
+
+const (
+	sideRequest side = iota
+	sideResponse
+)
+
+type comparer struct {
+	oldDoc  *openapi.Document
+	newDoc  *openapi.Document
+	changes []Change
+	op      string
+	visited map[[2]*openapi.Schema]struct{}
+}
+
+// Compare reports the changes from oldDoc to newDoc. When cfg is set only
+// operations named by api.operation_mappings are compared, and each change
+// carries the mapping's sdk methods.
+func Compare(oldDoc *openapi.Document, newDoc *openapi.Document, cfg *config.Config) []Change {
+	c := &comparer{oldDoc: oldDoc, newDoc: newDoc}
+	for _, ref := range operationRefs(oldDoc, newDoc, cfg) {
+		c.op = strings.ToUpper(ref.Method) + " " + ref.Path
+		c.visited = map[[2]*openapi.Schema]struct{}{}
+		start := len(c.changes)
+		c.operation(ref)
+		if methods := sdkMethods(cfg, ref); len(methods) > 0 {
+			for i := start; i < len(c.changes); i++ {
+				c.changes[i].SDKMethods = methods
+			}
+		}
+	}
+	return c.changes
+}
+
+func operationRefs(oldDoc *openapi.Document, newDoc *openapi.Document, cfg *config.Config) []openapi.OperationRef {
+	seen := map[openapi.OperationRef]struct{}{}
+	refs := make([]openapi.OperationRef, 0)
+	add := func(ref openapi.OperationRef) {
+		ref.Method = strings.ToLower(strings.TrimSpace(ref.Method))
+		if _, ok := seen[ref]; ok {
+			return
+		}
+		seen[ref] = struct{}{}
+		refs = append(refs, ref)
+	}
+	if cfg != nil {
+		for _, mapping := range cfg.API.OperationMappings {
+			add(openapi.OperationRef{Path: mapping.Path, Method: mapping.Method})
+		}
+	} else {
+		for _, ref := range oldDoc.ListOperations() {
+			add(ref)
+		}
+		for _, ref := range newDoc.ListOperations() {
+			add(ref)
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Path == refs[j].Path {
+			return refs[i].Method < refs[j].Method
+		}
+		return refs[i].Path < refs[j].Path
+	})
+	return refs
+}
+
+func sdkMethods(cfg *config.Config, ref openapi.OperationRef) []string {
+	if cfg == nil {
+		return nil
+	}
+	methods := make([]string, 0)
+	for _, mapping := range cfg.API.OperationMappings {
+		if mapping.Path == ref.Path && strings.EqualFold(strings.TrimSpace(mapping.Method), ref.Method) {
+			methods = append(methods, mapping.SDKMethods...)
+		}
+	}
+	return methods
+}
+
+func (c *comparer) add(kind ChangeKind, field string, breaking bool, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Operation: c.op,
+		Kind:      kind,
+		Field:     field,
+		Breaking:  breaking,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *comparer) operation(ref openapi.OperationRef) {
+	oldDetails, inOld := c.oldDoc.OperationDetails(ref.Path, ref.Method)
+	newDetails, inNew := c.newDoc.OperationDetails(ref.Path, ref.Method)
+	switch {
+	case !inOld && !inNew:
+		return
+	case !inNew:
+		c.add(OperationRemoved, "", true, "operation removed")
+		return
+	case !inOld:
+		c.add(OperationAdded, "", false, "operation added")
+		return
+	}
+
+	c.parameters(oldDetails.Parameters, newDetails.Parameters)
+
+	switch {
+	case oldDetails.RequestBodySchema != nil && newDetails.RequestBodySchema == nil:
+		c.add(FieldRemoved, "body", true, "request body removed")
+	case oldDetails.RequestBodySchema == nil && newDetails.RequestBodySchema != nil:
+		required := newDetails.RequestBody != nil && newDetails.RequestBody.Required
+		c.add(FieldAdded, "body", required, "request body added")
+	case oldDetails.RequestBodySchema != nil:
+		if oldDetails.RequestBodyContentType != newDetails.RequestBodyContentType {
+			c.add(ContentTypeChanged, "body", true, "%s -> %s", oldDetails.RequestBodyContentType, newDetails.RequestBodyContentType)
+		}
+		c.schema("body", oldDetails.RequestBodySchema, newDetails.RequestBodySchema, sideRequest)
+	}
+
+	switch {
+	case oldDetails.ResponseSchema != nil && newDetails.ResponseSchema == nil:
+		c.add(FieldRemoved, "response", true, "response body removed")
+	case oldDetails.ResponseSchema == nil && newDetails.ResponseSchema != nil:
+		c.add(FieldAdded, "response", false, "response body added")
+	case oldDetails.ResponseSchema != nil:
+		c.schema("response", oldDetails.ResponseSchema, newDetails.ResponseSchema, sideResponse)
+	}
+}
+
+func (c *comparer) parameters(oldParams []openapi.ParameterSpec, newParams []openapi.ParameterSpec) {
+	byKey := map[string]openapi.ParameterSpec{}
+	for _, param := range newParams {
+		byKey[param.In+"."+param.Name] = param
+	}
+	oldKeys := map[string]struct{}{}
+	for _, oldParam := range oldParams {
+		key := oldParam.In + "." + oldParam.Name
+		oldKeys[key] = struct{}{}
+		newParam, ok := byKey[key]
+		if !ok {
+			c.add(FieldRemoved, key, true, "parameter removed")
+			continue
+		}
+		if newParam.Required && !oldParam.Required {
+			c.add(FieldRequired, key, true, "parameter became required")
+		}
+		c.schema(key, oldParam.Schema, newParam.Schema, sideRequest)
+	}
+	for _, newParam := range newParams {
+		key := newParam.In + "." + newParam.Name
+		if _, ok := oldKeys[key]; ok {
+			continue
+		}
+		if newParam.Required {
+			c.add(FieldAdded, key, true, "required parameter added")
+		} else {
+			c.add(FieldAdded, key, false, "optional parameter added")
+		}
+	}
+}
+
+func (c *comparer) schema(field string, oldSchema *openapi.Schema, newSchema *openapi.Schema, s side) {
+	oldSchema = c.oldDoc.EffectiveSchema(oldSchema)
+	newSchema = c.newDoc.EffectiveSchema(newSchema)
+	if oldSchema == nil || newSchema == nil {
+		return
+	}
+	key := [2]*openapi.Schema{oldSchema, newSchema}
+	if _, ok := c.visited[key]; ok {
+		return
+	}
+	c.visited[key] = struct{}{}
+
+	oldType, newType := schemaType(oldSchema), schemaType(newSchema)
+	if oldType != "" && newType != "" && oldType != newType {
+		c.add(TypeChanged, field, true, "%s -> %s", oldType, newType)
+		return
+	}
+	c.enum(field, oldSchema.Enum, newSchema.Enum)
+	if oldSchema.Items != nil || newSchema.Items != nil {
+		c.schema(field+"[]", oldSchema.Items, newSchema.Items, s)
+	}
+	c.properties(field, oldSchema, newSchema, s)
+}
+
+func (c *comparer) properties(field string, oldSchema *openapi.Schema, newSchema *openapi.Schema, s side) {
+	removed := make([]string, 0)
+	added := make([]string, 0)
+	for _, name := range sortedKeys(oldSchema.Properties) {
+		if _, ok := newSchema.Properties[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	for _, name := range sortedKeys(newSchema.Properties) {
+		if _, ok := oldSchema.Properties[name]; !ok {
+			added = append(added, name)
+		}
+	}
+
+	// A single field swapped for one of the same type reads as a rename.
+	if len(removed) == 1 && len(added) == 1 &&
+		schemaType(c.oldDoc.EffectiveSchema(oldSchema.Properties[removed[0]])) == schemaType(c.newDoc.EffectiveSchema(newSchema.Properties[added[0]])) {
+		c.add(FieldRenamed, joinField(field, removed[0]), true, "renamed to %q", added[0])
+	} else {
+		for _, name := range removed {
+			c.add(FieldRemoved, joinField(field, name), true, "field removed")
+		}
+		for _, name := range added {
+			required := containsString(newSchema.Required, name)
+			if required && s == sideRequest {
+				c.add(FieldAdded, joinField(field, name), true, "required field added")
+			} else {
+				c.add(FieldAdded, joinField(field, name), false, "field added")
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(oldSchema.Properties) {
+		newProperty, ok := newSchema.Properties[name]
+		if !ok {
+			continue
+		}
+		if containsString(newSchema.Required, name) && !containsString(oldSchema.Required, name) {
+			// Responses only gain guarantees; requests gain obligations.
+			c.add(FieldRequired, joinField(field, name), s == sideRequest, "field became required")
+		}
+		c.schema(joinField(field, name), oldSchema.Properties[name], newProperty, s)
+	}
+}
+
+func (c *comparer) enum(field string, oldValues []interface{}, newValues []interface{}) {
+	if len(oldValues) == 0 || len(newValues) == 0 {
+		return
+	}
+	oldSet := map[string]struct{}{}
+	for _, value := range oldValues {
+		oldSet[fmt.Sprint(value)] = struct{}{}
+	}
+	newSet := map[string]struct{}{}
+	for _, value := range newValues {
+		newSet[fmt.Sprint(value)] = struct{}{}
+	}
+	for _, value := range oldValues {
+		if _, ok := newSet[fmt.Sprint(value)]; !ok {
+			c.add(EnumValueRemoved, field, true, "enum value %v removed", value)
+		}
+	}
+	for _, value := range newValues {
+		if _, ok := oldSet[fmt.Sprint(value)]; !ok {
+			c.add(EnumValueAdded, field, false, "enum value %v added", value)
+		}
+	}
+}
+
+func schemaType(schema *openapi.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	if schema.Type == "" && len(schema.Properties) > 0 {
+		return "object"
+	}
+	return schema.Type
+}
+
+func joinField(field string, name string) string {
+	return field + "." + name
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,185 @@
+package specdiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/config"
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+const oldSpec = `openapi: 3.0.0
+paths:
+  /v1/bots:
+    post:
+      parameters:
+        - in: query
+          name: space_id
+          schema:
+            type: string
+        - in: query
+          name: debug
+          schema:
+            type: boolean
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                icon:
+                  type: string
+                mode:
+                  $ref: '#/components/schemas/Mode'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Bot'
+  /v1/bots/list:
+    get:
+      responses:
+        '200':
+          description: ok
+  /v1/internal:
+    get:
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    Mode:
+      type: string
+      enum: [chat, workflow]
+    Bot:
+      type: object
+      properties:
+        bot_id:
+          type: string
+        version:
+          type: integer
+        tags:
+          type: array
+          items:
+            type: string
+`
+
+const newSpec = `openapi: 3.0.0
+paths:
+  /v1/bots:
+    post:
+      parameters:
+        - in: query
+          name: space_id
+          required: true
+          schema:
+            type: string
+        - in: query
+          name: locale
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                icon_url:
+                  type: string
+                mode:
+                  $ref: '#/components/schemas/Mode'
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Bot'
+  /v1/internal:
+    get:
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    Mode:
+      type: string
+      enum: [chat, agent]
+    Bot:
+      type: object
+      required: [bot_id]
+      properties:
+        bot_id:
+          type: string
+        version:
+          type: string
+        tags:
+          type: array
+          items:
+            type: integer
+`
+
+func mustParse(t *testing.T, content string) *openapi.Document {
+	t.Helper()
+	doc, err := openapi.Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return doc
+}
+
+func TestCompareClassifiesMappedChanges(t *testing.T) {
+	cfg := &config.Config{API: config.APIConfig{OperationMappings: []config.OperationMapping{
+		{Path: "/v1/bots", Method: "post", SDKMethods: []string{"bots.create"}},
+		{Path: "/v1/bots/list", Method: "get", SDKMethods: []string{"bots.list"}},
+	}}}
+
+	changes := Compare(mustParse(t, oldSpec), mustParse(t, newSpec), cfg)
+	got := make([]string, 0, len(changes))
+	for _, change := range changes {
+		got = append(got, change.String())
+	}
+	want := []string{
+		"breaking field-removed POST /v1/bots query.debug: parameter removed (bots.create)",
+		"breaking field-required POST /v1/bots query.space_id: parameter became required (bots.create)",
+		"non-breaking field-added POST /v1/bots query.locale: optional parameter added (bots.create)",
+		"breaking field-renamed POST /v1/bots body.icon: renamed to \"icon_url\" (bots.create)",
+		"breaking enum-removed POST /v1/bots body.mode: enum value workflow removed (bots.create)",
+		"non-breaking enum-added POST /v1/bots body.mode: enum value agent added (bots.create)",
+		"breaking field-required POST /v1/bots body.name: field became required (bots.create)",
+		"non-breaking field-required POST /v1/bots response.bot_id: field became required (bots.create)",
+		"breaking type-changed POST /v1/bots response.tags[]: string -> integer (bots.create)",
+		"breaking type-changed POST /v1/bots response.version: integer -> string (bots.create)",
+		"breaking operation-removed GET /v1/bots/list: operation removed (bots.list)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	report := NewReport(changes)
+	if report.Breaking != 8 || report.NonBreaking != 3 {
+		t.Fatalf("unexpected report totals: %+v", report)
+	}
+}
+
+func TestCompareWithoutConfigCoversEveryOperation(t *testing.T) {
+	changes := Compare(mustParse(t, oldSpec), mustParse(t, newSpec), nil)
+	for _, change := range changes {
+		if len(change.SDKMethods) > 0 {
+			t.Fatalf("did not expect sdk methods without config: %s", change)
+		}
+	}
+	if changes[len(changes)-1].Operation != "GET /v1/bots/list" {
+		t.Fatalf("expected unmapped comparison to include every operation, got %v", changes)
+	}
+	if len(Compare(mustParse(t, newSpec), mustParse(t, newSpec), nil)) != 0 {
+		t.Fatal("expected identical documents to have no changes")
+	}
+}
@@ -0,0 +1,50 @@
+package speclint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report is the outcome of a lint run after suppressions are applied.
+type Report struct {
+	Errors     int       `json:"errors"`
+	Warnings   int       `json:"warnings"`
+	Findings   []Finding `json:"findings"`
+	Suppressed []Finding `json:"suppressed"`
+}
+
+func NewReport(findings []Finding, suppressions *Suppressions) Report {
+	kept, suppressed := suppressions.Filter(findings)
+	report := Report{Findings: kept, Suppressed: suppressed}
+	for _, finding := range kept {
+		if finding.Severity == SeverityError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+	}
+	return report
+}
+
+func (r Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("encode lint report: %w", err)
+	}
+	return nil
+}
+
+// WriteText prints one line per finding followed by the totals.
+func (r Report) WriteText(w io.Writer) error {
+	var buf strings.Builder
+	for _, finding := range r.Findings {
+		buf.WriteString(finding.String())
+		buf.WriteString("\n")
+	}
+	buf.WriteString(fmt.Sprintf("errors=%d warnings=%d suppressed=%d\n", r.Errors, r.Warnings, len(r.Suppressed)))
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
@@ -0,0 +1,354 @@
+// Package speclint checks an OpenAPI document for problems that break or
+// degrade sdk generation.
+package speclint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+}
+
+const (
+	RuleOperationIDMissing     = "operation-id-missing"
+	RuleOperationIDDuplicate   = "operation-id-duplicate"
+	RulePathParamUndeclared    = "path-param-undeclared"
+	RuleRefUnresolved          = "ref-unresolved"
+	RuleRequestBodyEmptyObject = "request-body-empty-object"
+	RuleEnumMixedTypes         = "enum-mixed-types"
+	RuleResponseNoSuccess      = "response-no-success"
+)
+
+// Rules lists every check in report order.
+var Rules = []Rule{
+	{ID: RuleOperationIDMissing, Severity: SeverityWarning, Description: "operation has no operationId; method names fall back to the path"},
+	{ID: RuleOperationIDDuplicate, Severity: SeverityError, Description: "operationId is used by more than one operation"},
+	{ID: RulePathParamUndeclared, Severity: SeverityError, Description: "path template and path parameters disagree"},
+	{ID: RuleRefUnresolved, Severity: SeverityError, Description: "$ref points at a missing component"},
+	{ID: RuleRequestBodyEmptyObject, Severity: SeverityWarning, Description: "request body is an object without properties"},
+	{ID: RuleEnumMixedTypes, Severity: SeverityError, Description: "enum mixes value types"},
+	{ID: RuleResponseNoSuccess, Severity: SeverityError, Description: "operation declares no 2xx response"},
+}
+
+// Finding is one rule violation. Location is "METHOD /path" for operations
+// and a component ref ("#/components/schemas/Bot") otherwise.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Location string   `json:"location"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s %s %s: %s", f.Severity, f.Rule, f.Location, f.Message)
+}
+
+var pathParamPattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+type linter struct {
+	doc      *openapi.Document
+	findings []Finding
+	visited  map[*openapi.Schema]struct{}
+}
+
+// Lint runs every rule over doc and returns the findings sorted by location
+// and rule.
+func Lint(doc *openapi.Document) []Finding {
+	if doc == nil {
+		return nil
+	}
+	l := &linter{doc: doc, visited: map[*openapi.Schema]struct{}{}}
+	l.components()
+	l.operations()
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		if l.findings[i].Location != l.findings[j].Location {
+			return l.findings[i].Location < l.findings[j].Location
+		}
+		return ruleOrder(l.findings[i].Rule) < ruleOrder(l.findings[j].Rule)
+	})
+	return l.findings
+}
+
+func ruleOrder(id string) int {
+	for i, rule := range Rules {
+		if rule.ID == id {
+			return i
+		}
+	}
+	return len(Rules)
+}
+
+func (l *linter) report(rule string, location string, format string, args ...interface{}) {
+	severity := SeverityError
+	if index := ruleOrder(rule); index < len(Rules) {
+		severity = Rules[index].Severity
+	}
+	l.findings = append(l.findings, Finding{
+		Rule:     rule,
+		Severity: severity,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) components() {
+	components := l.doc.Components
+	for _, name := range sortedKeys(components.Schemas) {
+		l.schema("#/components/schemas/"+name, "", components.Schemas[name])
+	}
+	for _, name := range sortedKeys(components.Parameters) {
+		l.parameter("#/components/parameters/"+name, components.Parameters[name])
+	}
+	for _, name := range sortedKeys(components.RequestBodies) {
+		body := components.RequestBodies[name]
+		if body != nil {
+			l.ref("#/components/requestBodies/"+name, "", body.Ref)
+			l.content("#/components/requestBodies/"+name, "", body.Content)
+		}
+	}
+	for _, name := range sortedKeys(components.Responses) {
+		response := components.Responses[name]
+		if response != nil {
+			l.ref("#/components/responses/"+name, "", response.Ref)
+			l.content("#/components/responses/"+name, "", response.Content)
+		}
+	}
+}
+
+func (l *linter) operations() {
+	operationIDs := map[string]string{}
+	for _, ref := range l.doc.ListOperations() {
+		location := strings.ToUpper(ref.Method) + " " + ref.Path
+		details, ok := l.doc.OperationDetails(ref.Path, ref.Method)
+		if !ok {
+			continue
+		}
+
+		operationID := strings.TrimSpace(details.OperationID)
+		if operationID == "" {
+			l.report(RuleOperationIDMissing, location, "operationId is empty")
+		} else if first, ok := operationIDs[operationID]; ok {
+			l.report(RuleOperationIDDuplicate, location, "operationId %q is also used by %s", operationID, first)
+		} else {
+			operationIDs[operationID] = location
+		}
+
+		l.pathParams(location, ref.Path, details)
+
+		item := l.doc.Paths[ref.Path]
+		for _, param := range item.Parameters {
+			l.parameter(location, param)
+		}
+		for _, param := range details.Operation.Parameters {
+			l.parameter(location, param)
+		}
+		if body := details.Operation.RequestBody; body != nil {
+			l.ref(location, "requestBody", body.Ref)
+			l.content(location, "requestBody", body.Content)
+		}
+		l.requestBody(location, details)
+		l.responses(location, details.Operation.Responses)
+	}
+}
+
+func (l *linter) pathParams(location string, path string, details *openapi.OperationDetails) {
+	declared := map[string]struct{}{}
+	for _, param := range details.PathParameters {
+		declared[param.Name] = struct{}{}
+	}
+	templated := map[string]struct{}{}
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		name := match[1]
+		templated[name] = struct{}{}
+		if _, ok := declared[name]; !ok {
+			l.report(RulePathParamUndeclared, location, "path parameter %q is not declared", name)
+		}
+	}
+	for _, param := range details.PathParameters {
+		if _, ok := templated[param.Name]; !ok {
+			l.report(RulePathParamUndeclared, location, "path parameter %q is not in the path template", param.Name)
+		}
+	}
+}
+
+func (l *linter) requestBody(location string, details *openapi.OperationDetails) {
+	switch strings.ToLower(details.Method) {
+	case "post", "put", "patch":
+	default:
+		return
+	}
+	if details.RequestBodySchema == nil || strings.HasPrefix(details.RequestBodyContentType, "multipart/") {
+		return
+	}
+	if l.doc.EffectiveSchema(details.RequestBodySchema).IsEmptyObject() {
+		l.report(RuleRequestBodyEmptyObject, location, "%s request body declares no properties", details.RequestBodyContentType)
+	}
+}
+
+func (l *linter) responses(location string, responses map[string]*openapi.Response) {
+	success := false
+	for status := range responses {
+		if strings.HasPrefix(status, "2") {
+			success = true
+		}
+	}
+	switch {
+	case len(responses) == 0:
+		l.report(RuleResponseNoSuccess, location, "no responses declared")
+	case !success:
+		l.report(RuleResponseNoSuccess, location, "responses %s have no 2xx status", strings.Join(sortedKeys(responses), ", "))
+	}
+	for _, status := range sortedKeys(responses) {
+		response := responses[status]
+		if response == nil {
+			continue
+		}
+		l.ref(location, "responses."+status, response.Ref)
+		l.content(location, "responses."+status, response.Content)
+	}
+}
+
+func (l *linter) parameter(location string, param *openapi.Parameter) {
+	if param == nil {
+		return
+	}
+	if param.Ref != "" {
+		l.ref(location, "", param.Ref)
+		return
+	}
+	l.schema(location, "parameters."+param.Name, param.Schema)
+}
+
+func (l *linter) content(location string, field string, content map[string]*openapi.MediaType) {
+	for _, contentType := range sortedKeys(content) {
+		if mediaType := content[contentType]; mediaType != nil {
+			l.schema(location, field, mediaType.Schema)
+		}
+	}
+}
+
+func (l *linter) schema(location string, field string, schema *openapi.Schema) {
+	if schema == nil {
+		return
+	}
+	if _, ok := l.visited[schema]; ok {
+		return
+	}
+	l.visited[schema] = struct{}{}
+	if schema.Ref != "" {
+		l.ref(location, field, schema.Ref)
+		return
+	}
+	if kinds := enumKinds(schema); len(kinds) > 1 {
+		l.report(RuleEnumMixedTypes, location, "%senum mixes %s values", fieldPrefix(field), strings.Join(kinds, ", "))
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		l.schema(location, joinField(field, "properties."+name), schema.Properties[name])
+	}
+	l.schema(location, joinField(field, "items"), schema.Items)
+	groups := []struct {
+		keyword string
+		schemas []*openapi.Schema
+	}{
+		{"allOf", schema.AllOf},
+		{"anyOf", schema.AnyOf},
+		{"oneOf", schema.OneOf},
+		{"prefixItems", schema.PrefixItems},
+	}
+	for _, group := range groups {
+		for i, item := range group.schemas {
+			l.schema(location, joinField(field, fmt.Sprintf("%s.%d", group.keyword, i)), item)
+		}
+	}
+	if raw, ok := schema.AdditionalProperties.(map[string]interface{}); ok {
+		if ref, ok := raw["$ref"].(string); ok {
+			l.ref(location, joinField(field, "additionalProperties"), ref)
+		}
+	}
+}
+
+// ref reports local component refs whose target is missing; refs into other
+// files are already resolved or rejected by openapi.Load.
+func (l *linter) ref(location string, field string, ref string) {
+	if !strings.HasPrefix(ref, "#/components/") {
+		return
+	}
+	parts := strings.SplitN(strings.TrimPrefix(ref, "#/components/"), "/", 2)
+	if len(parts) != 2 {
+		l.report(RuleRefUnresolved, location, "%s$ref %q is not a component ref", fieldPrefix(field), ref)
+		return
+	}
+	components := l.doc.Components
+	found := false
+	switch parts[0] {
+	case "schemas":
+		_, found = components.Schemas[parts[1]]
+	case "parameters":
+		_, found = components.Parameters[parts[1]]
+	case "requestBodies":
+		_, found = components.RequestBodies[parts[1]]
+	case "responses":
+		_, found = components.Responses[parts[1]]
+	}
+	if !found {
+		l.report(RuleRefUnresolved, location, "%s$ref %q not found", fieldPrefix(field), ref)
+	}
+}
+
+// enumKinds lists the JSON types of the enum values; null is ignored.
+func enumKinds(schema *openapi.Schema) []string {
+	seen := map[string]struct{}{}
+	for _, value := range schema.Enum {
+		switch value.(type) {
+		case nil:
+			continue
+		case string:
+			seen["string"] = struct{}{}
+		case bool:
+			seen["boolean"] = struct{}{}
+		case int, int64, uint64, float64:
+			seen["number"] = struct{}{}
+		default:
+			seen[fmt.Sprintf("%T", value)] = struct{}{}
+		}
+	}
+	return sortedKeys(seen)
+}
+
+func joinField(field string, child string) string {
+	if field == "" {
+		return child
+	}
+	return field + "." + child
+}
+
+func fieldPrefix(field string) string {
+	if field == "" {
+		return ""
+	}
+	return field + ": "
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
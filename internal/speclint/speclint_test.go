@@ -0,0 +1,125 @@
+package speclint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+)
+
+const lintFixture = `openapi: 3.0.0
+paths:
+  /v1/bots/{bot_id}:
+    get:
+      operationId: GetBot
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Missing'
+    post:
+      operationId: GetBot
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: string
+        - in: path
+          name: space_id
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        '400':
+          description: bad request
+  /v1/files:
+    post:
+      requestBody:
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+      responses:
+        '200':
+          description: ok
+components:
+  schemas:
+    Mode:
+      enum: [1, "two", null]
+`
+
+func mustParse(t *testing.T, content string) *openapi.Document {
+	t.Helper()
+	doc, err := openapi.Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return doc
+}
+
+func TestLintReportsRules(t *testing.T) {
+	findings := Lint(mustParse(t, lintFixture))
+	got := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		got = append(got, finding.String())
+	}
+	want := []string{
+		"error enum-mixed-types #/components/schemas/Mode: enum mixes number, string values",
+		"error path-param-undeclared GET /v1/bots/{bot_id}: path parameter \"bot_id\" is not declared",
+		"error ref-unresolved GET /v1/bots/{bot_id}: responses.200: $ref \"#/components/schemas/Missing\" not found",
+		"error operation-id-duplicate POST /v1/bots/{bot_id}: operationId \"GetBot\" is also used by GET /v1/bots/{bot_id}",
+		"error path-param-undeclared POST /v1/bots/{bot_id}: path parameter \"space_id\" is not in the path template",
+		"warning request-body-empty-object POST /v1/bots/{bot_id}: application/json request body declares no properties",
+		"error response-no-success POST /v1/bots/{bot_id}: responses 400 have no 2xx status",
+		"warning operation-id-missing POST /v1/files: operationId is empty",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestSuppressionsFilterFindings(t *testing.T) {
+	suppressions, err := ParseSuppressions([]byte(`
+suppressions:
+  - rule: path-param-undeclared
+    location: "* /v1/bots/*"
+  - rule: operation-id-missing
+`))
+	if err != nil {
+		t.Fatalf("ParseSuppressions() error = %v", err)
+	}
+	report := NewReport(Lint(mustParse(t, lintFixture)), suppressions)
+	if len(report.Suppressed) != 3 || report.Errors != 4 || report.Warnings != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	for _, finding := range report.Findings {
+		if finding.Rule == RulePathParamUndeclared || finding.Rule == RuleOperationIDMissing {
+			t.Fatalf("expected %s to be suppressed", finding)
+		}
+	}
+
+	var out strings.Builder
+	if err := report.WriteText(&out); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	if !strings.HasSuffix(out.String(), "errors=4 warnings=1 suppressed=3\n") {
+		t.Fatalf("unexpected text report:\n%s", out.String())
+	}
+}
+
+func TestParseSuppressionsRejectsUnknownRules(t *testing.T) {
+	if _, err := ParseSuppressions([]byte("suppressions:\n  - rule: no-such-rule\n")); err == nil || !strings.Contains(err.Error(), "unknown rule") {
+		t.Fatalf("expected unknown rule error, got %v", err)
+	}
+	if _, err := ParseSuppressions([]byte("suppressions:\n  - rule: ref-unresolved\n    location: \"[\"\n")); err == nil {
+		t.Fatal("expected invalid pattern error")
+	}
+}
@@ -0,0 +1,82 @@
+package speclint
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Suppression silences the findings of Rule at Location. Location is a
+// path.Match pattern ("POST /v1/files/*"); empty matches every location.
+type Suppression struct {
+	Rule     string `yaml:"rule"`
+	Location string `yaml:"location"`
+	Reason   string `yaml:"reason"`
+}
+
+type Suppressions struct {
+	Suppressions []Suppression `yaml:"suppressions"`
+}
+
+func LoadSuppressions(filePath string) (*Suppressions, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("read suppression file %q: %w", filePath, err)
+	}
+	suppressions, err := ParseSuppressions(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse suppression file %q: %w", filePath, err)
+	}
+	return suppressions, nil
+}
+
+func ParseSuppressions(content []byte) (*Suppressions, error) {
+	var suppressions Suppressions
+	if err := yaml.Unmarshal(content, &suppressions); err != nil {
+		return nil, err
+	}
+	for i, suppression := range suppressions.Suppressions {
+		if ruleOrder(strings.TrimSpace(suppression.Rule)) == len(Rules) {
+			return nil, fmt.Errorf("suppressions[%d]: unknown rule %q", i, suppression.Rule)
+		}
+		if _, err := path.Match(suppression.Location, ""); err != nil {
+			return nil, fmt.Errorf("suppressions[%d]: invalid location pattern %q: %w", i, suppression.Location, err)
+		}
+	}
+	return &suppressions, nil
+}
+
+func (s *Suppressions) matches(finding Finding) bool {
+	if s == nil {
+		return false
+	}
+	for _, suppression := range s.Suppressions {
+		if strings.TrimSpace(suppression.Rule) != finding.Rule {
+			continue
+		}
+		if suppression.Location == "" {
+			return true
+		}
+		if ok, _ := path.Match(suppression.Location, finding.Location); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter splits findings into the ones to report and the suppressed ones.
+func (s *Suppressions) Filter(findings []Finding) ([]Finding, []Finding) {
+	kept := make([]Finding, 0, len(findings))
+	suppressed := make([]Finding, 0)
+	for _, finding := range findings {
+		if s.matches(finding) {
+			suppressed = append(suppressed, finding)
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept, suppressed
+}
@@ -0,0 +1,206 @@
+package specmerge
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func documentRoot(node *yaml.Node) *yaml.Node {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return node.Content[0]
+	}
+	return node
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// ensureMapping returns the mapping under key, creating it when missing.
+func ensureMapping(node *yaml.Node, key string) *yaml.Node {
+	if value := mappingValue(node, key); value != nil && value.Kind == yaml.MappingNode {
+		return value
+	}
+	value := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	setMappingValue(node, key, value)
+	return value
+}
+
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	appendMappingValue(node, key, value)
+}
+
+func appendMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	node.Content = append(node.Content, scalarNode(key), value)
+}
+
+// insertMappingValueSorted adds key before the first greater key, keeping a
+// sorted mapping sorted.
+func insertMappingValueSorted(node *yaml.Node, key string, value *yaml.Node) {
+	at := len(node.Content)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value > key {
+			at = i
+			break
+		}
+	}
+	content := make([]*yaml.Node, 0, len(node.Content)+2)
+	content = append(content, node.Content[:at]...)
+	content = append(content, scalarNode(key), value)
+	node.Content = append(content, node.Content[at:]...)
+}
+
+func scalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func cloneNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	clone := *node
+	clone.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		clone.Content[i] = cloneNode(child)
+	}
+	return &clone
+}
+
+// structuralKeys are the schema keys that decide whether two schemas are the
+// same; docs, examples and extensions are ignored.
+var structuralKeys = map[string]bool{
+	"$ref":                 true,
+	"type":                 true,
+	"format":               true,
+	"enum":                 true,
+	"const":                true,
+	"items":                true,
+	"properties":           true,
+	"required":             true,
+	"additionalProperties": true,
+	"allOf":                true,
+	"oneOf":                true,
+	"anyOf":                true,
+	"prefixItems":          true,
+}
+
+// fingerprint renders the structure of a schema canonically, with mapping
+// keys sorted.
+func fingerprint(schema *yaml.Node) string {
+	var b strings.Builder
+	writeSchemaFingerprint(&b, schema)
+	return b.String()
+}
+
+func writeSchemaFingerprint(b *strings.Builder, schema *yaml.Node) {
+	if schema == nil {
+		b.WriteString("~")
+		return
+	}
+	if schema.Kind != yaml.MappingNode {
+		writeValueFingerprint(b, schema)
+		return
+	}
+	b.WriteString("{")
+	for _, key := range sortedMappingKeys(schema) {
+		if !structuralKeys[key] {
+			continue
+		}
+		value := mappingValue(schema, key)
+		b.WriteString(key)
+		b.WriteString(":")
+		switch key {
+		case "properties":
+			b.WriteString("{")
+			for _, name := range sortedMappingKeys(value) {
+				b.WriteString(name)
+				b.WriteString(":")
+				writeSchemaFingerprint(b, mappingValue(value, name))
+				b.WriteString(",")
+			}
+			b.WriteString("}")
+		case "required":
+			b.WriteString("[")
+			for _, name := range sortedScalars(value) {
+				b.WriteString(name)
+				b.WriteString(",")
+			}
+			b.WriteString("]")
+		case "items", "additionalProperties":
+			writeSchemaFingerprint(b, value)
+		case "allOf", "oneOf", "anyOf", "prefixItems":
+			b.WriteString("[")
+			for _, item := range value.Content {
+				writeSchemaFingerprint(b, item)
+				b.WriteString(",")
+			}
+			b.WriteString("]")
+		default:
+			writeValueFingerprint(b, value)
+		}
+		b.WriteString(";")
+	}
+	b.WriteString("}")
+}
+
+func writeValueFingerprint(b *strings.Builder, value *yaml.Node) {
+	switch value.Kind {
+	case yaml.SequenceNode:
+		b.WriteString("[")
+		for _, item := range value.Content {
+			writeValueFingerprint(b, item)
+			b.WriteString(",")
+		}
+		b.WriteString("]")
+	case yaml.MappingNode:
+		b.WriteString("{")
+		for _, key := range sortedMappingKeys(value) {
+			b.WriteString(key)
+			b.WriteString(":")
+			writeValueFingerprint(b, mappingValue(value, key))
+			b.WriteString(",")
+		}
+		b.WriteString("}")
+	default:
+		b.WriteString(value.Tag)
+		b.WriteString(" ")
+		b.WriteString(value.Value)
+	}
+}
+
+func sortedScalars(node *yaml.Node) []string {
+	values := make([]string, 0, len(node.Content))
+	for _, item := range node.Content {
+		values = append(values, item.Value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+func sortedMappingKeys(node *yaml.Node) []string {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	keys := make([]string, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i].Value)
+	}
+	sort.Strings(keys)
+	return keys
+}
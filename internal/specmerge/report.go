@@ -0,0 +1,66 @@
+package specmerge
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Report struct {
+	Added      int               `json:"added"`
+	Changed    int               `json:"changed"`
+	Unchanged  int               `json:"unchanged"`
+	Operations []OperationChange `json:"operations"`
+	Schemas    []SchemaRename    `json:"schemas"`
+}
+
+func NewReport(result Result) Report {
+	report := Report{Operations: result.Operations, Schemas: result.Schemas}
+	if report.Operations == nil {
+		report.Operations = []OperationChange{}
+	}
+	if report.Schemas == nil {
+		report.Schemas = []SchemaRename{}
+	}
+	for _, change := range report.Operations {
+		switch change.Kind {
+		case OperationAdded:
+			report.Added++
+		case OperationChanged:
+			report.Changed++
+		default:
+			report.Unchanged++
+		}
+	}
+	return report
+}
+
+func (r Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return fmt.Errorf("encode merge report: %w", err)
+	}
+	return nil
+}
+
+// WriteText prints the added and changed operations and the renamed schemas
+// followed by the totals.
+func (r Report) WriteText(w io.Writer) error {
+	var buf strings.Builder
+	for _, change := range r.Operations {
+		if change.Kind == OperationUnchanged {
+			continue
+		}
+		buf.WriteString(change.String())
+		buf.WriteString("\n")
+	}
+	for _, rename := range r.Schemas {
+		buf.WriteString(rename.String())
+		buf.WriteString("\n")
+	}
+	buf.WriteString(fmt.Sprintf("added=%d changed=%d unchanged=%d renamed_schemas=%d\n", r.Added, r.Changed, r.Unchanged, len(r.Schemas)))
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
@@ -0,0 +1,467 @@
+// Package specmerge folds the per-endpoint documents written by
+// coze-api-doc-sync into the consolidated OpenAPI document.
+package specmerge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/coze-dev/coze-sdk-gen/internal/openapi"
+	"gopkg.in/yaml.v3"
+)
+
+type ChangeKind string
+
+const (
+	OperationAdded     ChangeKind = "added"
+	OperationChanged   ChangeKind = "changed"
+	OperationUnchanged ChangeKind = "unchanged"
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+var (
+	httpMethods = map[string]bool{
+		"get": true, "put": true, "post": true, "delete": true,
+		"patch": true, "options": true, "head": true, "trace": true,
+	}
+	// curatedKeys are never merged into an existing node: its docs, tags and
+	// operationId are maintained by hand.
+	curatedKeys = map[string]bool{
+		"operationId": true,
+		"tags":        true,
+		"summary":     true,
+		"description": true,
+		"title":       true,
+		"example":     true,
+		"examples":    true,
+	}
+	// droppedHeaders are expressed by security schemes and content types in
+	// the consolidated spec.
+	droppedHeaders = map[string]bool{
+		"authorization": true,
+		"content-type":  true,
+	}
+)
+
+// Source is one synced per-endpoint document; Name is its page slug.
+type Source struct {
+	Name string
+	Root *yaml.Node
+}
+
+// OperationChange is the merge outcome of one synced operation. Fields lists
+// what the merge added to an existing operation.
+type OperationChange struct {
+	Operation string     `json:"operation"`
+	Source    string     `json:"source"`
+	Kind      ChangeKind `json:"kind"`
+	Fields    []string   `json:"fields,omitempty"`
+}
+
+func (c OperationChange) String() string {
+	text := fmt.Sprintf("%s %s (%s)", c.Kind, c.Operation, c.Source)
+	if len(c.Fields) > 0 {
+		text += ": " + strings.Join(c.Fields, ", ")
+	}
+	return text
+}
+
+// SchemaRename is a synced schema stored under another name, because a schema
+// of the same structure already exists or because its name was taken.
+type SchemaRename struct {
+	Source string `json:"source"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+func (r SchemaRename) String() string {
+	return fmt.Sprintf("schema %s -> %s (%s)", r.From, r.To, r.Source)
+}
+
+type Result struct {
+	Operations []OperationChange
+	Schemas    []SchemaRename
+}
+
+// LoadSources reads every .yaml and .yml document in dir, sorted by name.
+func LoadSources(dir string) ([]Source, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read source dir %q: %w", dir, err)
+	}
+	sources := make([]Source, 0, len(entries))
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		filePath := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("read source %q: %w", filePath, err)
+		}
+		var root yaml.Node
+		if err := yaml.Unmarshal(content, &root); err != nil {
+			return nil, fmt.Errorf("parse source %q: %w", filePath, err)
+		}
+		sources = append(sources, Source{Name: strings.TrimSuffix(entry.Name(), ext), Root: &root})
+	}
+	return sources, nil
+}
+
+// LoadDocument reads the consolidated spec; a missing file starts an empty
+// OpenAPI 3.0.3 document.
+func LoadDocument(filePath string) (*yaml.Node, error) {
+	content, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		appendMappingValue(root, "openapi", scalarNode("3.0.3"))
+		return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read spec %q: %w", filePath, err)
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("parse spec %q: %w", filePath, err)
+	}
+	return &root, nil
+}
+
+// WriteDocument writes doc as YAML with two-space indentation.
+func WriteDocument(filePath string, doc *yaml.Node) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("encode spec: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("encode spec: %w", err)
+	}
+	if err := os.WriteFile(filePath, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write spec %q: %w", filePath, err)
+	}
+	return nil
+}
+
+type merger struct {
+	paths   *yaml.Node
+	schemas *yaml.Node
+	// byFingerprint holds the first schema name of each structure.
+	byFingerprint map[string]string
+	result        Result
+}
+
+// sourceScope tracks how the schema names of one source map into the
+// consolidated document. Schemas are only brought in once a merged node
+// references them.
+type sourceScope struct {
+	name    string
+	schemas *yaml.Node
+	names   map[string]string
+	// open holds, per schema still being resolved, the refs that already
+	// point at it, to be renamed once its final name is known.
+	open map[string][]*yaml.Node
+}
+
+// Merge folds sources into base in order. New operations and schemas are
+// added; existing operations only gain the keys, parameters and enum values
+// they lack, so hand-curated content wins. Synced schemas reuse a consolidated
+// schema of the same structure, and a name taken by a different schema is
+// prefixed with the source name.
+func Merge(base *yaml.Node, sources []Source) (Result, error) {
+	root := documentRoot(base)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return Result{}, fmt.Errorf("consolidated spec is not a mapping")
+	}
+	m := &merger{
+		paths:         ensureMapping(root, "paths"),
+		schemas:       ensureMapping(ensureMapping(root, "components"), "schemas"),
+		byFingerprint: map[string]string{},
+		result: Result{
+			Operations: []OperationChange{},
+			Schemas:    []SchemaRename{},
+		},
+	}
+	for i := 0; i+1 < len(m.schemas.Content); i += 2 {
+		key := fingerprint(m.schemas.Content[i+1])
+		if _, ok := m.byFingerprint[key]; !ok {
+			m.byFingerprint[key] = m.schemas.Content[i].Value
+		}
+	}
+
+	for _, source := range sources {
+		if err := m.source(source); err != nil {
+			return Result{}, err
+		}
+	}
+	return m.result, nil
+}
+
+func (m *merger) source(source Source) error {
+	root := documentRoot(source.Root)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return fmt.Errorf("source %q is not a mapping", source.Name)
+	}
+	scope := &sourceScope{
+		name:    source.Name,
+		schemas: mappingValue(mappingValue(root, "components"), "schemas"),
+		names:   map[string]string{},
+		open:    map[string][]*yaml.Node{},
+	}
+
+	paths := mappingValue(root, "paths")
+	if paths == nil {
+		return nil
+	}
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		item := paths.Content[i+1]
+		for j := 0; j+1 < len(item.Content); j += 2 {
+			method := strings.ToLower(item.Content[j].Value)
+			if !httpMethods[method] {
+				continue
+			}
+			m.operation(scope, paths.Content[i].Value, method, item.Content[j+1])
+		}
+	}
+	return nil
+}
+
+// schema returns the consolidated name of the source schema name, adding the
+// schema when no structurally equal one exists.
+func (m *merger) schema(scope *sourceScope, name string) string {
+	if merged, ok := scope.names[name]; ok {
+		return merged
+	}
+	node := mappingValue(scope.schemas, name)
+	if node == nil {
+		return name
+	}
+	// Recursive refs resolve to the source name while the schema is open and
+	// are renamed below.
+	scope.names[name] = name
+	scope.open[name] = nil
+
+	schema := cloneNode(node)
+	m.rewriteRefs(scope, schema)
+	key := fingerprint(schema)
+
+	merged, ok := m.byFingerprint[key]
+	if existing := mappingValue(m.schemas, name); existing != nil && fingerprint(existing) == key {
+		merged, ok = name, true
+	}
+	if !ok {
+		merged = name
+		if mappingValue(m.schemas, name) != nil {
+			merged = m.uniqueSchemaName(pascalCase(scope.name) + name)
+		}
+		appendMappingValue(m.schemas, merged, schema)
+		m.byFingerprint[key] = merged
+	}
+	scope.names[name] = merged
+	for _, ref := range scope.open[name] {
+		ref.Value = schemaRefPrefix + merged
+	}
+	delete(scope.open, name)
+	if merged != name {
+		m.result.Schemas = append(m.result.Schemas, SchemaRename{Source: scope.name, From: name, To: merged})
+	}
+	return merged
+}
+
+func (m *merger) uniqueSchemaName(name string) string {
+	if mappingValue(m.schemas, name) == nil {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := name + strconv.Itoa(i)
+		if mappingValue(m.schemas, candidate) == nil {
+			return candidate
+		}
+	}
+}
+
+func (m *merger) rewriteRefs(scope *sourceScope, node *yaml.Node) {
+	if node == nil {
+		return
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value := node.Content[i+1]
+			if node.Content[i].Value == "$ref" && value.Kind == yaml.ScalarNode {
+				if name, ok := strings.CutPrefix(value.Value, schemaRefPrefix); ok {
+					value.Value = schemaRefPrefix + m.schema(scope, name)
+					if refs, open := scope.open[name]; open {
+						scope.open[name] = append(refs, value)
+					}
+				}
+				continue
+			}
+			m.rewriteRefs(scope, value)
+		}
+		return
+	}
+	for _, child := range node.Content {
+		m.rewriteRefs(scope, child)
+	}
+}
+
+func (m *merger) operation(scope *sourceScope, rawPath string, method string, node *yaml.Node) {
+	op := cloneNode(node)
+	dropHeaderParameters(op)
+
+	path := openapi.NormalizePathTemplate(rawPath)
+	change := OperationChange{Operation: strings.ToUpper(method) + " " + path, Source: scope.name}
+	item := mappingValue(m.paths, path)
+	if item == nil {
+		item = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		insertMappingValueSorted(m.paths, path, item)
+	}
+	existing := mappingValue(item, method)
+	switch {
+	case existing == nil:
+		m.rewriteRefs(scope, op)
+		appendMappingValue(item, method, op)
+		change.Kind = OperationAdded
+	default:
+		change.Fields = m.mergeMapping(scope, existing, op, "", false)
+		change.Kind = OperationUnchanged
+		if len(change.Fields) > 0 {
+			change.Kind = OperationChanged
+		}
+	}
+	m.result.Operations = append(m.result.Operations, change)
+}
+
+func dropHeaderParameters(op *yaml.Node) {
+	params := mappingValue(op, "parameters")
+	if params == nil || params.Kind != yaml.SequenceNode {
+		return
+	}
+	kept := params.Content[:0]
+	for _, param := range params.Content {
+		in := mappingValue(param, "in")
+		name := mappingValue(param, "name")
+		if in != nil && name != nil && in.Value == "header" && droppedHeaders[strings.ToLower(name.Value)] {
+			continue
+		}
+		kept = append(kept, param)
+	}
+	params.Content = kept
+}
+
+// mergeMapping adds the keys of src that dst lacks and returns their dotted
+// paths. Values dst already has are kept, and a schema that is a $ref on one
+// side and inline on the other is left as dst has it. Keys of a properties map
+// are field names, so curated keys only apply elsewhere.
+func (m *merger) mergeMapping(scope *sourceScope, dst *yaml.Node, src *yaml.Node, at string, fieldNames bool) []string {
+	fields := make([]string, 0)
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i].Value, src.Content[i+1]
+		if !fieldNames && curatedKeys[key] {
+			continue
+		}
+		field := joinField(at, key)
+		existing := mappingValue(dst, key)
+		switch {
+		case existing == nil:
+			m.rewriteRefs(scope, value)
+			appendMappingValue(dst, key, value)
+			fields = append(fields, field)
+		case existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			if isRef(existing) != isRef(value) {
+				continue
+			}
+			fields = append(fields, m.mergeMapping(scope, existing, value, field, !fieldNames && key == "properties")...)
+		case existing.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode:
+			fields = append(fields, m.mergeSequence(scope, existing, value, field)...)
+		}
+	}
+	return fields
+}
+
+// mergeSequence appends the scalars dst lacks, such as enum values, and the
+// parameters dst lacks by location and name, merging matching parameters.
+func (m *merger) mergeSequence(scope *sourceScope, dst *yaml.Node, src *yaml.Node, at string) []string {
+	fields := make([]string, 0)
+	for _, item := range src.Content {
+		switch item.Kind {
+		case yaml.ScalarNode:
+			if sequenceHasScalar(dst, item.Value) {
+				continue
+			}
+			dst.Content = append(dst.Content, item)
+			fields = append(fields, at+"["+item.Value+"]")
+		case yaml.MappingNode:
+			key, ok := parameterKey(item)
+			if !ok {
+				continue
+			}
+			field := at + "[" + key + "]"
+			existing := findParameter(dst, key)
+			if existing == nil {
+				m.rewriteRefs(scope, item)
+				dst.Content = append(dst.Content, item)
+				fields = append(fields, field)
+				continue
+			}
+			fields = append(fields, m.mergeMapping(scope, existing, item, field, false)...)
+		}
+	}
+	return fields
+}
+
+func isRef(node *yaml.Node) bool {
+	return mappingValue(node, "$ref") != nil
+}
+
+func sequenceHasScalar(seq *yaml.Node, value string) bool {
+	for _, item := range seq.Content {
+		if item.Kind == yaml.ScalarNode && item.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func parameterKey(node *yaml.Node) (string, bool) {
+	in := mappingValue(node, "in")
+	name := mappingValue(node, "name")
+	if in == nil || name == nil {
+		return "", false
+	}
+	return in.Value + "." + name.Value, true
+}
+
+func findParameter(seq *yaml.Node, key string) *yaml.Node {
+	for _, item := range seq.Content {
+		if itemKey, ok := parameterKey(item); ok && itemKey == key {
+			return item
+		}
+	}
+	return nil
+}
+
+func joinField(at string, key string) string {
+	if at == "" {
+		return key
+	}
+	return at + "." + key
+}
+
+func pascalCase(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' ' || r == '.'
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,381 @@
+package specmerge
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func parseNode(t *testing.T, content string) *yaml.Node {
+	t.Helper()
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		t.Fatalf("parse yaml: %v", err)
+	}
+	return &root
+}
+
+func encodeNode(t *testing.T, node *yaml.Node) string {
+	t.Helper()
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		t.Fatalf("encode yaml: %v", err)
+	}
+	return buf.String()
+}
+
+const baseSpec = `
+openapi: 3.0.3
+paths:
+  /v1/bots/{bot_id}:
+    get:
+      operationId: GetBot
+      description: curated
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Bot'
+  /v1/zoo:
+    get:
+      operationId: Zoo
+components:
+  schemas:
+    Bot:
+      type: object
+      properties:
+        name:
+          title: curated name
+          type: string
+        mode:
+          type: string
+          enum: [a, b]
+    Detail:
+      type: object
+      required: [logid]
+      properties:
+        logid:
+          type: string
+`
+
+const syncedBot = `
+paths:
+  /v1/bots/:bot_id:
+    get:
+      operationId: CozeGetbot
+      description: synced
+      tags: [developer_guides]
+      parameters:
+        - in: path
+          name: bot_id
+          required: true
+          description: synced id
+          schema:
+            type: string
+        - in: header
+          name: Authorization
+          schema:
+            type: string
+        - in: query
+          name: locale
+          schema:
+            type: string
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Bot'
+components:
+  schemas:
+    Bot:
+      type: object
+      properties:
+        name:
+          description: synced name
+          type: string
+        description:
+          type: string
+        mode:
+          type: string
+          enum: [a, b, c]
+`
+
+const syncedMember = `
+paths:
+  /v1/members/:member_id:
+    post:
+      operationId: CozePostmember
+      parameters:
+        - in: header
+          name: Content-Type
+          schema:
+            type: string
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  detail:
+                    $ref: '#/components/schemas/ResponseDetail'
+                  bot:
+                    $ref: '#/components/schemas/Bot'
+components:
+  schemas:
+    ResponseDetail:
+      type: object
+      required: [logid]
+      properties:
+        logid:
+          type: string
+          description: docs differ
+    Bot:
+      type: object
+      properties:
+        id:
+          type: integer
+`
+
+func TestMerge(t *testing.T) {
+	base := parseNode(t, baseSpec)
+	result, err := Merge(base, []Source{
+		{Name: "retrieve_bot", Root: parseNode(t, syncedBot)},
+		{Name: "add_member", Root: parseNode(t, syncedMember)},
+	})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+
+	wantOps := []OperationChange{
+		{
+			Operation: "GET /v1/bots/{bot_id}",
+			Source:    "retrieve_bot",
+			Kind:      OperationChanged,
+			Fields:    []string{"parameters[query.locale]"},
+		},
+		{Operation: "POST /v1/members/{member_id}", Source: "add_member", Kind: OperationAdded},
+	}
+	if !reflect.DeepEqual(result.Operations, wantOps) {
+		t.Fatalf("operations = %+v, want %+v", result.Operations, wantOps)
+	}
+	wantSchemas := []SchemaRename{
+		{Source: "add_member", From: "ResponseDetail", To: "Detail"},
+		{Source: "add_member", From: "Bot", To: "AddMemberBot"},
+	}
+	if !reflect.DeepEqual(result.Schemas, wantSchemas) {
+		t.Fatalf("schemas = %+v, want %+v", result.Schemas, wantSchemas)
+	}
+
+	merged := encodeNode(t, base)
+	for _, want := range []string{
+		"operationId: GetBot",
+		"description: curated",
+		"title: curated name",
+		"$ref: '#/components/schemas/Detail'",
+		"$ref: '#/components/schemas/AddMemberBot'",
+		"AddMemberBot:",
+	} {
+		if !strings.Contains(merged, want) {
+			t.Fatalf("merged spec missing %q:\n%s", want, merged)
+		}
+	}
+	for _, unwanted := range []string{"CozeGetbot", "synced id", "Authorization", "Content-Type", "developer_guides", "ResponseDetail:"} {
+		if strings.Contains(merged, unwanted) {
+			t.Fatalf("merged spec should not contain %q:\n%s", unwanted, merged)
+		}
+	}
+	if strings.Index(merged, "/v1/members/{member_id}") > strings.Index(merged, "/v1/zoo") {
+		t.Fatalf("expected new path inserted in sorted order:\n%s", merged)
+	}
+
+	bot := mappingValue(mappingValue(mappingValue(documentRoot(base), "components"), "schemas"), "Bot")
+	properties := mappingValue(bot, "properties")
+	if mappingValue(properties, "description") != nil {
+		t.Fatalf("schemas already in the spec should not be merged: %s", encodeNode(t, bot))
+	}
+}
+
+func TestMergeExistingSchemaGainsFieldsThroughOperation(t *testing.T) {
+	base := parseNode(t, `
+paths:
+  /v1/chat:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                mode:
+                  type: string
+                  enum: [a]
+`)
+	synced := parseNode(t, `
+paths:
+  /v1/chat:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                description:
+                  type: string
+                mode:
+                  type: string
+                  enum: [a, b]
+`)
+	result, err := Merge(base, []Source{{Name: "chat", Root: synced}})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	want := []string{
+		"requestBody.content.application/json.schema.properties.description",
+		"requestBody.content.application/json.schema.properties.mode.enum[b]",
+	}
+	if !reflect.DeepEqual(result.Operations[0].Fields, want) {
+		t.Fatalf("fields = %v, want %v", result.Operations[0].Fields, want)
+	}
+}
+
+func TestMergeKeepsInlineSchemaAgainstRef(t *testing.T) {
+	base := parseNode(t, `
+paths:
+  /v1/bot:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data:
+                    type: object
+`)
+	synced := parseNode(t, `
+paths:
+  /v1/bot:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data:
+                    $ref: '#/components/schemas/Bot'
+components:
+  schemas:
+    Bot:
+      type: object
+`)
+	result, err := Merge(base, []Source{{Name: "bot", Root: synced}})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if result.Operations[0].Kind != OperationUnchanged {
+		t.Fatalf("expected operation to be unchanged, got %+v", result.Operations[0])
+	}
+	if merged := encodeNode(t, base); strings.Contains(merged, "$ref") {
+		t.Fatalf("expected inline data schema to be kept:\n%s", merged)
+	}
+}
+
+func TestMergeRenamesRecursiveSchemaRefs(t *testing.T) {
+	base := parseNode(t, `
+paths: {}
+components:
+  schemas:
+    Node:
+      type: string
+`)
+	synced := parseNode(t, `
+paths:
+  /v1/bots/tree:
+    get:
+      responses:
+        '200':
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Node'
+components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        child:
+          $ref: '#/components/schemas/Node'
+`)
+	result, err := Merge(base, []Source{{Name: "bots", Root: synced}})
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	want := []SchemaRename{{Source: "bots", From: "Node", To: "BotsNode"}}
+	if !reflect.DeepEqual(result.Schemas, want) {
+		t.Fatalf("schemas = %v, want %v", result.Schemas, want)
+	}
+	node := mappingValue(mappingValue(mappingValue(documentRoot(base), "components"), "schemas"), "BotsNode")
+	child := mappingValue(mappingValue(mappingValue(node, "properties"), "child"), "$ref")
+	if child == nil || child.Value != "#/components/schemas/BotsNode" {
+		t.Fatalf("expected self-ref to follow the rename:\n%s", encodeNode(t, base))
+	}
+}
+
+func TestFingerprintIncludesRequired(t *testing.T) {
+	schema := func(required string) string {
+		return fingerprint(documentRoot(parseNode(t, "type: object\nproperties: {a: {type: string}, b: {type: string}}\n"+required)))
+	}
+	if schema("required: [a, b]") != schema("required: [b, a]") {
+		t.Fatal("expected required order to be ignored")
+	}
+	if schema("required: [a]") == schema("") {
+		t.Fatal("expected required fields to change the fingerprint")
+	}
+}
+
+func TestMergeRejectsNonMappingSource(t *testing.T) {
+	_, err := Merge(parseNode(t, "openapi: 3.0.3\n"), []Source{{Name: "broken", Root: parseNode(t, "- a\n")}})
+	if err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Fatalf("expected error naming the source, got %v", err)
+	}
+}
+
+func TestReportWriteText(t *testing.T) {
+	report := NewReport(Result{
+		Operations: []OperationChange{
+			{Operation: "GET /v1/a", Source: "a", Kind: OperationAdded},
+			{Operation: "GET /v1/b", Source: "b", Kind: OperationChanged, Fields: []string{"x-coze-source"}},
+			{Operation: "GET /v1/c", Source: "c", Kind: OperationUnchanged},
+		},
+		Schemas: []SchemaRename{{Source: "b", From: "Bot", To: "BBot"}},
+	})
+	var buf bytes.Buffer
+	if err := report.WriteText(&buf); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	want := "added GET /v1/a (a)\n" +
+		"changed GET /v1/b (b): x-coze-source\n" +
+		"schema Bot -> BBot (b)\n" +
+		"added=1 changed=1 unchanged=1 renamed_schemas=1\n"
+	if buf.String() != want {
+		t.Fatalf("WriteText() = %q, want %q", buf.String(), want)
+	}
+}